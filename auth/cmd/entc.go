@@ -64,6 +64,7 @@ func run() error {
 	opts := []entc.Option{
 		entc.Extensions(ex),
 		entc.FeatureNames(features...),
+		entc.TemplateFiles("./ent/template/entity.tmpl"), // Federation entity markers for gqlgen
 	}
 
 	// Run Ent code generation with custom config
@@ -2,11 +2,13 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/joho/godotenv"
+	"github.com/saurabh/entgo-microservices/pkg/jwt"
 )
 
 type Config struct {
@@ -17,6 +19,8 @@ type Config struct {
 	Server   ServerConfig
 	GraphQL  GraphQLConfig
 	Logging  LoggingConfig
+	Mail     MailConfig
+	Account  AccountConfig
 }
 
 type AppConfig struct {
@@ -48,8 +52,11 @@ type RedisConfig struct {
 }
 
 type JWTConfig struct {
-	Secret      string
-	ExpiryHours int
+	Secret                string
+	ExpiryHours           int
+	GatewayIdentitySecret string // Shared with the gateway to trust its signed identity header
+	KeysFile              string // RS256/EdDSA key ring, see jwt.LoadKeyRing; empty signs with Secret
+	KeyOverlapHours       int    // How long a superseded key keeps verifying tokens
 }
 
 type ServerConfig struct {
@@ -58,6 +65,8 @@ type ServerConfig struct {
 	ReadTimeout  int
 	WriteTimeout int
 	IdleTimeout  int
+	// Proxies whose X-Forwarded-For is trusted for the client IP, e.g. the gateway; none by default
+	TrustedProxies []string
 }
 
 type GraphQLConfig struct {
@@ -66,6 +75,24 @@ type GraphQLConfig struct {
 	MaxDepth          int
 }
 
+type MailConfig struct {
+	Driver       string // smtp, file, log or memory
+	From         string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	FileDir      string // Where the file driver writes emails
+}
+
+type AccountConfig struct {
+	AppURL                 string // Frontend base URL; emails link to {AppURL}/reset-password and {AppURL}/verify-email
+	PasswordResetMinutes   int    // How long a password reset link is valid
+	EmailVerificationHours int    // How long an email verification link is valid
+	MfaIssuer              string // Account issuer shown by authenticator apps
+	MfaChallengeMinutes    int    // How long the MFA step of a login may take
+}
+
 type LoggingConfig struct {
 	Level      string
 	LogDir     string
@@ -118,6 +145,10 @@ func Load() (*Config, error) {
 		JWT: JWTConfig{
 			Secret:      getEnv("JWT_SECRET", "your-secret-key"),
 			ExpiryHours: getEnvInt("JWT_EXPIRY_HOURS", 24),
+
+			GatewayIdentitySecret: getEnv("GATEWAY_IDENTITY_SECRET", ""),
+			KeysFile:              getEnv("JWT_KEYS_FILE", ""),
+			KeyOverlapHours:       getEnvInt("JWT_KEY_OVERLAP_HOURS", jwt.RefreshExpiryDays*24),
 		},
 		Server: ServerConfig{
 			Host:         getEnv("SERVER_HOST", "localhost"),
@@ -125,6 +156,15 @@ func Load() (*Config, error) {
 			ReadTimeout:  getEnvInt("SERVER_READ_TIMEOUT", 15),
 			WriteTimeout: getEnvInt("SERVER_WRITE_TIMEOUT", 15),
 			IdleTimeout:  getEnvInt("SERVER_IDLE_TIMEOUT", 60),
+			TrustedProxies: func() []string {
+				var proxies []string
+				for _, part := range strings.Split(getEnv("TRUSTED_PROXIES", ""), ",") {
+					if part = strings.TrimSpace(part); part != "" {
+						proxies = append(proxies, part)
+					}
+				}
+				return proxies
+			}(),
 		},
 		GraphQL: GraphQLConfig{
 			PlaygroundEnabled: getEnvBool("GRAPHQL_PLAYGROUND", true),
@@ -139,6 +179,22 @@ func Load() (*Config, error) {
 			MaxAge:     getEnvInt("LOG_MAX_AGE", 30),
 			Compress:   getEnvBool("LOG_COMPRESS", true),
 		},
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", "log"),
+			From:         getEnv("MAIL_FROM", "no-reply@localhost"),
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnvInt("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			FileDir:      getEnv("MAIL_FILE_DIR", "mail"),
+		},
+		Account: AccountConfig{
+			AppURL:                 strings.TrimSuffix(getEnv("APP_URL", "http://localhost:3000"), "/"),
+			PasswordResetMinutes:   getEnvInt("PASSWORD_RESET_TTL_MINUTES", 60),
+			EmailVerificationHours: getEnvInt("EMAIL_VERIFICATION_TTL_HOURS", 48),
+			MfaIssuer:              getEnv("MFA_ISSUER", getEnv("APP_NAME", "MyApp")),
+			MfaChallengeMinutes:    getEnvInt("MFA_CHALLENGE_TTL_MINUTES", 5),
+		},
 	}
 
 	return cfg, nil
@@ -220,18 +276,30 @@ func (c *Config) Validate() error {
 		errors = append(errors, "REDIS_DB must be between 0 and 15")
 	}
 
-	// Validate JWT config
-	if c.JWT.Secret == "" {
-		errors = append(errors, "JWT_SECRET is required and cannot be empty")
-	}
-	if len(c.JWT.Secret) < 32 {
-		errors = append(errors, "JWT_SECRET must be at least 32 characters for security")
+	// Validate JWT config; the secret is only needed without a key ring
+	if c.JWT.KeysFile == "" {
+		if c.JWT.Secret == "" {
+			errors = append(errors, "JWT_SECRET is required and cannot be empty")
+		}
+		if len(c.JWT.Secret) < 32 {
+			errors = append(errors, "JWT_SECRET must be at least 32 characters for security")
+		}
+	} else if c.JWT.KeyOverlapHours < jwt.RefreshExpiryDays*24 {
+		// A retired key must verify the refresh tokens it signed until they expire
+		errors = append(errors, fmt.Sprintf("JWT_KEY_OVERLAP_HOURS must be at least the refresh token lifetime (%d hours)", jwt.RefreshExpiryDays*24))
 	}
 	if c.JWT.ExpiryHours <= 0 {
 		errors = append(errors, "JWT_EXPIRY_HOURS must be greater than 0")
 	}
 
 	// Validate Server config
+	for _, proxy := range c.Server.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				errors = append(errors, fmt.Sprintf("TRUSTED_PROXIES entry %q is not an IP address or CIDR range", proxy))
+			}
+		}
+	}
 	if c.Server.Port <= 0 || c.Server.Port > 65535 {
 		errors = append(errors, "SERVER_PORT must be between 1 and 65535")
 	}
@@ -275,6 +343,36 @@ func (c *Config) Validate() error {
 		errors = append(errors, "LOG_MAX_AGE cannot be negative")
 	}
 
+	// Validate Mail config
+	validDrivers := []string{"smtp", "file", "log", "memory"}
+	validDriver := false
+	for _, driver := range validDrivers {
+		if c.Mail.Driver == driver {
+			validDriver = true
+			break
+		}
+	}
+	if !validDriver {
+		errors = append(errors, fmt.Sprintf("MAIL_DRIVER must be one of: %s", strings.Join(validDrivers, ", ")))
+	}
+	if c.Mail.Driver == "smtp" && c.Mail.SMTPHost == "" {
+		errors = append(errors, "SMTP_HOST is required when MAIL_DRIVER is smtp")
+	}
+	if c.Mail.From == "" {
+		errors = append(errors, "MAIL_FROM is required")
+	}
+
+	// Validate Account config
+	if c.Account.PasswordResetMinutes <= 0 {
+		errors = append(errors, "PASSWORD_RESET_TTL_MINUTES must be greater than 0")
+	}
+	if c.Account.EmailVerificationHours <= 0 {
+		errors = append(errors, "EMAIL_VERIFICATION_TTL_HOURS must be greater than 0")
+	}
+	if c.Account.MfaChallengeMinutes <= 0 {
+		errors = append(errors, "MFA_CHALLENGE_TTL_MINUTES must be greater than 0")
+	}
+
 	// Return all validation errors
 	if len(errors) > 0 {
 		return fmt.Errorf("configuration validation failed:\n  - %s", strings.Join(errors, "\n  - "))
@@ -56,6 +56,9 @@ func (Role) Fields() []ent.Field {
 		field.Int("priority").
 			Default(0).
 			Comment("Role priority for hierarchy (higher number = higher priority)"),
+		field.Bool("mfa_required").
+			Default(false).
+			Comment("Whether users with the role must log in with multi-factor authentication"),
 	}
 }
 
@@ -61,6 +61,9 @@ func (Tenant) Fields() []ent.Field {
 			Default(true).
 			Comment("Whether the tenant is currently active").
 			Annotations(entgql.OrderField("IS_ACTIVE")),
+		field.Bool("mfa_required").
+			Default(false).
+			Comment("Whether users of the tenant must log in with multi-factor authentication"),
 	}
 }
 
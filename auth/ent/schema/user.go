@@ -90,6 +90,22 @@ func (User) Fields() []ent.Field {
 		field.Time("last_login").
 			Optional().
 			Nillable(),
+
+		// Multi-factor authentication, changed only through the MFA mutations
+		field.Bool("mfa_enabled").
+			Default(false).
+			Comment("Whether login requires a TOTP or recovery code").
+			Annotations(entgql.Skip(entgql.SkipMutationCreateInput, entgql.SkipMutationUpdateInput)),
+		field.String("mfa_secret").
+			Optional().
+			Sensitive().
+			Comment("Base32 TOTP secret").
+			Annotations(entgql.Skip()),
+		field.JSON("mfa_recovery_codes", []string{}).
+			Optional().
+			Sensitive().
+			Comment("Bcrypt hashes of the unused recovery codes").
+			Annotations(entgql.Skip()),
 	}
 }
 
@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/saurabh/entgo-microservices/auth/internal/ent"
+	"github.com/saurabh/entgo-microservices/pkg/mail"
+)
+
+// Purposes of single-use account tokens, also their Redis key types
+const (
+	passwordResetPurpose     = "password_reset"
+	emailVerificationPurpose = "email_verification"
+)
+
+// newAccountToken creates a random single-use token for an account email link
+func newAccountToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// accountLink builds the frontend link an account email points to
+func (r *mutationResolver) accountLink(path, token string) string {
+	return fmt.Sprintf("%s/%s?token=%s", r.accounts.AppURL, path, url.QueryEscape(token))
+}
+
+// sendPasswordResetEmail emails a user a link to reset their password
+func (r *mutationResolver) sendPasswordResetEmail(ctx context.Context, userEntity *ent.User) error {
+	token, err := newAccountToken()
+	if err != nil {
+		return err
+	}
+	if err := r.tokens.SaveOneTimeToken(ctx, passwordResetPurpose, token, strconv.Itoa(userEntity.ID), r.accounts.ResetTTL); err != nil {
+		return fmt.Errorf("failed to save password reset token: %w", err)
+	}
+
+	return r.accounts.Mailer.Send(ctx, &mail.Message{
+		To:      userEntity.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse this link to choose a new password:\n\n%s\n\n"+
+			"The link expires in %s and works once. If you did not ask to reset your password, ignore this email.\n",
+			userEntity.Name, r.accountLink("reset-password", token), r.accounts.ResetTTL),
+	})
+}
+
+// sendVerificationEmail emails a user a link confirming they own their email address
+func (r *mutationResolver) sendVerificationEmail(ctx context.Context, userEntity *ent.User) error {
+	token, err := newAccountToken()
+	if err != nil {
+		return err
+	}
+	// The token verifies the address it was sent to, not one the user changed to since
+	value := verificationValue(userEntity.ID, userEntity.Email)
+	if err := r.tokens.SaveOneTimeToken(ctx, emailVerificationPurpose, token, value, r.accounts.VerificationTTL); err != nil {
+		return fmt.Errorf("failed to save email verification token: %w", err)
+	}
+
+	return r.accounts.Mailer.Send(ctx, &mail.Message{
+		To:      userEntity.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm your email address with this link:\n\n%s\n\nThe link expires in %s and works once.\n",
+			userEntity.Name, r.accountLink("verify-email", token), r.accounts.VerificationTTL),
+	})
+}
+
+// verificationValue is what an email verification token redeems for: the user and the address it was sent to
+func verificationValue(userID int, email string) string {
+	return fmt.Sprintf("%d:%s", userID, email)
+}
+
+// parseVerificationValue splits the value of an email verification token
+func parseVerificationValue(value string) (int, string, error) {
+	id, email, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, "", fmt.Errorf("malformed email verification token value")
+	}
+	userID, err := strconv.Atoi(id)
+	return userID, email, err
+}
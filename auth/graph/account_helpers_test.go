@@ -0,0 +1,168 @@
+package graph
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/saurabh/entgo-microservices/auth/graph/model"
+	"github.com/saurabh/entgo-microservices/pkg/authz"
+	pkgcontext "github.com/saurabh/entgo-microservices/pkg/context"
+	"github.com/saurabh/entgo-microservices/pkg/jwt"
+	"github.com/saurabh/entgo-microservices/pkg/mail"
+)
+
+var linkToken = regexp.MustCompile(`\?token=(\S+)`)
+
+// waitForMail returns the token linked from the last email to an address, waiting for emails sent in the background
+func waitForMail(t *testing.T, mailer *mail.MemoryMailer, to string) string {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if msg := mailer.Last(to); msg != nil {
+			match := linkToken.FindStringSubmatch(msg.Body)
+			if match == nil {
+				t.Fatalf("email to %s has no token link:\n%s", to, msg.Body)
+			}
+			token, err := url.QueryUnescape(match[1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return token
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("no email sent to %s", to)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRequestPasswordResetSendsToken(t *testing.T) {
+	ctx := context.Background()
+	r, mailer := newTestResolver(t)
+	alice := createUser(t, r, "acme", "alice")
+
+	if ok, err := r.RequestPasswordReset(ctx, alice.Email); !ok || err != nil {
+		t.Fatalf("RequestPasswordReset = %v, %v", ok, err)
+	}
+	token := waitForMail(t, mailer, alice.Email)
+
+	if msg := mailer.Last(alice.Email); msg.Subject != "Reset your password" {
+		t.Errorf("subject = %q", msg.Subject)
+	}
+	if ok, err := r.ResetPassword(ctx, model.ResetPasswordInput{Token: token, NewPassword: "new password"}); !ok || err != nil {
+		t.Fatalf("ResetPassword = %v, %v", ok, err)
+	}
+
+	updated, err := r.client.User.Get(authz.SetBypass(ctx, true), alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !jwt.CheckPassword("new password", updated.PasswordHash) {
+		t.Error("password was not changed")
+	}
+}
+
+func TestRequestPasswordResetIgnoresUnknownAddresses(t *testing.T) {
+	ctx := context.Background()
+	r, mailer := newTestResolver(t)
+	alice := createUser(t, r, "acme", "alice")
+
+	// The response is the same whether or not the address has an account
+	if ok, err := r.RequestPasswordReset(ctx, "nobody@example.com"); !ok || err != nil {
+		t.Fatalf("RequestPasswordReset = %v, %v", ok, err)
+	}
+	// Emails are sent in the background, so one to a known address shows when the earlier request is done
+	if _, err := r.RequestPasswordReset(ctx, alice.Email); err != nil {
+		t.Fatal(err)
+	}
+	waitForMail(t, mailer, alice.Email)
+
+	for _, msg := range mailer.Messages() {
+		if msg.To != alice.Email {
+			t.Errorf("email sent to %s", msg.To)
+		}
+	}
+}
+
+func TestResetPasswordTokenWorksOnce(t *testing.T) {
+	ctx := context.Background()
+	r, mailer := newTestResolver(t)
+	alice := createUser(t, r, "acme", "alice")
+
+	if _, err := r.RequestPasswordReset(ctx, alice.Email); err != nil {
+		t.Fatal(err)
+	}
+	token := waitForMail(t, mailer, alice.Email)
+
+	if _, err := r.ResetPassword(ctx, model.ResetPasswordInput{Token: token, NewPassword: "first"}); err != nil {
+		t.Fatalf("first reset: %v", err)
+	}
+	if ok, err := r.ResetPassword(ctx, model.ResetPasswordInput{Token: token, NewPassword: "second"}); ok || err == nil {
+		t.Fatal("reset token was accepted twice")
+	}
+
+	updated, err := r.client.User.Get(authz.SetBypass(ctx, true), alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !jwt.CheckPassword("first", updated.PasswordHash) {
+		t.Error("second reset changed the password")
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	r, mailer := newTestResolver(t)
+	alice := createUser(t, r, "acme", "alice")
+	ctx := pkgcontext.SetUser(context.Background(), &pkgcontext.User{ID: alice.ID})
+
+	if _, err := r.SendVerificationEmail(ctx); err != nil {
+		t.Fatal(err)
+	}
+	token := waitForMail(t, mailer, alice.Email)
+
+	if ok, err := r.VerifyEmail(context.Background(), token); !ok || err != nil {
+		t.Fatalf("VerifyEmail = %v, %v", ok, err)
+	}
+	updated, err := r.client.User.Get(authz.SetBypass(ctx, true), alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !updated.EmailVerified {
+		t.Error("email is not verified")
+	}
+	if ok, err := r.VerifyEmail(context.Background(), token); ok || err == nil {
+		t.Error("verification token was accepted twice")
+	}
+}
+
+func TestVerifyEmailRejectsChangedAddress(t *testing.T) {
+	r, mailer := newTestResolver(t)
+	alice := createUser(t, r, "acme", "alice")
+	ctx := pkgcontext.SetUser(context.Background(), &pkgcontext.User{ID: alice.ID})
+
+	if _, err := r.SendVerificationEmail(ctx); err != nil {
+		t.Fatal(err)
+	}
+	token := waitForMail(t, mailer, alice.Email)
+
+	// The link verifies the old address, not the one the user changed to
+	bypass := authz.SetBypass(ctx, true)
+	if _, err := r.client.User.UpdateOneID(alice.ID).SetEmail("alice@example.org").Save(bypass); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := r.VerifyEmail(context.Background(), token); ok || err == nil {
+		t.Fatal("token for the old address was accepted")
+	}
+
+	updated, err := r.client.User.Get(bypass, alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.EmailVerified {
+		t.Error("changed address was marked verified")
+	}
+}
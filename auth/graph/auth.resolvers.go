@@ -7,8 +7,11 @@ package graph
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/saurabh/entgo-microservices/auth/graph/model"
 	"github.com/saurabh/entgo-microservices/auth/internal/ent"
@@ -18,6 +21,7 @@ import (
 	pkgcontext "github.com/saurabh/entgo-microservices/pkg/context"
 	"github.com/saurabh/entgo-microservices/pkg/jwt"
 	"github.com/saurabh/entgo-microservices/pkg/logger"
+	"github.com/saurabh/entgo-microservices/pkg/totp"
 )
 
 // Login is the resolver for the login field.
@@ -51,23 +55,17 @@ func (r *mutationResolver) Login(ctx context.Context, input model.LoginInput) (*
 		return nil, fmt.Errorf("invalid credentials")
 	}
 
-	// Generate tokens
-	accessToken, refreshToken, err := r.jwtService.GenerateTokenPair(ctx, userEntity.ID, userEntity.Username, userEntity.Email)
+	// A second factor is asked for when the user enrolled, or their role or tenant requires one
+	required, err := r.mfaRequired(ctx, userEntity)
 	if err != nil {
-		logger.WithError(err).Error("Failed to generate tokens")
+		logger.WithError(err).WithField("user_id", userEntity.ID).Error("Failed to check MFA requirement")
 		return nil, fmt.Errorf("login failed")
 	}
+	if userEntity.MfaEnabled || required {
+		return r.mfaChallenge(ctx, userEntity)
+	}
 
-	// Cache user data asynchronously
-	r.cacheUserData(userEntity)
-
-	logger.WithFields(map[string]interface{}{"user_id": userEntity.ID, "email": userEntity.Email}).Info("User logged in")
-
-	return &model.LoginResponse{
-		User:         userEntity,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	}, nil
+	return r.completeLogin(ctx, userEntity)
 }
 
 // Register is the resolver for the register field.
@@ -121,6 +119,13 @@ func (r *mutationResolver) Register(ctx context.Context, input model.RegisterInp
 	// Cache user data asynchronously
 	r.cacheUserData(userEntity)
 
+	// Ask the user to verify their address
+	go func() {
+		if err := r.sendVerificationEmail(context.Background(), userEntity); err != nil {
+			logger.WithError(err).WithField("user_id", userEntity.ID).Warn("Failed to send verification email")
+		}
+	}()
+
 	logger.WithFields(map[string]interface{}{"user_id": userEntity.ID, "email": userEntity.Email}).Info("User registered")
 
 	return &model.RegisterResponse{
@@ -138,15 +143,24 @@ func (r *mutationResolver) Logout(ctx context.Context) (*model.LogoutResponse, e
 	}
 
 	// Validate token to get user ID for cache invalidation
-	if claims, err := r.jwtService.ValidateToken(ctx, tokenString); err == nil && claims != nil {
+	claims, err := r.jwtService.ValidateToken(ctx, tokenString)
+	if err == nil && claims != nil {
 		// Invalidate user cache
 		if err := pkgcache.InvalidateUserCache(ctx, r.redisClient, "auth", claims.UserID); err != nil {
 			logger.WithError(err).WithField("user_id", claims.UserID).Warn("Failed to invalidate user cache")
 		}
 	}
 
-	// Add token to blacklist
-	if err := r.jwtService.RevokeToken(ctx, tokenString); err != nil {
+	// End the token's session, revoking its refresh token too; tokens issued before sessions are revoked alone
+	err = jwt.ErrSessionNotFound
+	if claims != nil && claims.Family != "" {
+		err = r.jwtService.RevokeSession(ctx, claims.UserID, claims.Family)
+	}
+	if errors.Is(err, jwt.ErrSessionNotFound) {
+		// Add token to blacklist
+		err = r.jwtService.RevokeToken(ctx, tokenString)
+	}
+	if err != nil {
 		logger.WithError(err).Error("Failed to revoke token during logout")
 		return nil, fmt.Errorf("logout failed")
 	}
@@ -163,15 +177,8 @@ func (r *mutationResolver) RefreshToken(ctx context.Context) (*model.TokenRespon
 		return nil, err
 	}
 
-	// Validate refresh token to get user ID
-	claims, err := r.jwtService.ValidateToken(ctx, tokenString)
-	if err != nil {
-		logger.WithError(err).Error("Failed to validate refresh token")
-		return nil, fmt.Errorf("token refresh failed: %v", err)
-	}
-
-	// Generate new token pair
-	accessToken, refreshToken, err := r.jwtService.RefreshAccessToken(ctx, tokenString)
+	// Exchange the refresh token for a new pair; the presented token is revoked
+	accessToken, refreshToken, claims, err := r.jwtService.RefreshAccessToken(ctx, tokenString)
 	if err != nil {
 		logger.WithError(err).Error("Failed to refresh token")
 		return nil, fmt.Errorf("token refresh failed: %v", err)
@@ -195,6 +202,475 @@ func (r *mutationResolver) RefreshToken(ctx context.Context) (*model.TokenRespon
 	}, nil
 }
 
+// LogoutEverywhere is the resolver for the logoutEverywhere field.
+func (r *mutationResolver) LogoutEverywhere(ctx context.Context) (*model.LogoutResponse, error) {
+	userCtx, ok := pkgcontext.GetUser(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user not authenticated")
+	}
+
+	if err := r.jwtService.RevokeAllUserTokens(ctx, userCtx.ID); err != nil {
+		logger.WithError(err).WithField("user_id", userCtx.ID).Error("Failed to revoke sessions during logout everywhere")
+		return nil, fmt.Errorf("logout failed")
+	}
+
+	if err := pkgcache.InvalidateUserCache(ctx, r.redisClient, "auth", userCtx.ID); err != nil {
+		logger.WithError(err).WithField("user_id", userCtx.ID).Warn("Failed to invalidate user cache")
+	}
+
+	logger.WithField("user_id", userCtx.ID).Info("User logged out everywhere")
+
+	return &model.LogoutResponse{Success: true}, nil
+}
+
+// RevokeSession is the resolver for the revokeSession field.
+func (r *mutationResolver) RevokeSession(ctx context.Context, id string) (*model.LogoutResponse, error) {
+	userCtx, ok := pkgcontext.GetUser(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user not authenticated")
+	}
+
+	// Users can only revoke their own sessions
+	if err := r.jwtService.RevokeSession(ctx, userCtx.ID, id); err != nil {
+		if errors.Is(err, jwt.ErrSessionNotFound) {
+			return nil, err
+		}
+		logger.WithError(err).WithField("user_id", userCtx.ID).Error("Failed to revoke session")
+		return nil, fmt.Errorf("failed to revoke session")
+	}
+
+	logger.WithFields(map[string]interface{}{"user_id": userCtx.ID, "session_id": id}).Info("Session revoked")
+
+	return &model.LogoutResponse{Success: true}, nil
+}
+
+// RevokeUserSessions is the resolver for the revokeUserSessions field.
+func (r *mutationResolver) RevokeUserSessions(ctx context.Context, userID int) (*model.RevokeSessionsResponse, error) {
+	// Admins manage users of their own tenant only; others are reported as not found
+	target, err := r.tenantUser(ctx, userID)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to get user for session revocation")
+		return nil, fmt.Errorf("failed to revoke sessions")
+	}
+	if target == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	revoked, err := r.jwtService.RevokeUserSessions(ctx, userID, "")
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to revoke user sessions")
+		return nil, fmt.Errorf("failed to revoke sessions")
+	}
+
+	if err := pkgcache.InvalidateUserCache(ctx, r.redisClient, "auth", userID); err != nil {
+		logger.WithError(err).WithField("user_id", userID).Warn("Failed to invalidate user cache")
+	}
+
+	fields := map[string]interface{}{"user_id": userID, "sessions": revoked}
+	if admin, ok := pkgcontext.GetUser(ctx); ok {
+		fields["admin_id"] = admin.ID
+	}
+	logger.WithFields(fields).Info("User sessions revoked by admin")
+
+	return &model.RevokeSessionsResponse{Revoked: revoked}, nil
+}
+
+// RequestPasswordReset is the resolver for the requestPasswordReset field.
+func (r *mutationResolver) RequestPasswordReset(ctx context.Context, email string) (bool, error) {
+	// Look up and email the user in the background, so the response does not reveal whether the address has an account
+	go func() {
+		bgCtx := authz.SetBypass(context.Background(), true)
+
+		userEntity, err := r.client.User.Query().Where(user.Email(email)).Only(bgCtx)
+		if err != nil {
+			if !ent.IsNotFound(err) {
+				logger.WithError(err).Error("Failed to query user for password reset")
+			}
+			return
+		}
+		if !userEntity.IsActive {
+			return
+		}
+
+		if err := r.sendPasswordResetEmail(bgCtx, userEntity); err != nil {
+			logger.WithError(err).WithField("user_id", userEntity.ID).Error("Failed to send password reset email")
+			return
+		}
+		logger.WithField("user_id", userEntity.ID).Info("Password reset requested")
+	}()
+
+	return true, nil
+}
+
+// ResetPassword is the resolver for the resetPassword field.
+func (r *mutationResolver) ResetPassword(ctx context.Context, input model.ResetPasswordInput) (bool, error) {
+	if input.NewPassword == "" {
+		return false, fmt.Errorf("password cannot be empty")
+	}
+
+	// Tokens work once, whether or not the reset succeeds
+	value, err := r.tokens.ConsumeOneTimeToken(ctx, passwordResetPurpose, input.Token)
+	if err != nil {
+		logger.WithError(err).Error("Failed to redeem password reset token")
+		return false, fmt.Errorf("password reset failed")
+	}
+	userID, err := strconv.Atoi(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid or expired token")
+	}
+
+	hashedPassword, err := jwt.HashPassword(input.NewPassword)
+	if err != nil {
+		logger.WithError(err).Error("Failed to hash password")
+		return false, fmt.Errorf("password reset failed")
+	}
+
+	ctx = authz.SetBypass(ctx, true)
+	if _, err := r.client.User.UpdateOneID(userID).SetPasswordHash(hashedPassword).Save(ctx); err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to reset password")
+		return false, fmt.Errorf("password reset failed")
+	}
+
+	// Whoever knew the old password is signed out
+	r.revokeSessionsAfterPasswordChange(ctx, userID)
+
+	logger.WithField("user_id", userID).Info("Password reset")
+
+	return true, nil
+}
+
+// SendVerificationEmail is the resolver for the sendVerificationEmail field.
+func (r *mutationResolver) SendVerificationEmail(ctx context.Context) (bool, error) {
+	userCtx, ok := pkgcontext.GetUser(ctx)
+	if !ok {
+		return false, fmt.Errorf("user not authenticated")
+	}
+
+	// Users verify their own address, whatever their role allows them to read
+	userEntity, err := r.client.User.Get(authz.SetBypass(ctx, true), userCtx.ID)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userCtx.ID).Error("Failed to get user for email verification")
+		return false, fmt.Errorf("failed to send verification email")
+	}
+	if userEntity.EmailVerified {
+		return false, fmt.Errorf("email is already verified")
+	}
+
+	if err := r.sendVerificationEmail(ctx, userEntity); err != nil {
+		logger.WithError(err).WithField("user_id", userEntity.ID).Error("Failed to send verification email")
+		return false, fmt.Errorf("failed to send verification email")
+	}
+
+	return true, nil
+}
+
+// VerifyEmail is the resolver for the verifyEmail field.
+func (r *mutationResolver) VerifyEmail(ctx context.Context, token string) (bool, error) {
+	value, err := r.tokens.ConsumeOneTimeToken(ctx, emailVerificationPurpose, token)
+	if err != nil {
+		logger.WithError(err).Error("Failed to redeem email verification token")
+		return false, fmt.Errorf("email verification failed")
+	}
+	userID, email, err := parseVerificationValue(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid or expired token")
+	}
+
+	ctx = authz.SetBypass(ctx, true)
+	userEntity, err := r.client.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return false, fmt.Errorf("invalid or expired token")
+		}
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to get user for email verification")
+		return false, fmt.Errorf("email verification failed")
+	}
+
+	// The address changed after the email was sent
+	if userEntity.Email != email {
+		return false, fmt.Errorf("invalid or expired token")
+	}
+	if userEntity.EmailVerified {
+		return true, nil
+	}
+
+	if _, err := userEntity.Update().SetEmailVerified(true).SetEmailVerifiedAt(time.Now()).Save(ctx); err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to mark email as verified")
+		return false, fmt.Errorf("email verification failed")
+	}
+
+	logger.WithFields(map[string]interface{}{"user_id": userID, "email": email}).Info("Email verified")
+
+	return true, nil
+}
+
+// VerifyMfa is the resolver for the verifyMfa field.
+func (r *mutationResolver) VerifyMfa(ctx context.Context, input model.VerifyMfaInput) (*model.LoginResponse, error) {
+	// The challenge stays valid across wrong codes, up to mfaMaxAttempts
+	value, err := r.tokens.PeekOneTimeToken(ctx, mfaChallengePurpose, input.MfaToken)
+	if err != nil {
+		logger.WithError(err).Error("Failed to read MFA challenge")
+		return nil, fmt.Errorf("MFA verification failed")
+	}
+	userID, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid or expired MFA token")
+	}
+
+	ctx = authz.SetBypass(ctx, true)
+	userEntity, err := r.client.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("invalid or expired MFA token")
+		}
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to get user for MFA verification")
+		return nil, fmt.Errorf("MFA verification failed")
+	}
+	if !userEntity.IsActive {
+		return nil, fmt.Errorf("account is deactivated")
+	}
+	// MFA was turned off by an admin since the challenge was issued
+	if !userEntity.MfaEnabled {
+		return nil, fmt.Errorf("invalid or expired MFA token")
+	}
+
+	ok, err := r.checkSecondFactor(ctx, userEntity, input.Code)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to check MFA code")
+		return nil, fmt.Errorf("MFA verification failed")
+	}
+	if !ok {
+		dropped, err := r.tokens.FailOneTimeToken(ctx, mfaChallengePurpose, input.MfaToken, mfaMaxAttempts, r.accounts.MfaChallengeTTL)
+		if err != nil {
+			logger.WithError(err).WithField("user_id", userID).Error("Failed to count MFA attempt")
+		}
+		logger.WithField("user_id", userID).Warn("Invalid MFA code")
+		if dropped {
+			return nil, fmt.Errorf("too many invalid codes, log in again")
+		}
+		return nil, fmt.Errorf("invalid code")
+	}
+
+	// Redeem the challenge, so a concurrent request with another code cannot log in too
+	if value, err := r.tokens.ConsumeOneTimeToken(ctx, mfaChallengePurpose, input.MfaToken); err != nil || value == "" {
+		if err != nil {
+			logger.WithError(err).WithField("user_id", userID).Error("Failed to redeem MFA challenge")
+		}
+		return nil, fmt.Errorf("invalid or expired MFA token")
+	}
+
+	return r.completeLogin(ctx, userEntity)
+}
+
+// EnrollMfa is the resolver for the enrollMfa field.
+func (r *mutationResolver) EnrollMfa(ctx context.Context, mfaToken *string) (*model.MfaEnrollment, error) {
+	userEntity, err := r.mfaUser(ctx, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		logger.WithError(err).Error("Failed to generate TOTP secret")
+		return nil, fmt.Errorf("MFA enrollment failed")
+	}
+
+	// The secret is only stored on the user once confirmMfa proves the app has it
+	if err := r.tokens.SaveOneTimeToken(ctx, mfaPendingSecretPurpose, strconv.Itoa(userEntity.ID), secret, mfaPendingTTL); err != nil {
+		logger.WithError(err).WithField("user_id", userEntity.ID).Error("Failed to save pending TOTP secret")
+		return nil, fmt.Errorf("MFA enrollment failed")
+	}
+
+	return &model.MfaEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(r.accounts.MfaIssuer, userEntity.Email, secret),
+	}, nil
+}
+
+// ConfirmMfa is the resolver for the confirmMfa field.
+func (r *mutationResolver) ConfirmMfa(ctx context.Context, code string, mfaToken *string) (*model.MfaConfirmation, error) {
+	userEntity, err := r.mfaUser(ctx, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := r.tokens.PeekOneTimeToken(ctx, mfaPendingSecretPurpose, strconv.Itoa(userEntity.ID))
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userEntity.ID).Error("Failed to read pending TOTP secret")
+		return nil, fmt.Errorf("MFA enrollment failed")
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("no MFA enrollment in progress")
+	}
+
+	ok, err := r.checkTotp(ctx, userEntity.ID, secret, code)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userEntity.ID).Error("Failed to check TOTP code")
+		return nil, fmt.Errorf("MFA enrollment failed")
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid code")
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		logger.WithError(err).Error("Failed to generate recovery codes")
+		return nil, fmt.Errorf("MFA enrollment failed")
+	}
+
+	ctx = authz.SetBypass(ctx, true)
+	updated, err := r.client.User.UpdateOneID(userEntity.ID).
+		SetMfaEnabled(true).
+		SetMfaSecret(secret).
+		SetMfaRecoveryCodes(hashes).
+		Save(ctx)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userEntity.ID).Error("Failed to enable MFA")
+		return nil, fmt.Errorf("MFA enrollment failed")
+	}
+	userEntity = updated
+
+	if _, err := r.tokens.ConsumeOneTimeToken(ctx, mfaPendingSecretPurpose, strconv.Itoa(userEntity.ID)); err != nil {
+		logger.WithError(err).WithField("user_id", userEntity.ID).Warn("Failed to delete pending TOTP secret")
+	}
+
+	logger.WithField("user_id", userEntity.ID).Info("MFA enabled")
+
+	confirmation := &model.MfaConfirmation{RecoveryCodes: codes}
+	if mfaToken == nil {
+		return confirmation, nil
+	}
+
+	// Enrolling finishes the login that required it. Without a login the recovery codes are still returned,
+	// since they are never shown again, and the user logs in with their new second factor
+	value, err := r.tokens.ConsumeOneTimeToken(ctx, mfaEnrollmentChallengePurpose, *mfaToken)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userEntity.ID).Error("Failed to redeem MFA enrollment challenge")
+	}
+	if value != "" {
+		if login, err := r.completeLogin(ctx, userEntity); err == nil {
+			confirmation.Login = login
+		}
+	}
+
+	return confirmation, nil
+}
+
+// DisableMfa is the resolver for the disableMfa field.
+func (r *mutationResolver) DisableMfa(ctx context.Context, code string) (bool, error) {
+	userEntity, err := r.enrolledUser(ctx, code)
+	if err != nil {
+		return false, err
+	}
+
+	required, err := r.mfaRequired(ctx, userEntity)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userEntity.ID).Error("Failed to check MFA requirement")
+		return false, fmt.Errorf("failed to disable MFA")
+	}
+	if required {
+		return false, fmt.Errorf("multi-factor authentication is required for your account")
+	}
+
+	if _, err := r.client.User.UpdateOneID(userEntity.ID).
+		SetMfaEnabled(false).
+		ClearMfaSecret().
+		ClearMfaRecoveryCodes().
+		Save(authz.SetBypass(ctx, true)); err != nil {
+		logger.WithError(err).WithField("user_id", userEntity.ID).Error("Failed to disable MFA")
+		return false, fmt.Errorf("failed to disable MFA")
+	}
+
+	logger.WithField("user_id", userEntity.ID).Info("MFA disabled")
+
+	return true, nil
+}
+
+// RegenerateRecoveryCodes is the resolver for the regenerateRecoveryCodes field.
+func (r *mutationResolver) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	userEntity, err := r.enrolledUser(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		logger.WithError(err).Error("Failed to generate recovery codes")
+		return nil, fmt.Errorf("failed to regenerate recovery codes")
+	}
+
+	// The new codes replace every old one, used or not
+	if _, err := r.client.User.UpdateOneID(userEntity.ID).SetMfaRecoveryCodes(hashes).Save(authz.SetBypass(ctx, true)); err != nil {
+		logger.WithError(err).WithField("user_id", userEntity.ID).Error("Failed to save recovery codes")
+		return nil, fmt.Errorf("failed to regenerate recovery codes")
+	}
+
+	logger.WithField("user_id", userEntity.ID).Info("MFA recovery codes regenerated")
+
+	return codes, nil
+}
+
+// DisableUserMfa is the resolver for the disableUserMfa field.
+func (r *mutationResolver) DisableUserMfa(ctx context.Context, userID int) (bool, error) {
+	// Admins manage users of their own tenant only; others are reported as not found
+	target, err := r.tenantUser(ctx, userID)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to get user for MFA reset")
+		return false, fmt.Errorf("failed to disable MFA")
+	}
+	if target == nil {
+		return false, fmt.Errorf("user not found")
+	}
+
+	if _, err := r.client.User.UpdateOneID(userID).
+		SetMfaEnabled(false).
+		ClearMfaSecret().
+		ClearMfaRecoveryCodes().
+		Save(authz.SetBypass(ctx, true)); err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to disable user MFA")
+		return false, fmt.Errorf("failed to disable MFA")
+	}
+
+	fields := map[string]interface{}{"user_id": userID}
+	if admin, ok := pkgcontext.GetUser(ctx); ok {
+		fields["admin_id"] = admin.ID
+	}
+	logger.WithFields(fields).Warn("User MFA disabled by admin")
+
+	return true, nil
+}
+
+// SetTenantMfaRequired is the resolver for the setTenantMfaRequired field.
+func (r *mutationResolver) SetTenantMfaRequired(ctx context.Context, tenantID int, required bool) (*ent.Tenant, error) {
+	admin, ok := pkgcontext.GetUser(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user not authenticated")
+	}
+
+	// Admins manage their own tenant only
+	adminEntity, err := r.client.User.Get(authz.SetBypass(ctx, true), admin.ID)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", admin.ID).Error("Failed to get admin for tenant update")
+		return nil, fmt.Errorf("failed to update tenant")
+	}
+	if adminEntity.TenantID != tenantID {
+		return nil, fmt.Errorf("forbidden")
+	}
+
+	tenantEntity, err := r.client.Tenant.UpdateOneID(tenantID).SetMfaRequired(required).Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("tenant not found")
+		}
+		logger.WithError(err).WithField("tenant_id", tenantID).Error("Failed to update tenant MFA requirement")
+		return nil, fmt.Errorf("failed to update tenant")
+	}
+
+	logger.WithFields(map[string]interface{}{"tenant_id": tenantID, "required": required, "admin_id": admin.ID}).Info("Tenant MFA requirement changed")
+
+	return tenantEntity, nil
+}
+
 // Me is the resolver for the me field.
 func (r *queryResolver) Me(ctx context.Context) (*ent.User, error) {
 	// Get user ID from context (set by auth middleware)
@@ -222,3 +698,32 @@ func (r *queryResolver) Me(ctx context.Context) (*ent.User, error) {
 
 	return userEntity, nil
 }
+
+// MySessions is the resolver for the mySessions field.
+func (r *queryResolver) MySessions(ctx context.Context) ([]*model.Session, error) {
+	userCtx, ok := pkgcontext.GetUser(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user not authenticated")
+	}
+
+	sessions, err := r.jwtService.Sessions(ctx, userCtx.ID)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userCtx.ID).Error("Failed to list sessions")
+		return nil, fmt.Errorf("failed to get sessions")
+	}
+
+	current := currentSessionID(ctx, r.jwtService)
+	result := make([]*model.Session, len(sessions))
+	for i, session := range sessions {
+		result[i] = &model.Session{
+			ID:        session.ID,
+			UserAgent: session.UserAgent,
+			IP:        session.IP,
+			IssuedAt:  session.IssuedAt,
+			LastSeen:  session.LastSeen,
+			Current:   session.ID == current,
+		}
+	}
+
+	return result, nil
+}
@@ -100,3 +100,60 @@ func extractToken(ctx context.Context) (string, error) {
 
 	return strings.TrimPrefix(tokenString, "Bearer "), nil
 }
+
+// currentSessionID returns the session of the request's token, or "" when it has none
+func currentSessionID(ctx context.Context, jwtService *jwt.Service) string {
+	tokenString, err := extractToken(ctx)
+	if err != nil {
+		return ""
+	}
+	claims, err := jwtService.ValidateToken(ctx, tokenString)
+	if err != nil {
+		return ""
+	}
+	return claims.Family
+}
+
+// revokeSessionsAfterPasswordChange revokes the sessions of a user whose password changed,
+// keeping the session the user changed it from
+func (r *mutationResolver) revokeSessionsAfterPasswordChange(ctx context.Context, userID int) {
+	var keep string
+	if userCtx, ok := pkgcontext.GetUser(ctx); ok && userCtx.ID == userID {
+		keep = currentSessionID(ctx, r.jwtService)
+	}
+
+	if _, err := r.jwtService.RevokeUserSessions(ctx, userID, keep); err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to revoke sessions after password change")
+		return
+	}
+
+	if err := pkgcache.InvalidateUserCache(ctx, r.redisClient, "auth", userID); err != nil {
+		logger.WithError(err).WithField("user_id", userID).Warn("Failed to invalidate user cache")
+	}
+}
+
+// tenantUser returns a user for an admin operation, or nil when the user does not exist or
+// belongs to another tenant than the calling admin
+func (r *mutationResolver) tenantUser(ctx context.Context, userID int) (*ent.User, error) {
+	admin, ok := pkgcontext.GetUser(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user not authenticated")
+	}
+
+	ctx = authz.SetBypass(ctx, true)
+	adminEntity, err := r.client.User.Get(ctx, admin.ID)
+	if err != nil {
+		return nil, err
+	}
+	userEntity, err := r.client.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if userEntity.TenantID != adminEntity.TenantID {
+		return nil, nil
+	}
+	return userEntity, nil
+}
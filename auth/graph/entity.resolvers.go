@@ -0,0 +1,108 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.84
+
+import (
+	"context"
+
+	"github.com/saurabh/entgo-microservices/auth/graph/model"
+	"github.com/saurabh/entgo-microservices/auth/internal/ent"
+	"github.com/saurabh/entgo-microservices/auth/internal/ent/brand"
+	"github.com/saurabh/entgo-microservices/auth/internal/ent/permission"
+	"github.com/saurabh/entgo-microservices/auth/internal/ent/role"
+	"github.com/saurabh/entgo-microservices/auth/internal/ent/rolepermission"
+	"github.com/saurabh/entgo-microservices/auth/internal/ent/tenant"
+	"github.com/saurabh/entgo-microservices/auth/internal/ent/user"
+)
+
+// FindManyBrandByIDs is the resolver for the findManyBrandByIDs field.
+func (r *entityResolver) FindManyBrandByIDs(ctx context.Context, reps []*model.BrandByIDsInput) ([]*ent.Brand, error) {
+	ids := make([]int, len(reps))
+	for i, rep := range reps {
+		ids[i] = rep.ID
+	}
+
+	entities, err := r.client.Brand.Query().Where(brand.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return alignByID(ids, entities, func(e *ent.Brand) int { return e.ID }), nil
+}
+
+// FindManyPermissionByIDs is the resolver for the findManyPermissionByIDs field.
+func (r *entityResolver) FindManyPermissionByIDs(ctx context.Context, reps []*model.PermissionByIDsInput) ([]*ent.Permission, error) {
+	ids := make([]int, len(reps))
+	for i, rep := range reps {
+		ids[i] = rep.ID
+	}
+
+	entities, err := r.client.Permission.Query().Where(permission.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return alignByID(ids, entities, func(e *ent.Permission) int { return e.ID }), nil
+}
+
+// FindManyRoleByIDs is the resolver for the findManyRoleByIDs field.
+func (r *entityResolver) FindManyRoleByIDs(ctx context.Context, reps []*model.RoleByIDsInput) ([]*ent.Role, error) {
+	ids := make([]int, len(reps))
+	for i, rep := range reps {
+		ids[i] = rep.ID
+	}
+
+	entities, err := r.client.Role.Query().Where(role.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return alignByID(ids, entities, func(e *ent.Role) int { return e.ID }), nil
+}
+
+// FindManyRolePermissionByIDs is the resolver for the findManyRolePermissionByIDs field.
+func (r *entityResolver) FindManyRolePermissionByIDs(ctx context.Context, reps []*model.RolePermissionByIDsInput) ([]*ent.RolePermission, error) {
+	ids := make([]int, len(reps))
+	for i, rep := range reps {
+		ids[i] = rep.ID
+	}
+
+	entities, err := r.client.RolePermission.Query().Where(rolepermission.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return alignByID(ids, entities, func(e *ent.RolePermission) int { return e.ID }), nil
+}
+
+// FindManyTenantByIDs is the resolver for the findManyTenantByIDs field.
+func (r *entityResolver) FindManyTenantByIDs(ctx context.Context, reps []*model.TenantByIDsInput) ([]*ent.Tenant, error) {
+	ids := make([]int, len(reps))
+	for i, rep := range reps {
+		ids[i] = rep.ID
+	}
+
+	entities, err := r.client.Tenant.Query().Where(tenant.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return alignByID(ids, entities, func(e *ent.Tenant) int { return e.ID }), nil
+}
+
+// FindManyUserByIDs is the resolver for the findManyUserByIDs field.
+func (r *entityResolver) FindManyUserByIDs(ctx context.Context, reps []*model.UserByIDsInput) ([]*ent.User, error) {
+	ids := make([]int, len(reps))
+	for i, rep := range reps {
+		ids[i] = rep.ID
+	}
+
+	entities, err := r.client.User.Query().Where(user.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return alignByID(ids, entities, func(e *ent.User) int { return e.ID }), nil
+}
+
+// Entity returns EntityResolver implementation.
+func (r *Resolver) Entity() EntityResolver { return &entityResolver{r} }
+
+type entityResolver struct{ *Resolver }
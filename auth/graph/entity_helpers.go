@@ -0,0 +1,16 @@
+package graph
+
+// alignByID orders batch lookup results like the requested ids, leaving nil for missing entities.
+// Federation entity resolvers must return exactly one entry per representation.
+func alignByID[T any](ids []int, entities []*T, id func(*T) int) []*T {
+	byID := make(map[int]*T, len(entities))
+	for _, e := range entities {
+		byID[id(e)] = e
+	}
+
+	aligned := make([]*T, len(ids))
+	for i, entityID := range ids {
+		aligned[i] = byID[entityID]
+	}
+	return aligned
+}
@@ -0,0 +1,293 @@
+package graph
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/saurabh/entgo-microservices/auth/graph/model"
+	"github.com/saurabh/entgo-microservices/auth/internal/ent"
+	"github.com/saurabh/entgo-microservices/auth/internal/ent/user"
+	"github.com/saurabh/entgo-microservices/pkg/authz"
+	pkgcontext "github.com/saurabh/entgo-microservices/pkg/context"
+	"github.com/saurabh/entgo-microservices/pkg/jwt"
+	"github.com/saurabh/entgo-microservices/pkg/logger"
+	"github.com/saurabh/entgo-microservices/pkg/totp"
+)
+
+// Purposes of MFA tokens and markers in Redis, also their key types
+const (
+	mfaChallengePurpose           = "mfa_challenge"            // Login waiting for a code, redeemed by verifyMfa
+	mfaEnrollmentChallengePurpose = "mfa_enrollment_challenge" // Login waiting for enrollment, redeemed by confirmMfa
+	mfaPendingSecretPurpose       = "mfa_pending_secret"       // Secret of an enrollment waiting for confirmation, keyed by user
+	totpUsedPurpose               = "totp_used"                // Codes already used, keyed by user and step
+	recoveryUsedPurpose           = "recovery_used"            // Recovery codes being redeemed, keyed by user and code hash
+)
+
+const (
+	mfaMaxAttempts     = 5                // Invalid codes before a login challenge is dropped
+	mfaPendingTTL      = 10 * time.Minute // How long an enrollment waits for confirmation
+	recoveryUsedTTL    = 24 * time.Hour   // How long a redeemed recovery code stays claimed, well past its removal
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10 // Characters of a recovery code, without the dash
+)
+
+// recoveryAlphabet is lowercase base32, without ambiguous characters like 0/O and 1/l
+const recoveryAlphabet = "abcdefghijklmnopqrstuvwxyz234567"
+
+// recoveryEncoding writes recovery codes in recoveryAlphabet
+var recoveryEncoding = base32.NewEncoding(recoveryAlphabet).WithPadding(base32.NoPadding)
+
+// completeLogin issues a token pair to a user who passed every login step
+func (r *mutationResolver) completeLogin(ctx context.Context, userEntity *ent.User) (*model.LoginResponse, error) {
+	accessToken, refreshToken, err := r.jwtService.GenerateTokenPair(ctx, userEntity.ID, userEntity.Username, userEntity.Email)
+	if err != nil {
+		logger.WithError(err).Error("Failed to generate tokens")
+		return nil, fmt.Errorf("login failed")
+	}
+
+	// Cache user data asynchronously
+	r.cacheUserData(userEntity)
+
+	logger.WithFields(map[string]interface{}{"user_id": userEntity.ID, "email": userEntity.Email}).Info("User logged in")
+
+	return &model.LoginResponse{
+		User:         userEntity,
+		AccessToken:  &accessToken,
+		RefreshToken: &refreshToken,
+	}, nil
+}
+
+// mfaRequired reports whether the role or tenant of a user requires multi-factor authentication
+func (r *mutationResolver) mfaRequired(ctx context.Context, userEntity *ent.User) (bool, error) {
+	ctx = authz.SetBypass(ctx, true)
+
+	if role, err := userEntity.QueryRole().Only(ctx); err == nil {
+		if role.MfaRequired {
+			return true, nil
+		}
+	} else if !ent.IsNotFound(err) {
+		return false, err
+	}
+
+	tenant, err := r.client.Tenant.Get(ctx, userEntity.TenantID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return tenant.MfaRequired, nil
+}
+
+// mfaChallenge answers a login with a correct password with a short-lived token for the second step:
+// verifyMfa for enrolled users, enrollMfa and confirmMfa for users who must enroll first
+func (r *mutationResolver) mfaChallenge(ctx context.Context, userEntity *ent.User) (*model.LoginResponse, error) {
+	purpose := mfaChallengePurpose
+	if !userEntity.MfaEnabled {
+		purpose = mfaEnrollmentChallengePurpose
+	}
+
+	token, err := newAccountToken()
+	if err == nil {
+		err = r.tokens.SaveOneTimeToken(ctx, purpose, token, strconv.Itoa(userEntity.ID), r.accounts.MfaChallengeTTL)
+	}
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userEntity.ID).Error("Failed to create MFA challenge")
+		return nil, fmt.Errorf("login failed")
+	}
+
+	logger.WithFields(map[string]interface{}{"user_id": userEntity.ID, "enrollment": !userEntity.MfaEnabled}).Info("MFA challenge issued")
+
+	return &model.LoginResponse{
+		MfaRequired:           true,
+		MfaEnrollmentRequired: !userEntity.MfaEnabled,
+		MfaToken:              &token,
+	}, nil
+}
+
+// mfaUser returns the user enrolling in MFA: the user of an enrollment challenge when mfaToken is set,
+// otherwise the authenticated caller
+func (r *mutationResolver) mfaUser(ctx context.Context, mfaToken *string) (*ent.User, error) {
+	var userID int
+	if mfaToken != nil {
+		value, err := r.tokens.PeekOneTimeToken(ctx, mfaEnrollmentChallengePurpose, *mfaToken)
+		if err != nil {
+			logger.WithError(err).Error("Failed to read MFA enrollment challenge")
+			return nil, fmt.Errorf("MFA enrollment failed")
+		}
+		if userID, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("invalid or expired MFA token")
+		}
+	} else {
+		userCtx, ok := pkgcontext.GetUser(ctx)
+		if !ok {
+			return nil, fmt.Errorf("user not authenticated")
+		}
+		userID = userCtx.ID
+	}
+
+	userEntity, err := r.client.User.Get(authz.SetBypass(ctx, true), userID)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to get user for MFA")
+		return nil, fmt.Errorf("MFA enrollment failed")
+	}
+	if !userEntity.IsActive {
+		return nil, fmt.Errorf("account is deactivated")
+	}
+	if userEntity.MfaEnabled {
+		return nil, fmt.Errorf("multi-factor authentication is already enabled")
+	}
+	return userEntity, nil
+}
+
+// enrolledUser returns the authenticated caller after checking a second factor, for changes to their MFA
+func (r *mutationResolver) enrolledUser(ctx context.Context, code string) (*ent.User, error) {
+	userCtx, ok := pkgcontext.GetUser(ctx)
+	if !ok {
+		return nil, fmt.Errorf("user not authenticated")
+	}
+
+	userEntity, err := r.client.User.Get(authz.SetBypass(ctx, true), userCtx.ID)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userCtx.ID).Error("Failed to get user for MFA")
+		return nil, fmt.Errorf("failed to update MFA")
+	}
+	if !userEntity.MfaEnabled {
+		return nil, fmt.Errorf("multi-factor authentication is not enabled")
+	}
+
+	ok, err = r.checkSecondFactor(ctx, userEntity, code)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", userEntity.ID).Error("Failed to check MFA code")
+		return nil, fmt.Errorf("failed to update MFA")
+	}
+	if !ok {
+		logger.WithField("user_id", userEntity.ID).Warn("Invalid MFA code")
+		return nil, fmt.Errorf("invalid code")
+	}
+	return userEntity, nil
+}
+
+// checkTotp validates a code of a secret, rejecting a code that was already used
+func (r *mutationResolver) checkTotp(ctx context.Context, userID int, secret, code string) (bool, error) {
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+	// A code is valid for a few steps, long enough for it to be replayed after use
+	return r.tokens.MarkUsed(ctx, totpUsedPurpose, fmt.Sprintf("%d:%d", userID, step), (2*totp.Skew+1)*totp.Period)
+}
+
+// checkSecondFactor validates a TOTP code or a recovery code of an enrolled user; a recovery code is used up
+func (r *mutationResolver) checkSecondFactor(ctx context.Context, userEntity *ent.User, code string) (bool, error) {
+	if ok, err := r.checkTotp(ctx, userEntity.ID, userEntity.MfaSecret, code); ok || err != nil {
+		return ok, err
+	}
+
+	// Each recovery code is a slow hash check, so only input shaped like one is compared
+	normalized := normalizeRecoveryCode(code)
+	if !isRecoveryCode(normalized) {
+		return false, nil
+	}
+	for _, hash := range userEntity.MfaRecoveryCodes {
+		if !jwt.CheckPassword(normalized, hash) {
+			continue
+		}
+
+		// Claim the code first, so concurrent logins cannot both redeem it
+		claimed, err := r.tokens.MarkUsed(ctx, recoveryUsedPurpose, fmt.Sprintf("%d:%s", userEntity.ID, hash), recoveryUsedTTL)
+		if err != nil || !claimed {
+			return false, err
+		}
+		return r.removeRecoveryCode(ctx, userEntity.ID, hash)
+	}
+	return false, nil
+}
+
+// removeRecoveryCode deletes a redeemed recovery code, returning false when it was already gone
+// The codes are re-read under a row lock, so concurrent changes to them are not overwritten
+func (r *mutationResolver) removeRecoveryCode(ctx context.Context, userID int, hash string) (bool, error) {
+	ctx = authz.SetBypass(ctx, true)
+	tx, err := r.client.Tx(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	userEntity, err := tx.User.Query().Where(user.ID(userID)).ForUpdate().Only(ctx)
+	if err != nil {
+		return false, rollback(tx, err)
+	}
+
+	remaining := make([]string, 0, len(userEntity.MfaRecoveryCodes))
+	for _, h := range userEntity.MfaRecoveryCodes {
+		if h != hash {
+			remaining = append(remaining, h)
+		}
+	}
+	// Regenerated since the code was checked
+	if len(remaining) == len(userEntity.MfaRecoveryCodes) {
+		return false, tx.Rollback()
+	}
+
+	if _, err := tx.User.UpdateOneID(userID).SetMfaRecoveryCodes(remaining).Save(ctx); err != nil {
+		return false, rollback(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	logger.WithFields(map[string]interface{}{"user_id": userID, "remaining": len(remaining)}).Warn("MFA recovery code used")
+	return true, nil
+}
+
+// rollback aborts a transaction after err, keeping err as the cause
+func rollback(tx *ent.Tx, err error) error {
+	if rerr := tx.Rollback(); rerr != nil {
+		return fmt.Errorf("%w: rolling back: %v", err, rerr)
+	}
+	return err
+}
+
+// newRecoveryCodes creates recovery codes, returning them for the user and their hashes for storing
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := recoveryEncoding.EncodeToString(b)[:recoveryCodeLength]
+
+		hash, err := jwt.HashPassword(code)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes[i], hashes[i] = code[:5]+"-"+code[5:], hash
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode accepts recovery codes typed in any case, with or without the dash
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// isRecoveryCode reports whether a normalized code has the shape of a recovery code
+func isRecoveryCode(code string) bool {
+	if len(code) != recoveryCodeLength {
+		return false
+	}
+	for _, c := range code {
+		if !strings.ContainsRune(recoveryAlphabet, c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,218 @@
+package graph
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/saurabh/entgo-microservices/auth/graph/model"
+	"github.com/saurabh/entgo-microservices/auth/internal/ent"
+	"github.com/saurabh/entgo-microservices/pkg/authz"
+	pkgcontext "github.com/saurabh/entgo-microservices/pkg/context"
+	"github.com/saurabh/entgo-microservices/pkg/totp"
+)
+
+// enrollMfa turns on MFA for a user, returning their TOTP secret and recovery codes
+func enrollMfa(t *testing.T, r *mutationResolver, userEntity *ent.User) (string, []string) {
+	t.Helper()
+	ctx := pkgcontext.SetUser(context.Background(), &pkgcontext.User{ID: userEntity.ID})
+
+	enrollment, err := r.EnrollMfa(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := totp.Code(enrollment.Secret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	confirmation, err := r.ConfirmMfa(ctx, code, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return enrollment.Secret, confirmation.RecoveryCodes
+}
+
+// mfaLogin logs in with a correct password and returns the MFA challenge token
+func mfaLogin(t *testing.T, r *mutationResolver, userEntity *ent.User) string {
+	t.Helper()
+
+	login, err := r.Login(context.Background(), model.LoginInput{Email: userEntity.Email, Password: "password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !login.MfaRequired || login.MfaToken == nil {
+		t.Fatal("login did not ask for a second factor")
+	}
+	return *login.MfaToken
+}
+
+// recoveryCodesLeft returns how many recovery codes a user has not used
+func recoveryCodesLeft(t *testing.T, r *mutationResolver, userID int) int {
+	t.Helper()
+
+	userEntity, err := r.client.User.Get(authz.SetBypass(context.Background(), true), userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(userEntity.MfaRecoveryCodes)
+}
+
+func TestVerifyMfaRejectsReplayedCode(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestResolver(t)
+	alice := createUser(t, r, "acme", "alice")
+	secret, _ := enrollMfa(t, r, alice)
+
+	// The enrollment used the code of the current step, the next one is still accepted
+	code, err := totp.Code(secret, time.Now().Add(totp.Period))
+	if err != nil {
+		t.Fatal(err)
+	}
+	login, err := r.VerifyMfa(ctx, model.VerifyMfaInput{MfaToken: mfaLogin(t, r, alice), Code: code})
+	if err != nil {
+		t.Fatalf("VerifyMfa: %v", err)
+	}
+	if login.AccessToken == nil {
+		t.Fatal("no access token after the second factor")
+	}
+
+	if _, err := r.VerifyMfa(ctx, model.VerifyMfaInput{MfaToken: mfaLogin(t, r, alice), Code: code}); err == nil {
+		t.Fatal("a used code was accepted again")
+	}
+}
+
+func TestVerifyMfaRedeemsChallengeOnce(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestResolver(t)
+	alice := createUser(t, r, "acme", "alice")
+	_, recoveryCodes := enrollMfa(t, r, alice)
+
+	token := mfaLogin(t, r, alice)
+	if _, err := r.VerifyMfa(ctx, model.VerifyMfaInput{MfaToken: token, Code: recoveryCodes[0]}); err != nil {
+		t.Fatalf("VerifyMfa: %v", err)
+	}
+	if _, err := r.VerifyMfa(ctx, model.VerifyMfaInput{MfaToken: token, Code: recoveryCodes[1]}); err == nil {
+		t.Fatal("a redeemed challenge was accepted again")
+	}
+}
+
+func TestVerifyMfaDropsChallengeAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestResolver(t)
+	alice := createUser(t, r, "acme", "alice")
+	_, recoveryCodes := enrollMfa(t, r, alice)
+	token := mfaLogin(t, r, alice)
+
+	for attempt := 1; attempt <= mfaMaxAttempts; attempt++ {
+		_, err := r.VerifyMfa(ctx, model.VerifyMfaInput{MfaToken: token, Code: "aaaaa-aaaaa"})
+		if err == nil {
+			t.Fatal("an invalid code was accepted")
+		}
+		dropped := strings.Contains(err.Error(), "too many invalid codes")
+		if dropped != (attempt == mfaMaxAttempts) {
+			t.Fatalf("attempt %d: %v", attempt, err)
+		}
+	}
+
+	// A correct code no longer helps once the challenge is dropped
+	if _, err := r.VerifyMfa(ctx, model.VerifyMfaInput{MfaToken: token, Code: recoveryCodes[0]}); err == nil {
+		t.Fatal("a dropped challenge was accepted")
+	}
+	if left := recoveryCodesLeft(t, r, alice.ID); left != recoveryCodeCount {
+		t.Errorf("%d recovery codes left, want %d", left, recoveryCodeCount)
+	}
+}
+
+func TestRecoveryCodeIsRedeemedOnce(t *testing.T) {
+	ctx := context.Background()
+	r, _ := newTestResolver(t)
+	alice := createUser(t, r, "acme", "alice")
+	_, recoveryCodes := enrollMfa(t, r, alice)
+
+	const logins = 5
+	tokens := make([]string, logins)
+	for i := range tokens {
+		tokens[i] = mfaLogin(t, r, alice)
+	}
+
+	// Concurrent logins with the same recovery code, typed differently, let only one of them in
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted := 0
+	code := strings.ToUpper(strings.ReplaceAll(recoveryCodes[0], "-", ""))
+	for _, token := range tokens {
+		wg.Add(1)
+		go func(token string) {
+			defer wg.Done()
+			if _, err := r.VerifyMfa(ctx, model.VerifyMfaInput{MfaToken: token, Code: code}); err == nil {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}(token)
+	}
+	wg.Wait()
+
+	if accepted != 1 {
+		t.Fatalf("recovery code accepted %d times, want once", accepted)
+	}
+	if left := recoveryCodesLeft(t, r, alice.ID); left != recoveryCodeCount-1 {
+		t.Errorf("%d recovery codes left, want %d", left, recoveryCodeCount-1)
+	}
+	if _, err := r.VerifyMfa(ctx, model.VerifyMfaInput{MfaToken: mfaLogin(t, r, alice), Code: recoveryCodes[0]}); err == nil {
+		t.Error("a used recovery code was accepted again")
+	}
+}
+
+func TestDisableUserMfaStaysInTenant(t *testing.T) {
+	r, _ := newTestResolver(t)
+	admin := createUser(t, r, "acme", "admin")
+	alice := createUser(t, r, "acme", "alice")
+	mallory := createUser(t, r, "globex", "mallory")
+	enrollMfa(t, r, alice)
+	enrollMfa(t, r, mallory)
+	ctx := pkgcontext.SetUser(context.Background(), &pkgcontext.User{ID: admin.ID, TenantID: admin.TenantID})
+
+	if ok, err := r.DisableUserMfa(ctx, mallory.ID); ok || err == nil {
+		t.Fatal("MFA of a user of another tenant was disabled")
+	}
+	if ok, err := r.DisableUserMfa(ctx, alice.ID); !ok || err != nil {
+		t.Fatalf("DisableUserMfa = %v, %v", ok, err)
+	}
+
+	bypass := authz.SetBypass(ctx, true)
+	for _, tt := range []struct {
+		user    *ent.User
+		enabled bool
+	}{{alice, false}, {mallory, true}} {
+		updated, err := r.client.User.Get(bypass, tt.user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.MfaEnabled != tt.enabled {
+			t.Errorf("%s: MFA enabled = %v, want %v", updated.Username, updated.MfaEnabled, tt.enabled)
+		}
+	}
+}
+
+func TestIsRecoveryCode(t *testing.T) {
+	tests := []struct {
+		code  string
+		valid bool
+	}{
+		{"abcdefghij", true},
+		{"a2b3c4d5e7", true},
+		{"123456", false},
+		{"abcdefghi", false},
+		{"abcdefghijk", false},
+		{"abcdefgh10", false},
+		{"abcde-fghij", false},
+	}
+	for _, tt := range tests {
+		if got := isRecoveryCode(tt.code); got != tt.valid {
+			t.Errorf("isRecoveryCode(%q) = %v, want %v", tt.code, got, tt.valid)
+		}
+	}
+}
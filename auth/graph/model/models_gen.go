@@ -3,24 +3,52 @@
 package model
 
 import (
+	"time"
+
 	"github.com/saurabh/entgo-microservices/auth/internal/ent"
 )
 
+type BrandByIDsInput struct {
+	ID int `json:"ID"`
+}
+
 type LoginInput struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
 
+// Either the user with a token pair, or an MFA challenge: pass mfaToken to verifyMfa,
+// or to enrollMfa and confirmMfa when mfaEnrollmentRequired
 type LoginResponse struct {
-	User         *ent.User `json:"user"`
-	AccessToken  string    `json:"accessToken"`
-	RefreshToken string    `json:"refreshToken"`
+	User                  *ent.User `json:"user,omitempty"`
+	AccessToken           *string   `json:"accessToken,omitempty"`
+	RefreshToken          *string   `json:"refreshToken,omitempty"`
+	MfaRequired           bool      `json:"mfaRequired"`
+	MfaEnrollmentRequired bool      `json:"mfaEnrollmentRequired"`
+	MfaToken              *string   `json:"mfaToken,omitempty"`
 }
 
 type LogoutResponse struct {
 	Success bool `json:"success"`
 }
 
+type MfaConfirmation struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+	// The completed login when confirming with an mfaToken
+	Login *LoginResponse `json:"login,omitempty"`
+}
+
+type MfaEnrollment struct {
+	// Base32 secret for entering in an authenticator app by hand
+	Secret string `json:"secret"`
+	// otpauth:// URI to show as a QR code
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+type PermissionByIDsInput struct {
+	ID int `json:"ID"`
+}
+
 type RegisterInput struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
@@ -33,7 +61,48 @@ type RegisterResponse struct {
 	RefreshToken string    `json:"refreshToken"`
 }
 
+type ResetPasswordInput struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"newPassword"`
+}
+
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
+type RoleByIDsInput struct {
+	ID int `json:"ID"`
+}
+
+type RolePermissionByIDsInput struct {
+	ID int `json:"ID"`
+}
+
+// A device the user is logged in on, from login until logout or the refresh token expires
+type Session struct {
+	ID        string    `json:"id"`
+	UserAgent string    `json:"userAgent"`
+	IP        string    `json:"ip"`
+	IssuedAt  time.Time `json:"issuedAt"`
+	LastSeen  time.Time `json:"lastSeen"`
+	// Whether the request was made with a token of this session
+	Current bool `json:"current"`
+}
+
+type TenantByIDsInput struct {
+	ID int `json:"ID"`
+}
+
 type TokenResponse struct {
 	AccessToken  string `json:"accessToken"`
 	RefreshToken string `json:"refreshToken"`
 }
+
+type UserByIDsInput struct {
+	ID int `json:"ID"`
+}
+
+type VerifyMfaInput struct {
+	MfaToken string `json:"mfaToken"`
+	Code     string `json:"code"`
+}
@@ -2,12 +2,15 @@ package graph
 
 import (
 	"sync"
+	"time"
 
 	"github.com/saurabh/entgo-microservices/auth/internal/ent"
 
 	"github.com/redis/go-redis/v9"
 	pkggrpc "github.com/saurabh/entgo-microservices/pkg/grpc"
 	"github.com/saurabh/entgo-microservices/pkg/jwt"
+	"github.com/saurabh/entgo-microservices/pkg/mail"
+	pkgredis "github.com/saurabh/entgo-microservices/pkg/redis"
 )
 
 // This file will not be regenerated automatically.
@@ -21,14 +24,28 @@ type Resolver struct {
 	gatewayClient *pkggrpc.GatewayClient
 	gatewayOnce   sync.Once
 	gatewayErr    error
+	accounts      AccountFlows
+	tokens        *pkgredis.TokenService // Single-use account tokens and MFA challenges
 }
 
-func NewResolver(client *ent.Client, jwtService *jwt.Service, redisClient *redis.Client, gatewayClient *pkggrpc.GatewayClient) *Resolver {
+// AccountFlows configures the password reset, email verification and multi-factor login flows
+type AccountFlows struct {
+	Mailer          mail.Mailer
+	AppURL          string        // Emails link to {AppURL}/reset-password and {AppURL}/verify-email
+	ResetTTL        time.Duration // How long a password reset link is valid
+	VerificationTTL time.Duration // How long an email verification link is valid
+	MfaIssuer       string        // Account issuer shown by authenticator apps
+	MfaChallengeTTL time.Duration // How long the MFA step of a login may take
+}
+
+func NewResolver(client *ent.Client, jwtService *jwt.Service, redisClient *redis.Client, gatewayClient *pkggrpc.GatewayClient, accounts AccountFlows) *Resolver {
 	return &Resolver{
 		client:        client,
 		jwtService:    jwtService,
 		redisClient:   redisClient,
 		gatewayClient: gatewayClient,
+		accounts:      accounts,
+		tokens:        pkgredis.NewTokenService(redisClient, "auth"),
 	}
 }
 
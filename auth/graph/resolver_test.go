@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/alicebob/miniredis/v2"
+	_ "github.com/mattn/go-sqlite3"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/saurabh/entgo-microservices/auth/internal/ent"
+	"github.com/saurabh/entgo-microservices/auth/internal/ent/migrate"
+	_ "github.com/saurabh/entgo-microservices/auth/internal/ent/runtime"
+	"github.com/saurabh/entgo-microservices/auth/internal/ent/tenant"
+	"github.com/saurabh/entgo-microservices/pkg/authz"
+	"github.com/saurabh/entgo-microservices/pkg/jwt"
+	"github.com/saurabh/entgo-microservices/pkg/logger"
+	"github.com/saurabh/entgo-microservices/pkg/mail"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMain(m *testing.M) {
+	logger.Logger = logrus.New()
+	logger.Logger.SetOutput(io.Discard)
+
+	// SQLite names indexes per database rather than per table, and the tests do not rely on them
+	for _, table := range migrate.Tables {
+		table.Indexes = nil
+	}
+	os.Exit(m.Run())
+}
+
+// sqliteDriver runs the queries ent builds for MySQL on SQLite, since ent refuses to build the row locks the
+// resolvers take for SQLite. The locks are dropped, SQLite serializes writes anyway
+type sqliteDriver struct {
+	dialect.Driver
+}
+
+func (d sqliteDriver) Dialect() string {
+	return dialect.MySQL
+}
+
+func (d sqliteDriver) Query(ctx context.Context, query string, args, v any) error {
+	return d.Driver.Query(ctx, strings.ReplaceAll(query, " FOR UPDATE", ""), args, v)
+}
+
+func (d sqliteDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sqliteTx{tx}, nil
+}
+
+// sqliteTx drops row locks inside a transaction
+type sqliteTx struct {
+	dialect.Tx
+}
+
+func (tx sqliteTx) Query(ctx context.Context, query string, args, v any) error {
+	return tx.Tx.Query(ctx, strings.ReplaceAll(query, " FOR UPDATE", ""), args, v)
+}
+
+// newTestResolver creates a resolver backed by an in-memory database and Redis, sending email to a memory mailer
+func newTestResolver(t *testing.T) (*mutationResolver, *mail.MemoryMailer) {
+	t.Helper()
+
+	drv, err := entsql.Open(dialect.SQLite, fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// One connection, so concurrent transactions wait for each other instead of failing on a locked table
+	drv.DB().SetMaxOpenConns(1)
+	t.Cleanup(func() { drv.Close() })
+	if err := ent.NewClient(ent.Driver(drv)).Schema.Create(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	server := miniredis.RunT(t)
+	redisClient := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { redisClient.Close() })
+
+	mailer := mail.NewMemoryMailer()
+	resolver := NewResolver(ent.NewClient(ent.Driver(sqliteDriver{drv})), jwt.NewService("test-secret", 1, redisClient, "auth"),
+		redisClient, nil, AccountFlows{
+			Mailer:          mailer,
+			AppURL:          "https://app.example.com",
+			ResetTTL:        time.Hour,
+			VerificationTTL: time.Hour,
+			MfaIssuer:       "Test",
+			MfaChallengeTTL: 5 * time.Minute,
+		})
+	return &mutationResolver{resolver}, mailer
+}
+
+// createUser adds an active user with the password "password" to the tenant with a slug, creating the tenant when needed
+func createUser(t *testing.T, r *mutationResolver, slug, username string) *ent.User {
+	t.Helper()
+	ctx := authz.SetBypass(context.Background(), true)
+
+	tenantEntity, err := r.client.Tenant.Query().Where(tenant.Slug(slug)).Only(ctx)
+	if ent.IsNotFound(err) {
+		tenantEntity, err = r.client.Tenant.Create().SetName(slug).SetSlug(slug).Save(ctx)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := jwt.HashPassword("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	userEntity, err := r.client.User.Create().
+		SetEmail(username + "@example.com").
+		SetUsername(username).
+		SetName(username).
+		SetPasswordHash(hash).
+		SetTenantID(tenantEntity.ID).
+		Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return userEntity
+}
@@ -28,7 +28,16 @@ func (r *mutationResolver) CreateBulkUser(ctx context.Context, input []*ent.Crea
 
 // UpdateUser is the resolver for the updateUser mutation.
 func (r *mutationResolver) UpdateUser(ctx context.Context, id int, input ent.UpdateUserInput) (*ent.User, error) {
-	return r.Resolver.client.User.UpdateOneID(id).SetInput(input).Save(ctx)
+	userEntity, err := r.Resolver.client.User.UpdateOneID(id).SetInput(input).Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// A new password signs the user out everywhere else
+	if input.PasswordHash != nil {
+		r.revokeSessionsAfterPasswordChange(ctx, id)
+	}
+	return userEntity, nil
 }
 
 // DeleteUser is the resolver for the deleteUser mutation.
@@ -0,0 +1,21 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+// IsEntity implements the gqlgen federation entity interface.
+func (_m *Brand) IsEntity() {}
+
+// IsEntity implements the gqlgen federation entity interface.
+func (_m *Permission) IsEntity() {}
+
+// IsEntity implements the gqlgen federation entity interface.
+func (_m *Role) IsEntity() {}
+
+// IsEntity implements the gqlgen federation entity interface.
+func (_m *RolePermission) IsEntity() {}
+
+// IsEntity implements the gqlgen federation entity interface.
+func (_m *Tenant) IsEntity() {}
+
+// IsEntity implements the gqlgen federation entity interface.
+func (_m *User) IsEntity() {}
@@ -82,6 +82,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			role.FieldDescription: {Type: field.TypeString, Column: role.FieldDescription},
 			role.FieldIsActive:    {Type: field.TypeBool, Column: role.FieldIsActive},
 			role.FieldPriority:    {Type: field.TypeInt, Column: role.FieldPriority},
+			role.FieldMfaRequired: {Type: field.TypeBool, Column: role.FieldMfaRequired},
 		},
 	}
 	graph.Nodes[3] = &sqlgraph.Node{
@@ -128,6 +129,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			tenant.FieldMetadata:    {Type: field.TypeJSON, Column: tenant.FieldMetadata},
 			tenant.FieldExpiresAt:   {Type: field.TypeTime, Column: tenant.FieldExpiresAt},
 			tenant.FieldIsActive:    {Type: field.TypeBool, Column: tenant.FieldIsActive},
+			tenant.FieldMfaRequired: {Type: field.TypeBool, Column: tenant.FieldMfaRequired},
 		},
 	}
 	graph.Nodes[5] = &sqlgraph.Node{
@@ -141,26 +143,29 @@ var schemaGraph = func() *sqlgraph.Schema {
 		},
 		Type: "User",
 		Fields: map[string]*sqlgraph.FieldSpec{
-			user.FieldCreatedAt:       {Type: field.TypeTime, Column: user.FieldCreatedAt},
-			user.FieldUpdatedAt:       {Type: field.TypeTime, Column: user.FieldUpdatedAt},
-			user.FieldCreatedBy:       {Type: field.TypeInt, Column: user.FieldCreatedBy},
-			user.FieldTenantID:        {Type: field.TypeInt, Column: user.FieldTenantID},
-			user.FieldCode:            {Type: field.TypeString, Column: user.FieldCode},
-			user.FieldEmail:           {Type: field.TypeString, Column: user.FieldEmail},
-			user.FieldUsername:        {Type: field.TypeString, Column: user.FieldUsername},
-			user.FieldPasswordHash:    {Type: field.TypeString, Column: user.FieldPasswordHash},
-			user.FieldName:            {Type: field.TypeString, Column: user.FieldName},
-			user.FieldPhone:           {Type: field.TypeString, Column: user.FieldPhone},
-			user.FieldAddress:         {Type: field.TypeString, Column: user.FieldAddress},
-			user.FieldUserType:        {Type: field.TypeString, Column: user.FieldUserType},
-			user.FieldUserCode:        {Type: field.TypeString, Column: user.FieldUserCode},
-			user.FieldCompanyName:     {Type: field.TypeString, Column: user.FieldCompanyName},
-			user.FieldCustomerType:    {Type: field.TypeString, Column: user.FieldCustomerType},
-			user.FieldPaymentTerms:    {Type: field.TypeInt, Column: user.FieldPaymentTerms},
-			user.FieldIsActive:        {Type: field.TypeBool, Column: user.FieldIsActive},
-			user.FieldEmailVerified:   {Type: field.TypeBool, Column: user.FieldEmailVerified},
-			user.FieldEmailVerifiedAt: {Type: field.TypeTime, Column: user.FieldEmailVerifiedAt},
-			user.FieldLastLogin:       {Type: field.TypeTime, Column: user.FieldLastLogin},
+			user.FieldCreatedAt:        {Type: field.TypeTime, Column: user.FieldCreatedAt},
+			user.FieldUpdatedAt:        {Type: field.TypeTime, Column: user.FieldUpdatedAt},
+			user.FieldCreatedBy:        {Type: field.TypeInt, Column: user.FieldCreatedBy},
+			user.FieldTenantID:         {Type: field.TypeInt, Column: user.FieldTenantID},
+			user.FieldCode:             {Type: field.TypeString, Column: user.FieldCode},
+			user.FieldEmail:            {Type: field.TypeString, Column: user.FieldEmail},
+			user.FieldUsername:         {Type: field.TypeString, Column: user.FieldUsername},
+			user.FieldPasswordHash:     {Type: field.TypeString, Column: user.FieldPasswordHash},
+			user.FieldName:             {Type: field.TypeString, Column: user.FieldName},
+			user.FieldPhone:            {Type: field.TypeString, Column: user.FieldPhone},
+			user.FieldAddress:          {Type: field.TypeString, Column: user.FieldAddress},
+			user.FieldUserType:         {Type: field.TypeString, Column: user.FieldUserType},
+			user.FieldUserCode:         {Type: field.TypeString, Column: user.FieldUserCode},
+			user.FieldCompanyName:      {Type: field.TypeString, Column: user.FieldCompanyName},
+			user.FieldCustomerType:     {Type: field.TypeString, Column: user.FieldCustomerType},
+			user.FieldPaymentTerms:     {Type: field.TypeInt, Column: user.FieldPaymentTerms},
+			user.FieldIsActive:         {Type: field.TypeBool, Column: user.FieldIsActive},
+			user.FieldEmailVerified:    {Type: field.TypeBool, Column: user.FieldEmailVerified},
+			user.FieldEmailVerifiedAt:  {Type: field.TypeTime, Column: user.FieldEmailVerifiedAt},
+			user.FieldLastLogin:        {Type: field.TypeTime, Column: user.FieldLastLogin},
+			user.FieldMfaEnabled:       {Type: field.TypeBool, Column: user.FieldMfaEnabled},
+			user.FieldMfaSecret:        {Type: field.TypeString, Column: user.FieldMfaSecret},
+			user.FieldMfaRecoveryCodes: {Type: field.TypeJSON, Column: user.FieldMfaRecoveryCodes},
 		},
 	}
 	graph.MustAddE(
@@ -503,6 +508,11 @@ func (f *RoleFilter) WherePriority(p entql.IntP) {
 	f.Where(p.Field(role.FieldPriority))
 }
 
+// WhereMfaRequired applies the entql bool predicate on the mfa_required field.
+func (f *RoleFilter) WhereMfaRequired(p entql.BoolP) {
+	f.Where(p.Field(role.FieldMfaRequired))
+}
+
 // WhereHasUsers applies a predicate to check if query has an edge users.
 func (f *RoleFilter) WhereHasUsers() {
 	f.Where(entql.HasEdge("users"))
@@ -739,6 +749,11 @@ func (f *TenantFilter) WhereIsActive(p entql.BoolP) {
 	f.Where(p.Field(tenant.FieldIsActive))
 }
 
+// WhereMfaRequired applies the entql bool predicate on the mfa_required field.
+func (f *TenantFilter) WhereMfaRequired(p entql.BoolP) {
+	f.Where(p.Field(tenant.FieldMfaRequired))
+}
+
 // addPredicate implements the predicateAdder interface.
 func (_q *UserQuery) addPredicate(pred func(s *sql.Selector)) {
 	_q.predicates = append(_q.predicates, pred)
@@ -879,6 +894,21 @@ func (f *UserFilter) WhereLastLogin(p entql.TimeP) {
 	f.Where(p.Field(user.FieldLastLogin))
 }
 
+// WhereMfaEnabled applies the entql bool predicate on the mfa_enabled field.
+func (f *UserFilter) WhereMfaEnabled(p entql.BoolP) {
+	f.Where(p.Field(user.FieldMfaEnabled))
+}
+
+// WhereMfaSecret applies the entql string predicate on the mfa_secret field.
+func (f *UserFilter) WhereMfaSecret(p entql.StringP) {
+	f.Where(p.Field(user.FieldMfaSecret))
+}
+
+// WhereMfaRecoveryCodes applies the entql json.RawMessage predicate on the mfa_recovery_codes field.
+func (f *UserFilter) WhereMfaRecoveryCodes(p entql.BytesP) {
+	f.Where(p.Field(user.FieldMfaRecoveryCodes))
+}
+
 // WhereHasRole applies a predicate to check if query has an edge role.
 func (f *UserFilter) WhereHasRole() {
 	f.Where(entql.HasEdge("role"))
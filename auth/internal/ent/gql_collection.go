@@ -368,6 +368,11 @@ func (_q *RoleQuery) collectField(ctx context.Context, oneNode bool, opCtx *grap
 				selectedFields = append(selectedFields, role.FieldPriority)
 				fieldSeen[role.FieldPriority] = struct{}{}
 			}
+		case "mfaRequired":
+			if _, ok := fieldSeen[role.FieldMfaRequired]; !ok {
+				selectedFields = append(selectedFields, role.FieldMfaRequired)
+				fieldSeen[role.FieldMfaRequired] = struct{}{}
+			}
 		case "id":
 		case "__typename":
 		default:
@@ -658,6 +663,11 @@ func (_q *TenantQuery) collectField(ctx context.Context, oneNode bool, opCtx *gr
 				selectedFields = append(selectedFields, tenant.FieldIsActive)
 				fieldSeen[tenant.FieldIsActive] = struct{}{}
 			}
+		case "mfaRequired":
+			if _, ok := fieldSeen[tenant.FieldMfaRequired]; !ok {
+				selectedFields = append(selectedFields, tenant.FieldMfaRequired)
+				fieldSeen[tenant.FieldMfaRequired] = struct{}{}
+			}
 		case "id":
 		case "__typename":
 		default:
@@ -848,6 +858,11 @@ func (_q *UserQuery) collectField(ctx context.Context, oneNode bool, opCtx *grap
 				selectedFields = append(selectedFields, user.FieldLastLogin)
 				fieldSeen[user.FieldLastLogin] = struct{}{}
 			}
+		case "mfaEnabled":
+			if _, ok := fieldSeen[user.FieldMfaEnabled]; !ok {
+				selectedFields = append(selectedFields, user.FieldMfaEnabled)
+				fieldSeen[user.FieldMfaEnabled] = struct{}{}
+			}
 		case "id":
 		case "__typename":
 		default:
@@ -146,6 +146,7 @@ type CreateRoleInput struct {
 	Description       *string
 	IsActive          *bool
 	Priority          *int
+	MfaRequired       *bool
 	UserIDs           []int
 	RolePermissionIDs []int
 }
@@ -164,6 +165,9 @@ func (i *CreateRoleInput) Mutate(m *RoleMutation) {
 	if v := i.Priority; v != nil {
 		m.SetPriority(*v)
 	}
+	if v := i.MfaRequired; v != nil {
+		m.SetMfaRequired(*v)
+	}
 	if v := i.UserIDs; len(v) > 0 {
 		m.AddUserIDs(v...)
 	}
@@ -186,6 +190,7 @@ type UpdateRoleInput struct {
 	Description             *string
 	IsActive                *bool
 	Priority                *int
+	MfaRequired             *bool
 	ClearUsers              bool
 	AddUserIDs              []int
 	RemoveUserIDs           []int
@@ -214,6 +219,9 @@ func (i *UpdateRoleInput) Mutate(m *RoleMutation) {
 	if v := i.Priority; v != nil {
 		m.SetPriority(*v)
 	}
+	if v := i.MfaRequired; v != nil {
+		m.SetMfaRequired(*v)
+	}
 	if i.ClearUsers {
 		m.ClearUsers()
 	}
@@ -335,6 +343,7 @@ type CreateTenantInput struct {
 	Metadata    map[string]interface{}
 	ExpiresAt   *time.Time
 	IsActive    *bool
+	MfaRequired *bool
 }
 
 // Mutate applies the CreateTenantInput on the TenantMutation builder.
@@ -362,6 +371,9 @@ func (i *CreateTenantInput) Mutate(m *TenantMutation) {
 	if v := i.IsActive; v != nil {
 		m.SetIsActive(*v)
 	}
+	if v := i.MfaRequired; v != nil {
+		m.SetMfaRequired(*v)
+	}
 }
 
 // SetInput applies the change-set in the CreateTenantInput on the TenantCreate builder.
@@ -386,6 +398,7 @@ type UpdateTenantInput struct {
 	ClearExpiresAt   bool
 	ExpiresAt        *time.Time
 	IsActive         *bool
+	MfaRequired      *bool
 }
 
 // Mutate applies the UpdateTenantInput on the TenantMutation builder.
@@ -432,6 +445,9 @@ func (i *UpdateTenantInput) Mutate(m *TenantMutation) {
 	if v := i.IsActive; v != nil {
 		m.SetIsActive(*v)
 	}
+	if v := i.MfaRequired; v != nil {
+		m.SetMfaRequired(*v)
+	}
 }
 
 // SetInput applies the change-set in the UpdateTenantInput on the TenantUpdate builder.
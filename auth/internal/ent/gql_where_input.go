@@ -973,6 +973,10 @@ type RoleWhereInput struct {
 	PriorityLT    *int  `json:"priorityLT,omitempty"`
 	PriorityLTE   *int  `json:"priorityLTE,omitempty"`
 
+	// "mfa_required" field predicates.
+	MfaRequired    *bool `json:"mfaRequired,omitempty"`
+	MfaRequiredNEQ *bool `json:"mfaRequiredNEQ,omitempty"`
+
 	// "users" edge predicates.
 	HasUsers     *bool             `json:"hasUsers,omitempty"`
 	HasUsersWith []*UserWhereInput `json:"hasUsersWith,omitempty"`
@@ -1347,6 +1351,12 @@ func (i *RoleWhereInput) P() (predicate.Role, error) {
 	if i.PriorityLTE != nil {
 		predicates = append(predicates, role.PriorityLTE(*i.PriorityLTE))
 	}
+	if i.MfaRequired != nil {
+		predicates = append(predicates, role.MfaRequiredEQ(*i.MfaRequired))
+	}
+	if i.MfaRequiredNEQ != nil {
+		predicates = append(predicates, role.MfaRequiredNEQ(*i.MfaRequiredNEQ))
+	}
 
 	if i.HasUsers != nil {
 		p := role.HasUsers()
@@ -1836,6 +1846,10 @@ type TenantWhereInput struct {
 	// "is_active" field predicates.
 	IsActive    *bool `json:"isActive,omitempty"`
 	IsActiveNEQ *bool `json:"isActiveNEQ,omitempty"`
+
+	// "mfa_required" field predicates.
+	MfaRequired    *bool `json:"mfaRequired,omitempty"`
+	MfaRequiredNEQ *bool `json:"mfaRequiredNEQ,omitempty"`
 }
 
 // AddPredicates adds custom predicates to the where input to be used during the filtering phase.
@@ -2203,6 +2217,12 @@ func (i *TenantWhereInput) P() (predicate.Tenant, error) {
 	if i.IsActiveNEQ != nil {
 		predicates = append(predicates, tenant.IsActiveNEQ(*i.IsActiveNEQ))
 	}
+	if i.MfaRequired != nil {
+		predicates = append(predicates, tenant.MfaRequiredEQ(*i.MfaRequired))
+	}
+	if i.MfaRequiredNEQ != nil {
+		predicates = append(predicates, tenant.MfaRequiredNEQ(*i.MfaRequiredNEQ))
+	}
 
 	switch len(predicates) {
 	case 0:
@@ -2482,6 +2502,10 @@ type UserWhereInput struct {
 	LastLoginIsNil  bool        `json:"lastLoginIsNil,omitempty"`
 	LastLoginNotNil bool        `json:"lastLoginNotNil,omitempty"`
 
+	// "mfa_enabled" field predicates.
+	MfaEnabled    *bool `json:"mfaEnabled,omitempty"`
+	MfaEnabledNEQ *bool `json:"mfaEnabledNEQ,omitempty"`
+
 	// "role" edge predicates.
 	HasRole     *bool             `json:"hasRole,omitempty"`
 	HasRoleWith []*RoleWhereInput `json:"hasRoleWith,omitempty"`
@@ -3221,6 +3245,12 @@ func (i *UserWhereInput) P() (predicate.User, error) {
 	if i.LastLoginNotNil {
 		predicates = append(predicates, user.LastLoginNotNil())
 	}
+	if i.MfaEnabled != nil {
+		predicates = append(predicates, user.MfaEnabledEQ(*i.MfaEnabled))
+	}
+	if i.MfaEnabledNEQ != nil {
+		predicates = append(predicates, user.MfaEnabledNEQ(*i.MfaEnabledNEQ))
+	}
 
 	if i.HasRole != nil {
 		p := user.HasRole()
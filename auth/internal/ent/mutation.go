@@ -1718,6 +1718,7 @@ type RoleMutation struct {
 	is_active               *bool
 	priority                *int
 	addpriority             *int
+	mfa_required            *bool
 	clearedFields           map[string]struct{}
 	users                   map[int]struct{}
 	removedusers            map[int]struct{}
@@ -2281,6 +2282,42 @@ func (m *RoleMutation) ResetPriority() {
 	m.addpriority = nil
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (m *RoleMutation) SetMfaRequired(b bool) {
+	m.mfa_required = &b
+}
+
+// MfaRequired returns the value of the "mfa_required" field in the mutation.
+func (m *RoleMutation) MfaRequired() (r bool, exists bool) {
+	v := m.mfa_required
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMfaRequired returns the old "mfa_required" field's value of the Role entity.
+// If the Role object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RoleMutation) OldMfaRequired(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMfaRequired is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMfaRequired requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMfaRequired: %w", err)
+	}
+	return oldValue.MfaRequired, nil
+}
+
+// ResetMfaRequired resets all changes to the "mfa_required" field.
+func (m *RoleMutation) ResetMfaRequired() {
+	m.mfa_required = nil
+}
+
 // AddUserIDs adds the "users" edge to the User entity by ids.
 func (m *RoleMutation) AddUserIDs(ids ...int) {
 	if m.users == nil {
@@ -2423,7 +2460,7 @@ func (m *RoleMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *RoleMutation) Fields() []string {
-	fields := make([]string, 0, 10)
+	fields := make([]string, 0, 11)
 	if m.created_at != nil {
 		fields = append(fields, role.FieldCreatedAt)
 	}
@@ -2454,6 +2491,9 @@ func (m *RoleMutation) Fields() []string {
 	if m.priority != nil {
 		fields = append(fields, role.FieldPriority)
 	}
+	if m.mfa_required != nil {
+		fields = append(fields, role.FieldMfaRequired)
+	}
 	return fields
 }
 
@@ -2482,6 +2522,8 @@ func (m *RoleMutation) Field(name string) (ent.Value, bool) {
 		return m.IsActive()
 	case role.FieldPriority:
 		return m.Priority()
+	case role.FieldMfaRequired:
+		return m.MfaRequired()
 	}
 	return nil, false
 }
@@ -2511,6 +2553,8 @@ func (m *RoleMutation) OldField(ctx context.Context, name string) (ent.Value, er
 		return m.OldIsActive(ctx)
 	case role.FieldPriority:
 		return m.OldPriority(ctx)
+	case role.FieldMfaRequired:
+		return m.OldMfaRequired(ctx)
 	}
 	return nil, fmt.Errorf("unknown Role field %s", name)
 }
@@ -2590,6 +2634,13 @@ func (m *RoleMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetPriority(v)
 		return nil
+	case role.FieldMfaRequired:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMfaRequired(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Role field %s", name)
 }
@@ -2723,6 +2774,9 @@ func (m *RoleMutation) ResetField(name string) error {
 	case role.FieldPriority:
 		m.ResetPriority()
 		return nil
+	case role.FieldMfaRequired:
+		m.ResetMfaRequired()
+		return nil
 	}
 	return fmt.Errorf("unknown Role field %s", name)
 }
@@ -3784,6 +3838,7 @@ type TenantMutation struct {
 	metadata      *map[string]interface{}
 	expires_at    *time.Time
 	is_active     *bool
+	mfa_required  *bool
 	clearedFields map[string]struct{}
 	done          bool
 	oldValue      func(context.Context) (*Tenant, error)
@@ -4425,6 +4480,42 @@ func (m *TenantMutation) ResetIsActive() {
 	m.is_active = nil
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (m *TenantMutation) SetMfaRequired(b bool) {
+	m.mfa_required = &b
+}
+
+// MfaRequired returns the value of the "mfa_required" field in the mutation.
+func (m *TenantMutation) MfaRequired() (r bool, exists bool) {
+	v := m.mfa_required
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMfaRequired returns the old "mfa_required" field's value of the Tenant entity.
+// If the Tenant object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TenantMutation) OldMfaRequired(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMfaRequired is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMfaRequired requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMfaRequired: %w", err)
+	}
+	return oldValue.MfaRequired, nil
+}
+
+// ResetMfaRequired resets all changes to the "mfa_required" field.
+func (m *TenantMutation) ResetMfaRequired() {
+	m.mfa_required = nil
+}
+
 // Where appends a list predicates to the TenantMutation builder.
 func (m *TenantMutation) Where(ps ...predicate.Tenant) {
 	m.predicates = append(m.predicates, ps...)
@@ -4459,7 +4550,7 @@ func (m *TenantMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *TenantMutation) Fields() []string {
-	fields := make([]string, 0, 12)
+	fields := make([]string, 0, 13)
 	if m.created_at != nil {
 		fields = append(fields, tenant.FieldCreatedAt)
 	}
@@ -4496,6 +4587,9 @@ func (m *TenantMutation) Fields() []string {
 	if m.is_active != nil {
 		fields = append(fields, tenant.FieldIsActive)
 	}
+	if m.mfa_required != nil {
+		fields = append(fields, tenant.FieldMfaRequired)
+	}
 	return fields
 }
 
@@ -4528,6 +4622,8 @@ func (m *TenantMutation) Field(name string) (ent.Value, bool) {
 		return m.ExpiresAt()
 	case tenant.FieldIsActive:
 		return m.IsActive()
+	case tenant.FieldMfaRequired:
+		return m.MfaRequired()
 	}
 	return nil, false
 }
@@ -4561,6 +4657,8 @@ func (m *TenantMutation) OldField(ctx context.Context, name string) (ent.Value,
 		return m.OldExpiresAt(ctx)
 	case tenant.FieldIsActive:
 		return m.OldIsActive(ctx)
+	case tenant.FieldMfaRequired:
+		return m.OldMfaRequired(ctx)
 	}
 	return nil, fmt.Errorf("unknown Tenant field %s", name)
 }
@@ -4654,6 +4752,13 @@ func (m *TenantMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetIsActive(v)
 		return nil
+	case tenant.FieldMfaRequired:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMfaRequired(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Tenant field %s", name)
 }
@@ -4793,6 +4898,9 @@ func (m *TenantMutation) ResetField(name string) error {
 	case tenant.FieldIsActive:
 		m.ResetIsActive()
 		return nil
+	case tenant.FieldMfaRequired:
+		m.ResetMfaRequired()
+		return nil
 	}
 	return fmt.Errorf("unknown Tenant field %s", name)
 }
@@ -4848,38 +4956,42 @@ func (m *TenantMutation) ResetEdge(name string) error {
 // UserMutation represents an operation that mutates the User nodes in the graph.
 type UserMutation struct {
 	config
-	op                Op
-	typ               string
-	id                *int
-	created_at        *time.Time
-	updated_at        *time.Time
-	created_by        *int
-	addcreated_by     *int
-	tenant_id         *int
-	addtenant_id      *int
-	code              *string
-	email             *string
-	username          *string
-	password_hash     *string
-	name              *string
-	phone             *string
-	address           *string
-	user_type         *string
-	user_code         *string
-	company_name      *string
-	customer_type     *string
-	payment_terms     *int
-	addpayment_terms  *int
-	is_active         *bool
-	email_verified    *bool
-	email_verified_at *time.Time
-	last_login        *time.Time
-	clearedFields     map[string]struct{}
-	role              *int
-	clearedrole       bool
-	done              bool
-	oldValue          func(context.Context) (*User, error)
-	predicates        []predicate.User
+	op                       Op
+	typ                      string
+	id                       *int
+	created_at               *time.Time
+	updated_at               *time.Time
+	created_by               *int
+	addcreated_by            *int
+	tenant_id                *int
+	addtenant_id             *int
+	code                     *string
+	email                    *string
+	username                 *string
+	password_hash            *string
+	name                     *string
+	phone                    *string
+	address                  *string
+	user_type                *string
+	user_code                *string
+	company_name             *string
+	customer_type            *string
+	payment_terms            *int
+	addpayment_terms         *int
+	is_active                *bool
+	email_verified           *bool
+	email_verified_at        *time.Time
+	last_login               *time.Time
+	mfa_enabled              *bool
+	mfa_secret               *string
+	mfa_recovery_codes       *[]string
+	appendmfa_recovery_codes []string
+	clearedFields            map[string]struct{}
+	role                     *int
+	clearedrole              bool
+	done                     bool
+	oldValue                 func(context.Context) (*User, error)
+	predicates               []predicate.User
 }
 
 var _ ent.Mutation = (*UserMutation)(nil)
@@ -5885,6 +5997,156 @@ func (m *UserMutation) ResetLastLogin() {
 	delete(m.clearedFields, user.FieldLastLogin)
 }
 
+// SetMfaEnabled sets the "mfa_enabled" field.
+func (m *UserMutation) SetMfaEnabled(b bool) {
+	m.mfa_enabled = &b
+}
+
+// MfaEnabled returns the value of the "mfa_enabled" field in the mutation.
+func (m *UserMutation) MfaEnabled() (r bool, exists bool) {
+	v := m.mfa_enabled
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMfaEnabled returns the old "mfa_enabled" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldMfaEnabled(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMfaEnabled is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMfaEnabled requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMfaEnabled: %w", err)
+	}
+	return oldValue.MfaEnabled, nil
+}
+
+// ResetMfaEnabled resets all changes to the "mfa_enabled" field.
+func (m *UserMutation) ResetMfaEnabled() {
+	m.mfa_enabled = nil
+}
+
+// SetMfaSecret sets the "mfa_secret" field.
+func (m *UserMutation) SetMfaSecret(s string) {
+	m.mfa_secret = &s
+}
+
+// MfaSecret returns the value of the "mfa_secret" field in the mutation.
+func (m *UserMutation) MfaSecret() (r string, exists bool) {
+	v := m.mfa_secret
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMfaSecret returns the old "mfa_secret" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldMfaSecret(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMfaSecret is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMfaSecret requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMfaSecret: %w", err)
+	}
+	return oldValue.MfaSecret, nil
+}
+
+// ClearMfaSecret clears the value of the "mfa_secret" field.
+func (m *UserMutation) ClearMfaSecret() {
+	m.mfa_secret = nil
+	m.clearedFields[user.FieldMfaSecret] = struct{}{}
+}
+
+// MfaSecretCleared returns if the "mfa_secret" field was cleared in this mutation.
+func (m *UserMutation) MfaSecretCleared() bool {
+	_, ok := m.clearedFields[user.FieldMfaSecret]
+	return ok
+}
+
+// ResetMfaSecret resets all changes to the "mfa_secret" field.
+func (m *UserMutation) ResetMfaSecret() {
+	m.mfa_secret = nil
+	delete(m.clearedFields, user.FieldMfaSecret)
+}
+
+// SetMfaRecoveryCodes sets the "mfa_recovery_codes" field.
+func (m *UserMutation) SetMfaRecoveryCodes(s []string) {
+	m.mfa_recovery_codes = &s
+	m.appendmfa_recovery_codes = nil
+}
+
+// MfaRecoveryCodes returns the value of the "mfa_recovery_codes" field in the mutation.
+func (m *UserMutation) MfaRecoveryCodes() (r []string, exists bool) {
+	v := m.mfa_recovery_codes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMfaRecoveryCodes returns the old "mfa_recovery_codes" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldMfaRecoveryCodes(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMfaRecoveryCodes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMfaRecoveryCodes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMfaRecoveryCodes: %w", err)
+	}
+	return oldValue.MfaRecoveryCodes, nil
+}
+
+// AppendMfaRecoveryCodes adds s to the "mfa_recovery_codes" field.
+func (m *UserMutation) AppendMfaRecoveryCodes(s []string) {
+	m.appendmfa_recovery_codes = append(m.appendmfa_recovery_codes, s...)
+}
+
+// AppendedMfaRecoveryCodes returns the list of values that were appended to the "mfa_recovery_codes" field in this mutation.
+func (m *UserMutation) AppendedMfaRecoveryCodes() ([]string, bool) {
+	if len(m.appendmfa_recovery_codes) == 0 {
+		return nil, false
+	}
+	return m.appendmfa_recovery_codes, true
+}
+
+// ClearMfaRecoveryCodes clears the value of the "mfa_recovery_codes" field.
+func (m *UserMutation) ClearMfaRecoveryCodes() {
+	m.mfa_recovery_codes = nil
+	m.appendmfa_recovery_codes = nil
+	m.clearedFields[user.FieldMfaRecoveryCodes] = struct{}{}
+}
+
+// MfaRecoveryCodesCleared returns if the "mfa_recovery_codes" field was cleared in this mutation.
+func (m *UserMutation) MfaRecoveryCodesCleared() bool {
+	_, ok := m.clearedFields[user.FieldMfaRecoveryCodes]
+	return ok
+}
+
+// ResetMfaRecoveryCodes resets all changes to the "mfa_recovery_codes" field.
+func (m *UserMutation) ResetMfaRecoveryCodes() {
+	m.mfa_recovery_codes = nil
+	m.appendmfa_recovery_codes = nil
+	delete(m.clearedFields, user.FieldMfaRecoveryCodes)
+}
+
 // SetRoleID sets the "role" edge to the Role entity by id.
 func (m *UserMutation) SetRoleID(id int) {
 	m.role = &id
@@ -5958,7 +6220,7 @@ func (m *UserMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *UserMutation) Fields() []string {
-	fields := make([]string, 0, 20)
+	fields := make([]string, 0, 23)
 	if m.created_at != nil {
 		fields = append(fields, user.FieldCreatedAt)
 	}
@@ -6019,6 +6281,15 @@ func (m *UserMutation) Fields() []string {
 	if m.last_login != nil {
 		fields = append(fields, user.FieldLastLogin)
 	}
+	if m.mfa_enabled != nil {
+		fields = append(fields, user.FieldMfaEnabled)
+	}
+	if m.mfa_secret != nil {
+		fields = append(fields, user.FieldMfaSecret)
+	}
+	if m.mfa_recovery_codes != nil {
+		fields = append(fields, user.FieldMfaRecoveryCodes)
+	}
 	return fields
 }
 
@@ -6067,6 +6338,12 @@ func (m *UserMutation) Field(name string) (ent.Value, bool) {
 		return m.EmailVerifiedAt()
 	case user.FieldLastLogin:
 		return m.LastLogin()
+	case user.FieldMfaEnabled:
+		return m.MfaEnabled()
+	case user.FieldMfaSecret:
+		return m.MfaSecret()
+	case user.FieldMfaRecoveryCodes:
+		return m.MfaRecoveryCodes()
 	}
 	return nil, false
 }
@@ -6116,6 +6393,12 @@ func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, er
 		return m.OldEmailVerifiedAt(ctx)
 	case user.FieldLastLogin:
 		return m.OldLastLogin(ctx)
+	case user.FieldMfaEnabled:
+		return m.OldMfaEnabled(ctx)
+	case user.FieldMfaSecret:
+		return m.OldMfaSecret(ctx)
+	case user.FieldMfaRecoveryCodes:
+		return m.OldMfaRecoveryCodes(ctx)
 	}
 	return nil, fmt.Errorf("unknown User field %s", name)
 }
@@ -6265,6 +6548,27 @@ func (m *UserMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetLastLogin(v)
 		return nil
+	case user.FieldMfaEnabled:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMfaEnabled(v)
+		return nil
+	case user.FieldMfaSecret:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMfaSecret(v)
+		return nil
+	case user.FieldMfaRecoveryCodes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMfaRecoveryCodes(v)
+		return nil
 	}
 	return fmt.Errorf("unknown User field %s", name)
 }
@@ -6361,6 +6665,12 @@ func (m *UserMutation) ClearedFields() []string {
 	if m.FieldCleared(user.FieldLastLogin) {
 		fields = append(fields, user.FieldLastLogin)
 	}
+	if m.FieldCleared(user.FieldMfaSecret) {
+		fields = append(fields, user.FieldMfaSecret)
+	}
+	if m.FieldCleared(user.FieldMfaRecoveryCodes) {
+		fields = append(fields, user.FieldMfaRecoveryCodes)
+	}
 	return fields
 }
 
@@ -6402,6 +6712,12 @@ func (m *UserMutation) ClearField(name string) error {
 	case user.FieldLastLogin:
 		m.ClearLastLogin()
 		return nil
+	case user.FieldMfaSecret:
+		m.ClearMfaSecret()
+		return nil
+	case user.FieldMfaRecoveryCodes:
+		m.ClearMfaRecoveryCodes()
+		return nil
 	}
 	return fmt.Errorf("unknown User nullable field %s", name)
 }
@@ -6470,6 +6786,15 @@ func (m *UserMutation) ResetField(name string) error {
 	case user.FieldLastLogin:
 		m.ResetLastLogin()
 		return nil
+	case user.FieldMfaEnabled:
+		m.ResetMfaEnabled()
+		return nil
+	case user.FieldMfaSecret:
+		m.ResetMfaSecret()
+		return nil
+	case user.FieldMfaRecoveryCodes:
+		m.ResetMfaRecoveryCodes()
+		return nil
 	}
 	return fmt.Errorf("unknown User field %s", name)
 }
@@ -38,6 +38,8 @@ type Role struct {
 	IsActive bool `json:"is_active,omitempty"`
 	// Role priority for hierarchy (higher number = higher priority)
 	Priority int `json:"priority,omitempty"`
+	// Whether users with the role must log in with multi-factor authentication
+	MfaRequired bool `json:"mfa_required,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the RoleQuery when eager-loading is set.
 	Edges        RoleEdges `json:"edges"`
@@ -83,7 +85,7 @@ func (*Role) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case role.FieldIsActive:
+		case role.FieldIsActive, role.FieldMfaRequired:
 			values[i] = new(sql.NullBool)
 		case role.FieldID, role.FieldCreatedBy, role.FieldTenantID, role.FieldPriority:
 			values[i] = new(sql.NullInt64)
@@ -173,6 +175,12 @@ func (_m *Role) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Priority = int(value.Int64)
 			}
+		case role.FieldMfaRequired:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field mfa_required", values[i])
+			} else if value.Valid {
+				_m.MfaRequired = value.Bool
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -250,6 +258,9 @@ func (_m *Role) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("priority=")
 	builder.WriteString(fmt.Sprintf("%v", _m.Priority))
+	builder.WriteString(", ")
+	builder.WriteString("mfa_required=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MfaRequired))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -35,6 +35,8 @@ const (
 	FieldIsActive = "is_active"
 	// FieldPriority holds the string denoting the priority field in the database.
 	FieldPriority = "priority"
+	// FieldMfaRequired holds the string denoting the mfa_required field in the database.
+	FieldMfaRequired = "mfa_required"
 	// EdgeUsers holds the string denoting the users edge name in mutations.
 	EdgeUsers = "users"
 	// EdgeRolePermissions holds the string denoting the role_permissions edge name in mutations.
@@ -70,6 +72,7 @@ var Columns = []string{
 	FieldDescription,
 	FieldIsActive,
 	FieldPriority,
+	FieldMfaRequired,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -108,6 +111,8 @@ var (
 	DefaultIsActive bool
 	// DefaultPriority holds the default value on creation for the "priority" field.
 	DefaultPriority int
+	// DefaultMfaRequired holds the default value on creation for the "mfa_required" field.
+	DefaultMfaRequired bool
 	// IDValidator is a validator for the "id" field. It is called by the builders before save.
 	IDValidator func(int) error
 )
@@ -170,6 +175,11 @@ func ByPriority(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldPriority, opts...).ToFunc()
 }
 
+// ByMfaRequired orders the results by the mfa_required field.
+func ByMfaRequired(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMfaRequired, opts...).ToFunc()
+}
+
 // ByUsersCount orders the results by users count.
 func ByUsersCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
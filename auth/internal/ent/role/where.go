@@ -105,6 +105,11 @@ func Priority(v int) predicate.Role {
 	return predicate.Role(sql.FieldEQ(FieldPriority, v))
 }
 
+// MfaRequired applies equality check predicate on the "mfa_required" field. It's identical to MfaRequiredEQ.
+func MfaRequired(v bool) predicate.Role {
+	return predicate.Role(sql.FieldEQ(FieldMfaRequired, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Role {
 	return predicate.Role(sql.FieldEQ(FieldCreatedAt, v))
@@ -595,6 +600,16 @@ func PriorityLTE(v int) predicate.Role {
 	return predicate.Role(sql.FieldLTE(FieldPriority, v))
 }
 
+// MfaRequiredEQ applies the EQ predicate on the "mfa_required" field.
+func MfaRequiredEQ(v bool) predicate.Role {
+	return predicate.Role(sql.FieldEQ(FieldMfaRequired, v))
+}
+
+// MfaRequiredNEQ applies the NEQ predicate on the "mfa_required" field.
+func MfaRequiredNEQ(v bool) predicate.Role {
+	return predicate.Role(sql.FieldNEQ(FieldMfaRequired, v))
+}
+
 // HasUsers applies the HasEdge predicate on the "users" edge.
 func HasUsers() predicate.Role {
 	return predicate.Role(func(s *sql.Selector) {
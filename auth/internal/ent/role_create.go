@@ -132,6 +132,20 @@ func (_c *RoleCreate) SetNillablePriority(v *int) *RoleCreate {
 	return _c
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (_c *RoleCreate) SetMfaRequired(v bool) *RoleCreate {
+	_c.mutation.SetMfaRequired(v)
+	return _c
+}
+
+// SetNillableMfaRequired sets the "mfa_required" field if the given value is not nil.
+func (_c *RoleCreate) SetNillableMfaRequired(v *bool) *RoleCreate {
+	if v != nil {
+		_c.SetMfaRequired(*v)
+	}
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *RoleCreate) SetID(v int) *RoleCreate {
 	_c.mutation.SetID(v)
@@ -227,6 +241,10 @@ func (_c *RoleCreate) defaults() error {
 		v := role.DefaultPriority
 		_c.mutation.SetPriority(v)
 	}
+	if _, ok := _c.mutation.MfaRequired(); !ok {
+		v := role.DefaultMfaRequired
+		_c.mutation.SetMfaRequired(v)
+	}
 	return nil
 }
 
@@ -276,6 +294,9 @@ func (_c *RoleCreate) check() error {
 	if _, ok := _c.mutation.Priority(); !ok {
 		return &ValidationError{Name: "priority", err: errors.New(`ent: missing required field "Role.priority"`)}
 	}
+	if _, ok := _c.mutation.MfaRequired(); !ok {
+		return &ValidationError{Name: "mfa_required", err: errors.New(`ent: missing required field "Role.mfa_required"`)}
+	}
 	if v, ok := _c.mutation.ID(); ok {
 		if err := role.IDValidator(v); err != nil {
 			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "Role.id": %w`, err)}
@@ -354,6 +375,10 @@ func (_c *RoleCreate) createSpec() (*Role, *sqlgraph.CreateSpec) {
 		_spec.SetField(role.FieldPriority, field.TypeInt, value)
 		_node.Priority = value
 	}
+	if value, ok := _c.mutation.MfaRequired(); ok {
+		_spec.SetField(role.FieldMfaRequired, field.TypeBool, value)
+		_node.MfaRequired = value
+	}
 	if nodes := _c.mutation.UsersIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -564,6 +589,18 @@ func (u *RoleUpsert) AddPriority(v int) *RoleUpsert {
 	return u
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (u *RoleUpsert) SetMfaRequired(v bool) *RoleUpsert {
+	u.Set(role.FieldMfaRequired, v)
+	return u
+}
+
+// UpdateMfaRequired sets the "mfa_required" field to the value that was provided on create.
+func (u *RoleUpsert) UpdateMfaRequired() *RoleUpsert {
+	u.SetExcluded(role.FieldMfaRequired)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
 // Using this option is equivalent to using:
 //
@@ -765,6 +802,20 @@ func (u *RoleUpsertOne) UpdatePriority() *RoleUpsertOne {
 	})
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (u *RoleUpsertOne) SetMfaRequired(v bool) *RoleUpsertOne {
+	return u.Update(func(s *RoleUpsert) {
+		s.SetMfaRequired(v)
+	})
+}
+
+// UpdateMfaRequired sets the "mfa_required" field to the value that was provided on create.
+func (u *RoleUpsertOne) UpdateMfaRequired() *RoleUpsertOne {
+	return u.Update(func(s *RoleUpsert) {
+		s.UpdateMfaRequired()
+	})
+}
+
 // Exec executes the query.
 func (u *RoleUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -1132,6 +1183,20 @@ func (u *RoleUpsertBulk) UpdatePriority() *RoleUpsertBulk {
 	})
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (u *RoleUpsertBulk) SetMfaRequired(v bool) *RoleUpsertBulk {
+	return u.Update(func(s *RoleUpsert) {
+		s.SetMfaRequired(v)
+	})
+}
+
+// UpdateMfaRequired sets the "mfa_required" field to the value that was provided on create.
+func (u *RoleUpsertBulk) UpdateMfaRequired() *RoleUpsertBulk {
+	return u.Update(func(s *RoleUpsert) {
+		s.UpdateMfaRequired()
+	})
+}
+
 // Exec executes the query.
 func (u *RoleUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
@@ -168,6 +168,20 @@ func (_u *RoleUpdate) AddPriority(v int) *RoleUpdate {
 	return _u
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (_u *RoleUpdate) SetMfaRequired(v bool) *RoleUpdate {
+	_u.mutation.SetMfaRequired(v)
+	return _u
+}
+
+// SetNillableMfaRequired sets the "mfa_required" field if the given value is not nil.
+func (_u *RoleUpdate) SetNillableMfaRequired(v *bool) *RoleUpdate {
+	if v != nil {
+		_u.SetMfaRequired(*v)
+	}
+	return _u
+}
+
 // AddUserIDs adds the "users" edge to the User entity by IDs.
 func (_u *RoleUpdate) AddUserIDs(ids ...int) *RoleUpdate {
 	_u.mutation.AddUserIDs(ids...)
@@ -369,6 +383,9 @@ func (_u *RoleUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.AddedPriority(); ok {
 		_spec.AddField(role.FieldPriority, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.MfaRequired(); ok {
+		_spec.SetField(role.FieldMfaRequired, field.TypeBool, value)
+	}
 	if _u.mutation.UsersCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -618,6 +635,20 @@ func (_u *RoleUpdateOne) AddPriority(v int) *RoleUpdateOne {
 	return _u
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (_u *RoleUpdateOne) SetMfaRequired(v bool) *RoleUpdateOne {
+	_u.mutation.SetMfaRequired(v)
+	return _u
+}
+
+// SetNillableMfaRequired sets the "mfa_required" field if the given value is not nil.
+func (_u *RoleUpdateOne) SetNillableMfaRequired(v *bool) *RoleUpdateOne {
+	if v != nil {
+		_u.SetMfaRequired(*v)
+	}
+	return _u
+}
+
 // AddUserIDs adds the "users" edge to the User entity by IDs.
 func (_u *RoleUpdateOne) AddUserIDs(ids ...int) *RoleUpdateOne {
 	_u.mutation.AddUserIDs(ids...)
@@ -849,6 +880,9 @@ func (_u *RoleUpdateOne) sqlSave(ctx context.Context) (_node *Role, err error) {
 	if value, ok := _u.mutation.AddedPriority(); ok {
 		_spec.AddField(role.FieldPriority, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.MfaRequired(); ok {
+		_spec.SetField(role.FieldMfaRequired, field.TypeBool, value)
+	}
 	if _u.mutation.UsersCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -247,6 +247,10 @@ func init() {
 	roleDescPriority := roleFields[4].Descriptor()
 	// role.DefaultPriority holds the default value on creation for the priority field.
 	role.DefaultPriority = roleDescPriority.Default.(int)
+	// roleDescMfaRequired is the schema descriptor for mfa_required field.
+	roleDescMfaRequired := roleFields[5].Descriptor()
+	// role.DefaultMfaRequired holds the default value on creation for the mfa_required field.
+	role.DefaultMfaRequired = roleDescMfaRequired.Default.(bool)
 	// roleDescID is the schema descriptor for id field.
 	roleDescID := roleMixinFields0[0].Descriptor()
 	// role.IDValidator is a validator for the "id" field. It is called by the builders before save.
@@ -371,6 +375,10 @@ func init() {
 	tenantDescIsActive := tenantFields[8].Descriptor()
 	// tenant.DefaultIsActive holds the default value on creation for the is_active field.
 	tenant.DefaultIsActive = tenantDescIsActive.Default.(bool)
+	// tenantDescMfaRequired is the schema descriptor for mfa_required field.
+	tenantDescMfaRequired := tenantFields[9].Descriptor()
+	// tenant.DefaultMfaRequired holds the default value on creation for the mfa_required field.
+	tenant.DefaultMfaRequired = tenantDescMfaRequired.Default.(bool)
 	// tenantDescID is the schema descriptor for id field.
 	tenantDescID := tenantMixinFields0[0].Descriptor()
 	// tenant.IDValidator is a validator for the "id" field. It is called by the builders before save.
@@ -491,6 +499,10 @@ func init() {
 	userDescEmailVerified := userFields[12].Descriptor()
 	// user.DefaultEmailVerified holds the default value on creation for the email_verified field.
 	user.DefaultEmailVerified = userDescEmailVerified.Default.(bool)
+	// userDescMfaEnabled is the schema descriptor for mfa_enabled field.
+	userDescMfaEnabled := userFields[15].Descriptor()
+	// user.DefaultMfaEnabled holds the default value on creation for the mfa_enabled field.
+	user.DefaultMfaEnabled = userDescMfaEnabled.Default.(bool)
 	// userDescID is the schema descriptor for id field.
 	userDescID := userMixinFields0[0].Descriptor()
 	// user.IDValidator is a validator for the "id" field. It is called by the builders before save.
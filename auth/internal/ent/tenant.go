@@ -42,7 +42,9 @@ type Tenant struct {
 	// Tenant expiration date (for trial/temporary tenants)
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 	// Whether the tenant is currently active
-	IsActive     bool `json:"is_active,omitempty"`
+	IsActive bool `json:"is_active,omitempty"`
+	// Whether users of the tenant must log in with multi-factor authentication
+	MfaRequired  bool `json:"mfa_required,omitempty"`
 	selectValues sql.SelectValues
 }
 
@@ -53,7 +55,7 @@ func (*Tenant) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case tenant.FieldSettings, tenant.FieldMetadata:
 			values[i] = new([]byte)
-		case tenant.FieldIsActive:
+		case tenant.FieldIsActive, tenant.FieldMfaRequired:
 			values[i] = new(sql.NullBool)
 		case tenant.FieldID, tenant.FieldCreatedBy:
 			values[i] = new(sql.NullInt64)
@@ -160,6 +162,12 @@ func (_m *Tenant) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.IsActive = value.Bool
 			}
+		case tenant.FieldMfaRequired:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field mfa_required", values[i])
+			} else if value.Valid {
+				_m.MfaRequired = value.Bool
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -235,6 +243,9 @@ func (_m *Tenant) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("is_active=")
 	builder.WriteString(fmt.Sprintf("%v", _m.IsActive))
+	builder.WriteString(", ")
+	builder.WriteString("mfa_required=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MfaRequired))
 	builder.WriteByte(')')
 	return builder.String()
 }
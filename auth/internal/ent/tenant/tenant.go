@@ -40,6 +40,8 @@ const (
 	FieldExpiresAt = "expires_at"
 	// FieldIsActive holds the string denoting the is_active field in the database.
 	FieldIsActive = "is_active"
+	// FieldMfaRequired holds the string denoting the mfa_required field in the database.
+	FieldMfaRequired = "mfa_required"
 	// Table holds the table name of the tenant in the database.
 	Table = "tenants"
 )
@@ -59,6 +61,7 @@ var Columns = []string{
 	FieldMetadata,
 	FieldExpiresAt,
 	FieldIsActive,
+	FieldMfaRequired,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -86,6 +89,8 @@ var (
 	DomainValidator func(string) error
 	// DefaultIsActive holds the default value on creation for the "is_active" field.
 	DefaultIsActive bool
+	// DefaultMfaRequired holds the default value on creation for the "mfa_required" field.
+	DefaultMfaRequired bool
 	// IDValidator is a validator for the "id" field. It is called by the builders before save.
 	IDValidator func(int) error
 )
@@ -176,6 +181,11 @@ func ByIsActive(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldIsActive, opts...).ToFunc()
 }
 
+// ByMfaRequired orders the results by the mfa_required field.
+func ByMfaRequired(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMfaRequired, opts...).ToFunc()
+}
+
 // MarshalGQL implements graphql.Marshaler interface.
 func (e Status) MarshalGQL(w io.Writer) {
 	io.WriteString(w, strconv.Quote(e.String()))
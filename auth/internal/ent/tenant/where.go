@@ -99,6 +99,11 @@ func IsActive(v bool) predicate.Tenant {
 	return predicate.Tenant(sql.FieldEQ(FieldIsActive, v))
 }
 
+// MfaRequired applies equality check predicate on the "mfa_required" field. It's identical to MfaRequiredEQ.
+func MfaRequired(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldMfaRequired, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Tenant {
 	return predicate.Tenant(sql.FieldEQ(FieldCreatedAt, v))
@@ -609,6 +614,16 @@ func IsActiveNEQ(v bool) predicate.Tenant {
 	return predicate.Tenant(sql.FieldNEQ(FieldIsActive, v))
 }
 
+// MfaRequiredEQ applies the EQ predicate on the "mfa_required" field.
+func MfaRequiredEQ(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldEQ(FieldMfaRequired, v))
+}
+
+// MfaRequiredNEQ applies the NEQ predicate on the "mfa_required" field.
+func MfaRequiredNEQ(v bool) predicate.Tenant {
+	return predicate.Tenant(sql.FieldNEQ(FieldMfaRequired, v))
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Tenant) predicate.Tenant {
 	return predicate.Tenant(sql.AndPredicates(predicates...))
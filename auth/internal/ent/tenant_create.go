@@ -158,6 +158,20 @@ func (_c *TenantCreate) SetNillableIsActive(v *bool) *TenantCreate {
 	return _c
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (_c *TenantCreate) SetMfaRequired(v bool) *TenantCreate {
+	_c.mutation.SetMfaRequired(v)
+	return _c
+}
+
+// SetNillableMfaRequired sets the "mfa_required" field if the given value is not nil.
+func (_c *TenantCreate) SetNillableMfaRequired(v *bool) *TenantCreate {
+	if v != nil {
+		_c.SetMfaRequired(*v)
+	}
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *TenantCreate) SetID(v int) *TenantCreate {
 	_c.mutation.SetID(v)
@@ -215,6 +229,10 @@ func (_c *TenantCreate) defaults() {
 		v := tenant.DefaultIsActive
 		_c.mutation.SetIsActive(v)
 	}
+	if _, ok := _c.mutation.MfaRequired(); !ok {
+		v := tenant.DefaultMfaRequired
+		_c.mutation.SetMfaRequired(v)
+	}
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -257,6 +275,9 @@ func (_c *TenantCreate) check() error {
 	if _, ok := _c.mutation.IsActive(); !ok {
 		return &ValidationError{Name: "is_active", err: errors.New(`ent: missing required field "Tenant.is_active"`)}
 	}
+	if _, ok := _c.mutation.MfaRequired(); !ok {
+		return &ValidationError{Name: "mfa_required", err: errors.New(`ent: missing required field "Tenant.mfa_required"`)}
+	}
 	if v, ok := _c.mutation.ID(); ok {
 		if err := tenant.IDValidator(v); err != nil {
 			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "Tenant.id": %w`, err)}
@@ -343,6 +364,10 @@ func (_c *TenantCreate) createSpec() (*Tenant, *sqlgraph.CreateSpec) {
 		_spec.SetField(tenant.FieldIsActive, field.TypeBool, value)
 		_node.IsActive = value
 	}
+	if value, ok := _c.mutation.MfaRequired(); ok {
+		_spec.SetField(tenant.FieldMfaRequired, field.TypeBool, value)
+		_node.MfaRequired = value
+	}
 	return _node, _spec
 }
 
@@ -569,6 +594,18 @@ func (u *TenantUpsert) UpdateIsActive() *TenantUpsert {
 	return u
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (u *TenantUpsert) SetMfaRequired(v bool) *TenantUpsert {
+	u.Set(tenant.FieldMfaRequired, v)
+	return u
+}
+
+// UpdateMfaRequired sets the "mfa_required" field to the value that was provided on create.
+func (u *TenantUpsert) UpdateMfaRequired() *TenantUpsert {
+	u.SetExcluded(tenant.FieldMfaRequired)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
 // Using this option is equivalent to using:
 //
@@ -823,6 +860,20 @@ func (u *TenantUpsertOne) UpdateIsActive() *TenantUpsertOne {
 	})
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (u *TenantUpsertOne) SetMfaRequired(v bool) *TenantUpsertOne {
+	return u.Update(func(s *TenantUpsert) {
+		s.SetMfaRequired(v)
+	})
+}
+
+// UpdateMfaRequired sets the "mfa_required" field to the value that was provided on create.
+func (u *TenantUpsertOne) UpdateMfaRequired() *TenantUpsertOne {
+	return u.Update(func(s *TenantUpsert) {
+		s.UpdateMfaRequired()
+	})
+}
+
 // Exec executes the query.
 func (u *TenantUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -1243,6 +1294,20 @@ func (u *TenantUpsertBulk) UpdateIsActive() *TenantUpsertBulk {
 	})
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (u *TenantUpsertBulk) SetMfaRequired(v bool) *TenantUpsertBulk {
+	return u.Update(func(s *TenantUpsert) {
+		s.SetMfaRequired(v)
+	})
+}
+
+// UpdateMfaRequired sets the "mfa_required" field to the value that was provided on create.
+func (u *TenantUpsertBulk) UpdateMfaRequired() *TenantUpsertBulk {
+	return u.Update(func(s *TenantUpsert) {
+		s.UpdateMfaRequired()
+	})
+}
+
 // Exec executes the query.
 func (u *TenantUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
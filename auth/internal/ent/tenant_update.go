@@ -202,6 +202,20 @@ func (_u *TenantUpdate) SetNillableIsActive(v *bool) *TenantUpdate {
 	return _u
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (_u *TenantUpdate) SetMfaRequired(v bool) *TenantUpdate {
+	_u.mutation.SetMfaRequired(v)
+	return _u
+}
+
+// SetNillableMfaRequired sets the "mfa_required" field if the given value is not nil.
+func (_u *TenantUpdate) SetNillableMfaRequired(v *bool) *TenantUpdate {
+	if v != nil {
+		_u.SetMfaRequired(*v)
+	}
+	return _u
+}
+
 // Mutation returns the TenantMutation object of the builder.
 func (_u *TenantUpdate) Mutation() *TenantMutation {
 	return _u.mutation
@@ -340,6 +354,9 @@ func (_u *TenantUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.IsActive(); ok {
 		_spec.SetField(tenant.FieldIsActive, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.MfaRequired(); ok {
+		_spec.SetField(tenant.FieldMfaRequired, field.TypeBool, value)
+	}
 	_spec.AddModifiers(_u.modifiers...)
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
@@ -535,6 +552,20 @@ func (_u *TenantUpdateOne) SetNillableIsActive(v *bool) *TenantUpdateOne {
 	return _u
 }
 
+// SetMfaRequired sets the "mfa_required" field.
+func (_u *TenantUpdateOne) SetMfaRequired(v bool) *TenantUpdateOne {
+	_u.mutation.SetMfaRequired(v)
+	return _u
+}
+
+// SetNillableMfaRequired sets the "mfa_required" field if the given value is not nil.
+func (_u *TenantUpdateOne) SetNillableMfaRequired(v *bool) *TenantUpdateOne {
+	if v != nil {
+		_u.SetMfaRequired(*v)
+	}
+	return _u
+}
+
 // Mutation returns the TenantMutation object of the builder.
 func (_u *TenantUpdateOne) Mutation() *TenantMutation {
 	return _u.mutation
@@ -703,6 +734,9 @@ func (_u *TenantUpdateOne) sqlSave(ctx context.Context) (_node *Tenant, err erro
 	if value, ok := _u.mutation.IsActive(); ok {
 		_spec.SetField(tenant.FieldIsActive, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.MfaRequired(); ok {
+		_spec.SetField(tenant.FieldMfaRequired, field.TypeBool, value)
+	}
 	_spec.AddModifiers(_u.modifiers...)
 	_node = &Tenant{config: _u.config}
 	_spec.Assign = _node.assignValues
@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -59,6 +60,12 @@ type User struct {
 	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
 	// LastLogin holds the value of the "last_login" field.
 	LastLogin *time.Time `json:"last_login,omitempty"`
+	// Whether login requires a TOTP or recovery code
+	MfaEnabled bool `json:"mfa_enabled,omitempty"`
+	// Base32 TOTP secret
+	MfaSecret string `json:"-"`
+	// Bcrypt hashes of the unused recovery codes
+	MfaRecoveryCodes []string `json:"-"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the UserQuery when eager-loading is set.
 	Edges        UserEdges `json:"edges"`
@@ -93,11 +100,13 @@ func (*User) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case user.FieldIsActive, user.FieldEmailVerified:
+		case user.FieldMfaRecoveryCodes:
+			values[i] = new([]byte)
+		case user.FieldIsActive, user.FieldEmailVerified, user.FieldMfaEnabled:
 			values[i] = new(sql.NullBool)
 		case user.FieldID, user.FieldCreatedBy, user.FieldTenantID, user.FieldPaymentTerms:
 			values[i] = new(sql.NullInt64)
-		case user.FieldCode, user.FieldEmail, user.FieldUsername, user.FieldPasswordHash, user.FieldName, user.FieldPhone, user.FieldAddress, user.FieldUserType, user.FieldUserCode, user.FieldCompanyName, user.FieldCustomerType:
+		case user.FieldCode, user.FieldEmail, user.FieldUsername, user.FieldPasswordHash, user.FieldName, user.FieldPhone, user.FieldAddress, user.FieldUserType, user.FieldUserCode, user.FieldCompanyName, user.FieldCustomerType, user.FieldMfaSecret:
 			values[i] = new(sql.NullString)
 		case user.FieldCreatedAt, user.FieldUpdatedAt, user.FieldEmailVerifiedAt, user.FieldLastLogin:
 			values[i] = new(sql.NullTime)
@@ -247,6 +256,26 @@ func (_m *User) assignValues(columns []string, values []any) error {
 				_m.LastLogin = new(time.Time)
 				*_m.LastLogin = value.Time
 			}
+		case user.FieldMfaEnabled:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field mfa_enabled", values[i])
+			} else if value.Valid {
+				_m.MfaEnabled = value.Bool
+			}
+		case user.FieldMfaSecret:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field mfa_secret", values[i])
+			} else if value.Valid {
+				_m.MfaSecret = value.String
+			}
+		case user.FieldMfaRecoveryCodes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field mfa_recovery_codes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.MfaRecoveryCodes); err != nil {
+					return fmt.Errorf("unmarshal field mfa_recovery_codes: %w", err)
+				}
+			}
 		case user.ForeignKeys[0]:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for edge-field user_role", value)
@@ -359,6 +388,13 @@ func (_m *User) String() string {
 		builder.WriteString("last_login=")
 		builder.WriteString(v.Format(time.ANSIC))
 	}
+	builder.WriteString(", ")
+	builder.WriteString("mfa_enabled=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MfaEnabled))
+	builder.WriteString(", ")
+	builder.WriteString("mfa_secret=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("mfa_recovery_codes=<sensitive>")
 	builder.WriteByte(')')
 	return builder.String()
 }
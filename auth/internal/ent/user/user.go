@@ -55,6 +55,12 @@ const (
 	FieldEmailVerifiedAt = "email_verified_at"
 	// FieldLastLogin holds the string denoting the last_login field in the database.
 	FieldLastLogin = "last_login"
+	// FieldMfaEnabled holds the string denoting the mfa_enabled field in the database.
+	FieldMfaEnabled = "mfa_enabled"
+	// FieldMfaSecret holds the string denoting the mfa_secret field in the database.
+	FieldMfaSecret = "mfa_secret"
+	// FieldMfaRecoveryCodes holds the string denoting the mfa_recovery_codes field in the database.
+	FieldMfaRecoveryCodes = "mfa_recovery_codes"
 	// EdgeRole holds the string denoting the role edge name in mutations.
 	EdgeRole = "role"
 	// Table holds the table name of the user in the database.
@@ -91,6 +97,9 @@ var Columns = []string{
 	FieldEmailVerified,
 	FieldEmailVerifiedAt,
 	FieldLastLogin,
+	FieldMfaEnabled,
+	FieldMfaSecret,
+	FieldMfaRecoveryCodes,
 }
 
 // ForeignKeys holds the SQL foreign-keys that are owned by the "users"
@@ -154,6 +163,8 @@ var (
 	DefaultIsActive bool
 	// DefaultEmailVerified holds the default value on creation for the "email_verified" field.
 	DefaultEmailVerified bool
+	// DefaultMfaEnabled holds the default value on creation for the "mfa_enabled" field.
+	DefaultMfaEnabled bool
 	// IDValidator is a validator for the "id" field. It is called by the builders before save.
 	IDValidator func(int) error
 )
@@ -266,6 +277,16 @@ func ByLastLogin(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldLastLogin, opts...).ToFunc()
 }
 
+// ByMfaEnabled orders the results by the mfa_enabled field.
+func ByMfaEnabled(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMfaEnabled, opts...).ToFunc()
+}
+
+// ByMfaSecret orders the results by the mfa_secret field.
+func ByMfaSecret(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMfaSecret, opts...).ToFunc()
+}
+
 // ByRoleField orders the results by role field.
 func ByRoleField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -255,6 +255,40 @@ func (_c *UserCreate) SetNillableLastLogin(v *time.Time) *UserCreate {
 	return _c
 }
 
+// SetMfaEnabled sets the "mfa_enabled" field.
+func (_c *UserCreate) SetMfaEnabled(v bool) *UserCreate {
+	_c.mutation.SetMfaEnabled(v)
+	return _c
+}
+
+// SetNillableMfaEnabled sets the "mfa_enabled" field if the given value is not nil.
+func (_c *UserCreate) SetNillableMfaEnabled(v *bool) *UserCreate {
+	if v != nil {
+		_c.SetMfaEnabled(*v)
+	}
+	return _c
+}
+
+// SetMfaSecret sets the "mfa_secret" field.
+func (_c *UserCreate) SetMfaSecret(v string) *UserCreate {
+	_c.mutation.SetMfaSecret(v)
+	return _c
+}
+
+// SetNillableMfaSecret sets the "mfa_secret" field if the given value is not nil.
+func (_c *UserCreate) SetNillableMfaSecret(v *string) *UserCreate {
+	if v != nil {
+		_c.SetMfaSecret(*v)
+	}
+	return _c
+}
+
+// SetMfaRecoveryCodes sets the "mfa_recovery_codes" field.
+func (_c *UserCreate) SetMfaRecoveryCodes(v []string) *UserCreate {
+	_c.mutation.SetMfaRecoveryCodes(v)
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *UserCreate) SetID(v int) *UserCreate {
 	_c.mutation.SetID(v)
@@ -343,6 +377,10 @@ func (_c *UserCreate) defaults() error {
 		v := user.DefaultEmailVerified
 		_c.mutation.SetEmailVerified(v)
 	}
+	if _, ok := _c.mutation.MfaEnabled(); !ok {
+		v := user.DefaultMfaEnabled
+		_c.mutation.SetMfaEnabled(v)
+	}
 	return nil
 }
 
@@ -431,6 +469,9 @@ func (_c *UserCreate) check() error {
 	if _, ok := _c.mutation.EmailVerified(); !ok {
 		return &ValidationError{Name: "email_verified", err: errors.New(`ent: missing required field "User.email_verified"`)}
 	}
+	if _, ok := _c.mutation.MfaEnabled(); !ok {
+		return &ValidationError{Name: "mfa_enabled", err: errors.New(`ent: missing required field "User.mfa_enabled"`)}
+	}
 	if v, ok := _c.mutation.ID(); ok {
 		if err := user.IDValidator(v); err != nil {
 			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "User.id": %w`, err)}
@@ -549,6 +590,18 @@ func (_c *UserCreate) createSpec() (*User, *sqlgraph.CreateSpec) {
 		_spec.SetField(user.FieldLastLogin, field.TypeTime, value)
 		_node.LastLogin = &value
 	}
+	if value, ok := _c.mutation.MfaEnabled(); ok {
+		_spec.SetField(user.FieldMfaEnabled, field.TypeBool, value)
+		_node.MfaEnabled = value
+	}
+	if value, ok := _c.mutation.MfaSecret(); ok {
+		_spec.SetField(user.FieldMfaSecret, field.TypeString, value)
+		_node.MfaSecret = value
+	}
+	if value, ok := _c.mutation.MfaRecoveryCodes(); ok {
+		_spec.SetField(user.FieldMfaRecoveryCodes, field.TypeJSON, value)
+		_node.MfaRecoveryCodes = value
+	}
 	if nodes := _c.mutation.RoleIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -906,6 +959,54 @@ func (u *UserUpsert) ClearLastLogin() *UserUpsert {
 	return u
 }
 
+// SetMfaEnabled sets the "mfa_enabled" field.
+func (u *UserUpsert) SetMfaEnabled(v bool) *UserUpsert {
+	u.Set(user.FieldMfaEnabled, v)
+	return u
+}
+
+// UpdateMfaEnabled sets the "mfa_enabled" field to the value that was provided on create.
+func (u *UserUpsert) UpdateMfaEnabled() *UserUpsert {
+	u.SetExcluded(user.FieldMfaEnabled)
+	return u
+}
+
+// SetMfaSecret sets the "mfa_secret" field.
+func (u *UserUpsert) SetMfaSecret(v string) *UserUpsert {
+	u.Set(user.FieldMfaSecret, v)
+	return u
+}
+
+// UpdateMfaSecret sets the "mfa_secret" field to the value that was provided on create.
+func (u *UserUpsert) UpdateMfaSecret() *UserUpsert {
+	u.SetExcluded(user.FieldMfaSecret)
+	return u
+}
+
+// ClearMfaSecret clears the value of the "mfa_secret" field.
+func (u *UserUpsert) ClearMfaSecret() *UserUpsert {
+	u.SetNull(user.FieldMfaSecret)
+	return u
+}
+
+// SetMfaRecoveryCodes sets the "mfa_recovery_codes" field.
+func (u *UserUpsert) SetMfaRecoveryCodes(v []string) *UserUpsert {
+	u.Set(user.FieldMfaRecoveryCodes, v)
+	return u
+}
+
+// UpdateMfaRecoveryCodes sets the "mfa_recovery_codes" field to the value that was provided on create.
+func (u *UserUpsert) UpdateMfaRecoveryCodes() *UserUpsert {
+	u.SetExcluded(user.FieldMfaRecoveryCodes)
+	return u
+}
+
+// ClearMfaRecoveryCodes clears the value of the "mfa_recovery_codes" field.
+func (u *UserUpsert) ClearMfaRecoveryCodes() *UserUpsert {
+	u.SetNull(user.FieldMfaRecoveryCodes)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
 // Using this option is equivalent to using:
 //
@@ -1296,6 +1397,62 @@ func (u *UserUpsertOne) ClearLastLogin() *UserUpsertOne {
 	})
 }
 
+// SetMfaEnabled sets the "mfa_enabled" field.
+func (u *UserUpsertOne) SetMfaEnabled(v bool) *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.SetMfaEnabled(v)
+	})
+}
+
+// UpdateMfaEnabled sets the "mfa_enabled" field to the value that was provided on create.
+func (u *UserUpsertOne) UpdateMfaEnabled() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateMfaEnabled()
+	})
+}
+
+// SetMfaSecret sets the "mfa_secret" field.
+func (u *UserUpsertOne) SetMfaSecret(v string) *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.SetMfaSecret(v)
+	})
+}
+
+// UpdateMfaSecret sets the "mfa_secret" field to the value that was provided on create.
+func (u *UserUpsertOne) UpdateMfaSecret() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateMfaSecret()
+	})
+}
+
+// ClearMfaSecret clears the value of the "mfa_secret" field.
+func (u *UserUpsertOne) ClearMfaSecret() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.ClearMfaSecret()
+	})
+}
+
+// SetMfaRecoveryCodes sets the "mfa_recovery_codes" field.
+func (u *UserUpsertOne) SetMfaRecoveryCodes(v []string) *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.SetMfaRecoveryCodes(v)
+	})
+}
+
+// UpdateMfaRecoveryCodes sets the "mfa_recovery_codes" field to the value that was provided on create.
+func (u *UserUpsertOne) UpdateMfaRecoveryCodes() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateMfaRecoveryCodes()
+	})
+}
+
+// ClearMfaRecoveryCodes clears the value of the "mfa_recovery_codes" field.
+func (u *UserUpsertOne) ClearMfaRecoveryCodes() *UserUpsertOne {
+	return u.Update(func(s *UserUpsert) {
+		s.ClearMfaRecoveryCodes()
+	})
+}
+
 // Exec executes the query.
 func (u *UserUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -1852,6 +2009,62 @@ func (u *UserUpsertBulk) ClearLastLogin() *UserUpsertBulk {
 	})
 }
 
+// SetMfaEnabled sets the "mfa_enabled" field.
+func (u *UserUpsertBulk) SetMfaEnabled(v bool) *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.SetMfaEnabled(v)
+	})
+}
+
+// UpdateMfaEnabled sets the "mfa_enabled" field to the value that was provided on create.
+func (u *UserUpsertBulk) UpdateMfaEnabled() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateMfaEnabled()
+	})
+}
+
+// SetMfaSecret sets the "mfa_secret" field.
+func (u *UserUpsertBulk) SetMfaSecret(v string) *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.SetMfaSecret(v)
+	})
+}
+
+// UpdateMfaSecret sets the "mfa_secret" field to the value that was provided on create.
+func (u *UserUpsertBulk) UpdateMfaSecret() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateMfaSecret()
+	})
+}
+
+// ClearMfaSecret clears the value of the "mfa_secret" field.
+func (u *UserUpsertBulk) ClearMfaSecret() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.ClearMfaSecret()
+	})
+}
+
+// SetMfaRecoveryCodes sets the "mfa_recovery_codes" field.
+func (u *UserUpsertBulk) SetMfaRecoveryCodes(v []string) *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.SetMfaRecoveryCodes(v)
+	})
+}
+
+// UpdateMfaRecoveryCodes sets the "mfa_recovery_codes" field to the value that was provided on create.
+func (u *UserUpsertBulk) UpdateMfaRecoveryCodes() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.UpdateMfaRecoveryCodes()
+	})
+}
+
+// ClearMfaRecoveryCodes clears the value of the "mfa_recovery_codes" field.
+func (u *UserUpsertBulk) ClearMfaRecoveryCodes() *UserUpsertBulk {
+	return u.Update(func(s *UserUpsert) {
+		s.ClearMfaRecoveryCodes()
+	})
+}
+
 // Exec executes the query.
 func (u *UserUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
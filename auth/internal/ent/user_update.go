@@ -10,6 +10,7 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 	"github.com/saurabh/entgo-microservices/auth/internal/ent/predicate"
 	"github.com/saurabh/entgo-microservices/auth/internal/ent/role"
@@ -349,6 +350,58 @@ func (_u *UserUpdate) ClearLastLogin() *UserUpdate {
 	return _u
 }
 
+// SetMfaEnabled sets the "mfa_enabled" field.
+func (_u *UserUpdate) SetMfaEnabled(v bool) *UserUpdate {
+	_u.mutation.SetMfaEnabled(v)
+	return _u
+}
+
+// SetNillableMfaEnabled sets the "mfa_enabled" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableMfaEnabled(v *bool) *UserUpdate {
+	if v != nil {
+		_u.SetMfaEnabled(*v)
+	}
+	return _u
+}
+
+// SetMfaSecret sets the "mfa_secret" field.
+func (_u *UserUpdate) SetMfaSecret(v string) *UserUpdate {
+	_u.mutation.SetMfaSecret(v)
+	return _u
+}
+
+// SetNillableMfaSecret sets the "mfa_secret" field if the given value is not nil.
+func (_u *UserUpdate) SetNillableMfaSecret(v *string) *UserUpdate {
+	if v != nil {
+		_u.SetMfaSecret(*v)
+	}
+	return _u
+}
+
+// ClearMfaSecret clears the value of the "mfa_secret" field.
+func (_u *UserUpdate) ClearMfaSecret() *UserUpdate {
+	_u.mutation.ClearMfaSecret()
+	return _u
+}
+
+// SetMfaRecoveryCodes sets the "mfa_recovery_codes" field.
+func (_u *UserUpdate) SetMfaRecoveryCodes(v []string) *UserUpdate {
+	_u.mutation.SetMfaRecoveryCodes(v)
+	return _u
+}
+
+// AppendMfaRecoveryCodes appends value to the "mfa_recovery_codes" field.
+func (_u *UserUpdate) AppendMfaRecoveryCodes(v []string) *UserUpdate {
+	_u.mutation.AppendMfaRecoveryCodes(v)
+	return _u
+}
+
+// ClearMfaRecoveryCodes clears the value of the "mfa_recovery_codes" field.
+func (_u *UserUpdate) ClearMfaRecoveryCodes() *UserUpdate {
+	_u.mutation.ClearMfaRecoveryCodes()
+	return _u
+}
+
 // SetRoleID sets the "role" edge to the Role entity by ID.
 func (_u *UserUpdate) SetRoleID(id int) *UserUpdate {
 	_u.mutation.SetRoleID(id)
@@ -584,6 +637,26 @@ func (_u *UserUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.LastLoginCleared() {
 		_spec.ClearField(user.FieldLastLogin, field.TypeTime)
 	}
+	if value, ok := _u.mutation.MfaEnabled(); ok {
+		_spec.SetField(user.FieldMfaEnabled, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.MfaSecret(); ok {
+		_spec.SetField(user.FieldMfaSecret, field.TypeString, value)
+	}
+	if _u.mutation.MfaSecretCleared() {
+		_spec.ClearField(user.FieldMfaSecret, field.TypeString)
+	}
+	if value, ok := _u.mutation.MfaRecoveryCodes(); ok {
+		_spec.SetField(user.FieldMfaRecoveryCodes, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedMfaRecoveryCodes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, user.FieldMfaRecoveryCodes, value)
+		})
+	}
+	if _u.mutation.MfaRecoveryCodesCleared() {
+		_spec.ClearField(user.FieldMfaRecoveryCodes, field.TypeJSON)
+	}
 	if _u.mutation.RoleCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -954,6 +1027,58 @@ func (_u *UserUpdateOne) ClearLastLogin() *UserUpdateOne {
 	return _u
 }
 
+// SetMfaEnabled sets the "mfa_enabled" field.
+func (_u *UserUpdateOne) SetMfaEnabled(v bool) *UserUpdateOne {
+	_u.mutation.SetMfaEnabled(v)
+	return _u
+}
+
+// SetNillableMfaEnabled sets the "mfa_enabled" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableMfaEnabled(v *bool) *UserUpdateOne {
+	if v != nil {
+		_u.SetMfaEnabled(*v)
+	}
+	return _u
+}
+
+// SetMfaSecret sets the "mfa_secret" field.
+func (_u *UserUpdateOne) SetMfaSecret(v string) *UserUpdateOne {
+	_u.mutation.SetMfaSecret(v)
+	return _u
+}
+
+// SetNillableMfaSecret sets the "mfa_secret" field if the given value is not nil.
+func (_u *UserUpdateOne) SetNillableMfaSecret(v *string) *UserUpdateOne {
+	if v != nil {
+		_u.SetMfaSecret(*v)
+	}
+	return _u
+}
+
+// ClearMfaSecret clears the value of the "mfa_secret" field.
+func (_u *UserUpdateOne) ClearMfaSecret() *UserUpdateOne {
+	_u.mutation.ClearMfaSecret()
+	return _u
+}
+
+// SetMfaRecoveryCodes sets the "mfa_recovery_codes" field.
+func (_u *UserUpdateOne) SetMfaRecoveryCodes(v []string) *UserUpdateOne {
+	_u.mutation.SetMfaRecoveryCodes(v)
+	return _u
+}
+
+// AppendMfaRecoveryCodes appends value to the "mfa_recovery_codes" field.
+func (_u *UserUpdateOne) AppendMfaRecoveryCodes(v []string) *UserUpdateOne {
+	_u.mutation.AppendMfaRecoveryCodes(v)
+	return _u
+}
+
+// ClearMfaRecoveryCodes clears the value of the "mfa_recovery_codes" field.
+func (_u *UserUpdateOne) ClearMfaRecoveryCodes() *UserUpdateOne {
+	_u.mutation.ClearMfaRecoveryCodes()
+	return _u
+}
+
 // SetRoleID sets the "role" edge to the Role entity by ID.
 func (_u *UserUpdateOne) SetRoleID(id int) *UserUpdateOne {
 	_u.mutation.SetRoleID(id)
@@ -1219,6 +1344,26 @@ func (_u *UserUpdateOne) sqlSave(ctx context.Context) (_node *User, err error) {
 	if _u.mutation.LastLoginCleared() {
 		_spec.ClearField(user.FieldLastLogin, field.TypeTime)
 	}
+	if value, ok := _u.mutation.MfaEnabled(); ok {
+		_spec.SetField(user.FieldMfaEnabled, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.MfaSecret(); ok {
+		_spec.SetField(user.FieldMfaSecret, field.TypeString, value)
+	}
+	if _u.mutation.MfaSecretCleared() {
+		_spec.ClearField(user.FieldMfaSecret, field.TypeString)
+	}
+	if value, ok := _u.mutation.MfaRecoveryCodes(); ok {
+		_spec.SetField(user.FieldMfaRecoveryCodes, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedMfaRecoveryCodes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, user.FieldMfaRecoveryCodes, value)
+		})
+	}
+	if _u.mutation.MfaRecoveryCodesCleared() {
+		_spec.ClearField(user.FieldMfaRecoveryCodes, field.TypeJSON)
+	}
 	if _u.mutation.RoleCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
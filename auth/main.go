@@ -53,7 +53,7 @@ func main() {
 	}
 
 	// Initialize and start HTTP server with JWT service
-	server := utils.InitializeServer(cfg, deps.DB, deps.JWTService, deps.Redis)
+	server := utils.InitializeServer(cfg, deps.DB, deps.JWTService, deps.KeyRing, deps.Redis, deps.Mailer)
 
 	// Initialize gRPC server
 	grpcPort := cfg.Server.Port + 1000 // Default: 9081 if HTTP is 8081
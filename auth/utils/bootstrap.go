@@ -2,12 +2,14 @@ package utils
 
 import (
 	"errors"
+	"time"
 
 	"github.com/saurabh/entgo-microservices/auth/config"
 	"github.com/saurabh/entgo-microservices/auth/utils/database"
 
 	"github.com/saurabh/entgo-microservices/pkg/jwt"
 	"github.com/saurabh/entgo-microservices/pkg/logger"
+	"github.com/saurabh/entgo-microservices/pkg/mail"
 )
 
 // Deps groups runtime dependencies initialized at startup
@@ -15,14 +17,18 @@ type Deps struct {
 	DB         *database.DB
 	Redis      *database.RedisClient
 	JWTService *jwt.Service
+	KeyRing    *jwt.KeyRing // Published as JWKS, nil when tokens are signed with JWT_SECRET
+	Mailer     mail.Mailer
 }
 
-// InitializeDependencies sets up DB, Redis and JWT service
+// InitializeDependencies sets up DB, Redis, JWT service and mailer
 func InitializeDependencies(cfg *config.Config) (*Deps, error) {
 	var (
 		db          *database.DB
 		redisClient *database.RedisClient
 		jwtService  *jwt.Service
+		keyRing     *jwt.KeyRing
+		mailer      mail.Mailer
 		err         error
 	)
 
@@ -64,9 +70,36 @@ func InitializeDependencies(cfg *config.Config) (*Deps, error) {
 	}
 
 	// Initialize JWT service with Redis and "auth" service name for key namespacing
-	jwtService = jwt.NewService(cfg.JWT.Secret, cfg.JWT.ExpiryHours, redisClient.Client, "auth")
-	logger.Info("JWT service initialized with Redis token management")
+	if cfg.JWT.KeysFile != "" {
+		// Asymmetric keys; other services verify tokens with the published public keys
+		keyRing, err = jwt.LoadKeyRing(cfg.JWT.KeysFile, time.Duration(cfg.JWT.KeyOverlapHours)*time.Hour)
+		if err != nil {
+			logger.WithError(err).Error("Failed to load JWT key ring")
+			return nil, err
+		}
+		jwtService = jwt.NewServiceWithKeys(keyRing, cfg.JWT.ExpiryHours, redisClient.Client, "auth")
+		logger.WithField("keys_file", cfg.JWT.KeysFile).Info("JWT service initialized with key ring and Redis token management")
+	} else {
+		jwtService = jwt.NewService(cfg.JWT.Secret, cfg.JWT.ExpiryHours, redisClient.Client, "auth")
+		logger.Info("JWT service initialized with Redis token management")
+	}
+
+	// Initialize mailer for password reset and email verification
+	mailer, err = mail.New(mail.Config{
+		Driver:   cfg.Mail.Driver,
+		From:     cfg.Mail.From,
+		Host:     cfg.Mail.SMTPHost,
+		Port:     cfg.Mail.SMTPPort,
+		Username: cfg.Mail.SMTPUsername,
+		Password: cfg.Mail.SMTPPassword,
+		Dir:      cfg.Mail.FileDir,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to initialize mailer")
+		return nil, err
+	}
+	logger.WithField("driver", cfg.Mail.Driver).Info("Mailer initialized")
 
 	// Success — cancel deferred cleanup by setting err to nil and returning resources
-	return &Deps{DB: db, Redis: redisClient, JWTService: jwtService}, nil
+	return &Deps{DB: db, Redis: redisClient, JWTService: jwtService, KeyRing: keyRing, Mailer: mailer}, nil
 }
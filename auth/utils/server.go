@@ -14,6 +14,7 @@ import (
 	pkggraphql "github.com/saurabh/entgo-microservices/pkg/graphql"
 	"github.com/saurabh/entgo-microservices/pkg/jwt"
 	"github.com/saurabh/entgo-microservices/pkg/logger"
+	"github.com/saurabh/entgo-microservices/pkg/mail"
 	pkgmiddleware "github.com/saurabh/entgo-microservices/pkg/middleware"
 
 	"github.com/99designs/gqlgen/graphql/handler"
@@ -33,7 +34,8 @@ type ServerConfig struct {
 }
 
 // InitializeServer sets up the HTTP server with all routes and middleware
-func InitializeServer(cfg *config.Config, db *database.DB, jwtService *jwt.Service, redis *database.RedisClient) *ServerConfig {
+// keyRing is published at jwt.JWKSPath so other services can verify tokens; nil publishes an empty set
+func InitializeServer(cfg *config.Config, db *database.DB, jwtService *jwt.Service, keyRing *jwt.KeyRing, redis *database.RedisClient, mailer mail.Mailer) *ServerConfig {
 	logger.Info("Initializing HTTP server")
 
 	// Set Gin mode based on environment
@@ -46,14 +48,27 @@ func InitializeServer(cfg *config.Config, db *database.DB, jwtService *jwt.Servi
 	// Create Gin router
 	router := gin.New()
 
+	// Client IPs follow X-Forwarded-For only from trusted proxies, so clients cannot spoof the IP of their sessions
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.WithError(err).Fatal("Invalid trusted proxies")
+	}
+
 	// Add middleware
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 	router.Use(pkgmiddleware.CORS())
+	router.Use(pkgmiddleware.Client())
 
 	// Initialize GraphQL resolver with JWT service and Redis client
 	// Gateway client will be initialized on-demand when needed (since gateway starts after microservices)
-	resolver := graph.NewResolver(db.Client, jwtService, redis.Client, nil)
+	resolver := graph.NewResolver(db.Client, jwtService, redis.Client, nil, graph.AccountFlows{
+		Mailer:          mailer,
+		AppURL:          cfg.Account.AppURL,
+		ResetTTL:        time.Duration(cfg.Account.PasswordResetMinutes) * time.Minute,
+		VerificationTTL: time.Duration(cfg.Account.EmailVerificationHours) * time.Hour,
+		MfaIssuer:       cfg.Account.MfaIssuer,
+		MfaChallengeTTL: time.Duration(cfg.Account.MfaChallengeMinutes) * time.Minute,
+	})
 
 	// Create GraphQL server with directive configuration
 	graphqlSrv := handler.New(graph.NewExecutableSchema(graph.Config{
@@ -99,11 +114,16 @@ func InitializeServer(cfg *config.Config, db *database.DB, jwtService *jwt.Servi
 	graphqlSrv.Use(extension.Introspection{})
 
 	// Initialize JWT auth middleware from pkg (just reads from Redis)
-	jwtAuthMiddleware := pkgmiddleware.NewJWTAuthMiddleware(jwtService, redis.Client, "auth")
+	jwtAuthMiddleware := pkgmiddleware.NewJWTAuthMiddleware(jwtService, redis.Client, "auth").
+		TrustGateway(cfg.JWT.GatewayIdentitySecret)
+
+	// Raw token for resolvers handling it themselves, e.g. refreshToken and logout
+	tokenMiddleware := pkgmiddleware.NewAuthMiddleware(jwtService)
 
 	// GraphQL routes with authentication middleware (use /graphql)
-	router.POST("/graphql", gin.WrapH(jwtAuthMiddleware.Middleware(graphqlSrv)))
-	router.GET("/graphql", gin.WrapH(jwtAuthMiddleware.Middleware(graphqlSrv)))
+	graphqlHandler := gin.WrapH(tokenMiddleware.Middleware(jwtAuthMiddleware.Middleware(graphqlSrv)))
+	router.POST("/graphql", graphqlHandler)
+	router.GET("/graphql", graphqlHandler)
 
 	// GraphQL playground (only in development)
 	if cfg.App.Environment != "production" {
@@ -124,6 +144,16 @@ func InitializeServer(cfg *config.Config, db *database.DB, jwtService *jwt.Servi
 		c.JSON(http.StatusOK, gin.H{"message": "pong"})
 	})
 
+	// Public keys for validating tokens without the signing secret (no auth required)
+	router.GET(jwt.JWKSPath, func(c *gin.Context) {
+		set := &jwt.JWKS{Keys: []jwt.JWK{}}
+		if keyRing != nil {
+			set = keyRing.JWKS()
+		}
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, set)
+	})
+
 	return &ServerConfig{
 		Router:     router,
 		GraphQLSrv: graphqlSrv,
@@ -155,6 +185,7 @@ func (s *ServerConfig) StartServer() error {
 	}
 	logger.Infof("🔍 GraphQL Endpoint: http://localhost%s/graphql", addr)
 	logger.Infof("❤️  Health Check: http://localhost%s/health", addr)
+	logger.Infof("🔑 JWKS: http://localhost%s%s", addr, jwt.JWKSPath)
 
 	return s.httpServer.ListenAndServe()
 }
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/saurabh/entgo-microservices/pkg/cache"
+	"github.com/saurabh/entgo-microservices/pkg/identity"
+	"github.com/saurabh/entgo-microservices/pkg/jwt"
+)
+
+// tokenNamespace is the Redis prefix the auth service uses for token lists and cached users
+const tokenNamespace = "auth"
+
+// Authenticator validates bearer tokens at the gateway and signs the identity forwarded to services
+type Authenticator struct {
+	jwtService     *jwt.Service
+	redisClient    *redis.Client
+	identitySecret []byte
+	identityTTL    time.Duration
+}
+
+// NewAuthenticator creates an authenticator that validates tokens against keys
+// Identities are signed with identitySecret and expire after identityTTL, or earlier with their token
+func NewAuthenticator(keys jwt.Keys, redisClient *redis.Client, identitySecret string, identityTTL time.Duration) *Authenticator {
+	return &Authenticator{
+		jwtService:     jwt.NewServiceWithKeys(keys, 0, redisClient, tokenNamespace),
+		redisClient:    redisClient,
+		identitySecret: []byte(identitySecret),
+		identityTTL:    identityTTL,
+	}
+}
+
+// Authenticate validates the token, rejects revoked tokens and inactive users, and returns the caller with its signed identity
+func (a *Authenticator) Authenticate(ctx context.Context, authorization string) (*identity.Identity, string, error) {
+	if authorization == "" {
+		return nil, "", nil
+	}
+
+	// Signature, expiry and the auth service's whitelist and blacklist
+	claims, err := a.jwtService.ValidateToken(ctx, strings.TrimPrefix(authorization, "Bearer "))
+	if err != nil {
+		return nil, "", err
+	}
+	if claims.TokenType != "access" {
+		return nil, "", errors.New("not an access token")
+	}
+
+	id := &identity.Identity{
+		UserID:    claims.UserID,
+		TokenID:   claims.ID,
+		ExpiresAt: time.Now().Add(a.identityTTL).Unix(),
+	}
+	if claims.ExpiresAt != nil && claims.ExpiresAt.Unix() < id.ExpiresAt {
+		id.ExpiresAt = claims.ExpiresAt.Unix()
+	}
+
+	// Tenant and role come from the user cache the auth service maintains
+	if cached, err := cache.GetUserFromCache(ctx, a.redisClient, tokenNamespace, claims.UserID); err == nil && cached.User != nil {
+		if !cached.User.IsActive {
+			return nil, "", fmt.Errorf("user %d is deactivated", claims.UserID)
+		}
+		id.TenantID = cached.User.TenantID
+		if cached.Role != nil {
+			id.Role = cached.Role.Name
+		}
+	}
+
+	signed, err := identity.Sign(id, a.identitySecret)
+	if err != nil {
+		return nil, "", err
+	}
+	return id, signed, nil
+}
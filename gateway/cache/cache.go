@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Entry is a cached GraphQL response
+type Entry struct {
+	Body     json.RawMessage `json:"body"`     // Response body as returned to the client
+	MaxAge   int             `json:"maxAge"`   // Seconds the response may be served from the cache
+	Private  bool            `json:"private"`  // Response is specific to one caller
+	StoredAt time.Time       `json:"storedAt"` // When the response was produced
+}
+
+// Age returns the number of seconds since the response was produced
+func (e *Entry) Age() int {
+	return int(time.Since(e.StoredAt).Seconds())
+}
+
+// Store keeps responses in Redis and tags them with the types they contain
+type Store struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+// storeEntry writes an entry and adds it to the set of each of its types
+// KEYS holds the entry key followed by the type sets; ARGV holds the entry and its TTL in ms
+// A type set lives as long as its longest-lived entry
+var storeEntry = redis.NewScript(`
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+for i = 2, #KEYS do
+	redis.call('SADD', KEYS[i], KEYS[1])
+	if redis.call('PTTL', KEYS[i]) < tonumber(ARGV[2]) then
+		redis.call('PEXPIRE', KEYS[i], ARGV[2])
+	end
+end
+return 1
+`)
+
+// invalidateTypes deletes every entry in the given type sets and the sets themselves
+// Returns the number of entries deleted
+var invalidateTypes = redis.NewScript(`
+local removed = 0
+for _, set in ipairs(KEYS) do
+	for _, key in ipairs(redis.call('SMEMBERS', set)) do
+		removed = removed + redis.call('DEL', key)
+	end
+	redis.call('DEL', set)
+end
+return removed
+`)
+
+// NewStore creates a response cache storing its entries under gateway:cache
+func NewStore(client *redis.Client) *Store {
+	return &Store{client: client, keyPrefix: "gateway:cache"}
+}
+
+// Get returns the entry stored under key, or nil when there is none
+func (s *Store) Get(ctx context.Context, key string) (*Entry, error) {
+	data, err := s.client.Get(ctx, s.entryKey(key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("invalid cache entry %s: %w", key, err)
+	}
+	return &entry, nil
+}
+
+// Set stores an entry for its max age and tags it with the types of the response
+func (s *Store) Set(ctx context.Context, key string, entry *Entry, types []string) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(types)+1)
+	keys = append(keys, s.entryKey(key))
+	for _, typeName := range types {
+		keys = append(keys, s.typeKey(typeName))
+	}
+	ttl := time.Duration(entry.MaxAge) * time.Second
+	return storeEntry.Run(ctx, s.client, keys, data, ttl.Milliseconds()).Err()
+}
+
+// Invalidate deletes every entry containing one of the types and returns how many were deleted
+func (s *Store) Invalidate(ctx context.Context, types []string) (int, error) {
+	if len(types) == 0 {
+		return 0, nil
+	}
+
+	keys := make([]string, len(types))
+	for i, typeName := range types {
+		keys[i] = s.typeKey(typeName)
+	}
+	removed, err := invalidateTypes.Run(ctx, s.client, keys).Int()
+	return removed, err
+}
+
+func (s *Store) entryKey(key string) string {
+	return s.keyPrefix + ":entry:" + key
+}
+
+func (s *Store) typeKey(typeName string) string {
+	return s.keyPrefix + ":type:" + typeName
+}
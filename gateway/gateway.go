@@ -1,21 +1,42 @@
 package main
 
 import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
 
+	"github.com/saurabh/entgo-microservices/gateway/auth"
+	"github.com/saurabh/entgo-microservices/gateway/cache"
+	"github.com/saurabh/entgo-microservices/gateway/grpc"
+	"github.com/saurabh/entgo-microservices/gateway/persisted"
+	"github.com/saurabh/entgo-microservices/gateway/ratelimit"
 	"github.com/saurabh/entgo-microservices/gateway/router"
 	"github.com/saurabh/entgo-microservices/gateway/schema"
 	"github.com/saurabh/entgo-microservices/gateway/utils"
+	"github.com/saurabh/entgo-microservices/pkg/jwt"
 
+	"github.com/vektah/gqlparser/v2/ast"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // schemaManager holds the schema data to be accessed by the router
 var schemaManager *schema.Manager
 
+// persistedQueries holds registered operations, nil when persisted queries are disabled
+var persistedQueries *persisted.Store
+
+// maxManifestSize bounds the size of an uploaded persisted query manifest
+const maxManifestSize = 10 << 20
+
 // Setup initializes the gateway components
-func Setup() *router.Router {
+func Setup(config *utils.Config) (*router.Router, error) {
 	// Configure logging
 	setupLogging()
 
@@ -39,14 +60,239 @@ func Setup() *router.Router {
 
 	// Initialize schema manager and collect schemas
 	schemaManager = schema.NewManager(services)
-	schemaManager.Initialize()
+	schemaManager.Composition = schema.CompositionConfig{
+		Mode:       config.CompositionMode,
+		Precedence: config.CompositionPrecedence,
+	}
+	schemaManager.Registry = newSchemaRegistry(config)
+	if err := schemaManager.Initialize(); err != nil {
+		return nil, err
+	}
 
 	// Show schema collection results
 	fmt.Println("\n📊 Schema Collection Results:")
 	fmt.Println(schemaManager.Debug())
 
+	// Pick up services that were down at startup and schema changes of running services
+	schemaManager.StartRefresher(config.SchemaRefreshInterval)
+
 	// Create router using adapter pattern
-	return router.NewRouter(newSchemaAdapter(schemaManager))
+	graphQLRouter := router.NewRouter(newSchemaAdapter(schemaManager))
+
+	// Per-service timeouts, retries and circuit breakers for forwarded requests
+	defaults := router.ServicePolicy{
+		Timeout:          config.ForwardTimeout,
+		MaxRetries:       config.ForwardMaxRetries,
+		RetryBackoff:     config.ForwardRetryBackoff,
+		FailureThreshold: config.BreakerFailureThreshold,
+		OpenTimeout:      config.BreakerOpenTimeout,
+		MaxConnections:   config.ForwardMaxConns,
+	}
+	policies := make(map[string]router.ServicePolicy, len(serviceConfigs))
+	for _, cfg := range serviceConfigs {
+		policy := defaults
+		if cfg.Timeout > 0 {
+			policy.Timeout = cfg.Timeout
+		}
+		policies[cfg.URL] = policy
+	}
+	graphQLRouter.SetServicePolicies(defaults, policies)
+
+	// Validate tokens once at the edge and forward a signed identity to the services
+	if edgeAuthEnabled(config) {
+		graphQLRouter.SetAuthenticator(newAuthenticator(config))
+		fmt.Println("🔐 Edge authentication enabled")
+	} else {
+		fmt.Println("⚠️  Edge authentication disabled, set JWT_JWKS_URL or JWT_SECRET, and GATEWAY_IDENTITY_SECRET, to enable it")
+	}
+
+	// Per user, tenant, IP and operation rate limits shared by all gateway instances through Redis
+	if config.RateLimitEnabled && utils.Client != nil {
+		trustedProxies, err := ratelimit.ParseNetworks(config.RateLimitTrustedProxies)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RATE_LIMIT_TRUSTED_PROXIES: %w", err)
+		}
+		graphQLRouter.SetRateLimiter(ratelimit.New(utils.Client, ratelimit.Config{
+			User:              ratelimit.Rule{Limit: config.RateLimitUser, Window: config.RateLimitWindow},
+			Tenant:            ratelimit.Rule{Limit: config.RateLimitTenant, Window: config.RateLimitWindow},
+			IP:                ratelimit.Rule{Limit: config.RateLimitIP, Window: config.RateLimitWindow},
+			Operations:        ratelimit.ParseRules(config.RateLimitOperations, config.RateLimitWindow),
+			TrustForwardedFor: config.RateLimitTrustForwardedFor,
+			TrustedProxies:    trustedProxies,
+		}))
+		fmt.Println("🚦 Rate limiting enabled")
+	}
+
+	// Depth, alias and cost limits checked before any operation is forwarded
+	graphQLRouter.SetQueryLimits(router.QueryLimits{
+		MaxDepth:              config.QueryMaxDepth,
+		MaxIntrospectionDepth: config.QueryMaxIntrospectionDepth,
+		MaxAliases:            config.QueryMaxAliases,
+		MaxCost:               config.QueryMaxCost,
+		CostByRole:            config.QueryCostByRole,
+		CostByTenant:          config.QueryCostByTenant,
+		DefaultListSize:       config.QueryDefaultListSize,
+	})
+
+	// Automatic persisted queries, or only the operations of uploaded manifests
+	switch config.PersistedQueries {
+	case "off", "":
+	case "apq", "allowlist":
+		// Whoever can upload a manifest decides what the allow-list runs
+		if config.PersistedQueries == "allowlist" && config.AdminToken == "" {
+			return nil, fmt.Errorf("PERSISTED_QUERIES=allowlist requires GATEWAY_ADMIN_TOKEN to protect manifest uploads")
+		}
+		if utils.Client == nil {
+			fmt.Println("⚠️  Persisted queries disabled, Redis is not connected")
+			break
+		}
+		persistedQueries = persisted.NewStore(utils.Client, config.PersistedQueryTTL)
+		graphQLRouter.SetPersistedQueries(persistedQueries, config.PersistedQueries == "allowlist")
+		fmt.Printf("📌 Persisted queries enabled (%s)\n", config.PersistedQueries)
+	default:
+		return nil, fmt.Errorf("unknown PERSISTED_QUERIES mode %q, expected apq, allowlist or off", config.PersistedQueries)
+	}
+
+	// Responses of queries with cache hints, invalidated by mutations of the types they contain
+	if config.ResponseCacheEnabled && utils.Client != nil {
+		graphQLRouter.SetResponseCache(cache.NewStore(utils.Client), router.ResponseCacheConfig{
+			Hints:       router.ParseCacheHints(config.CacheHints),
+			Invalidates: router.ParseInvalidations(config.CacheInvalidate),
+		})
+		fmt.Println("🗃️  Response caching enabled")
+	}
+
+	// Arrays of operations sent by batching clients such as Apollo and urql
+	graphQLRouter.SetMaxBatchSize(config.MaxBatchSize)
+
+	// File uploads following the GraphQL multipart request spec are streamed to the owning service
+	graphQLRouter.SetUploadLimits(router.UploadLimits{
+		MaxRequestSize: int64(config.UploadMaxRequestMB) << 20,
+		MaxFileSize:    int64(config.UploadMaxFileMB) << 20,
+		MaxFiles:       config.UploadMaxFiles,
+	})
+
+	// REST requests are routed by the declarative route file, then by /api/v1/{service}/
+	restServices := make(map[string]string, len(serviceConfigs))
+	for _, cfg := range serviceConfigs {
+		restServices[cfg.Name] = cfg.URL
+	}
+	routeConfigs, err := utils.LoadRoutesFromFile(config.RESTRoutesFile)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to load REST routes: %w", err)
+	}
+	restRoutes := make([]router.RESTRoute, len(routeConfigs))
+	protected := 0
+	for i, route := range routeConfigs {
+		restRoutes[i] = router.RESTRoute{
+			Prefix:        route.Prefix,
+			Service:       route.Service,
+			Methods:       route.Methods,
+			Rewrite:       route.Rewrite,
+			Authenticated: route.Authenticated,
+			Roles:         route.Roles,
+		}
+		if route.Authenticated {
+			protected++
+		}
+	}
+	if err := graphQLRouter.SetRESTRoutes(restServices, restRoutes); err != nil {
+		return nil, fmt.Errorf("invalid REST routes in %s: %w", config.RESTRoutesFile, err)
+	}
+	fmt.Printf("🛣️  REST routes loaded: %d from %s\n", len(restRoutes), config.RESTRoutesFile)
+	if protected > 0 && !edgeAuthEnabled(config) {
+		fmt.Printf("⚠️  %d REST routes require authentication, which is rejected while edge authentication is disabled\n", protected)
+	}
+
+	return graphQLRouter, nil
+}
+
+// SetupGRPCAccess loads the method policies of the gRPC proxy and the credentials it accepts
+// Without a policy file every proxied call is denied
+func SetupGRPCAccess(config *utils.Config) (*grpc.AccessControl, error) {
+	policyConfigs, err := utils.LoadGRPCPoliciesFromFile(config.GRPCPolicyFile)
+	if os.IsNotExist(err) {
+		fmt.Printf("⚠️  %s not found, every call to the gRPC proxy will be denied\n", config.GRPCPolicyFile)
+	} else if err != nil {
+		return nil, err
+	}
+
+	policies := make([]grpc.MethodPolicy, len(policyConfigs))
+	for i, policy := range policyConfigs {
+		policies[i] = grpc.MethodPolicy{
+			Pattern:       policy.Pattern,
+			Public:        policy.Public,
+			Authenticated: policy.Authenticated,
+			Roles:         policy.Roles,
+			Services:      policy.Services,
+		}
+	}
+
+	// End users present the same bearer tokens as over HTTP
+	var users grpc.Authenticator
+	if edgeAuthEnabled(config) {
+		users = newAuthenticator(config)
+	} else {
+		fmt.Println("⚠️  gRPC proxy rejects bearer tokens, set JWT_JWKS_URL or JWT_SECRET, and GATEWAY_IDENTITY_SECRET, to accept them")
+	}
+	if config.ServiceTokenSecret == "" {
+		fmt.Println("⚠️  gRPC proxy rejects service credentials, set SERVICE_TOKEN_SECRET to accept them")
+	}
+
+	// Denied calls are kept apart from the gateway log
+	audit := log.New(&lumberjack.Logger{
+		Filename:   config.GRPCAuditLog,
+		MaxSize:    10,   // megabytes
+		MaxBackups: 10,   // number of backups
+		MaxAge:     90,   // days
+		Compress:   true, // compress old logs
+	}, "", 0)
+
+	fmt.Printf("🛡️  gRPC access policies loaded: %d from %s, denials audited to %s\n", len(policies), config.GRPCPolicyFile, config.GRPCAuditLog)
+	return grpc.NewAccessControl(users, config.ServiceTokenSecret, policies, audit), nil
+}
+
+// GRPCProxyConfig builds the backend, metadata and deadline settings of the gRPC proxy
+// Without a deadline file every call without a deadline gets GRPC_DEFAULT_DEADLINE
+func GRPCProxyConfig(config *utils.Config) (grpc.ProxyConfig, error) {
+	deadlineConfigs, err := utils.LoadGRPCDeadlinesFromFile(config.GRPCDeadlineFile)
+	if err != nil && !os.IsNotExist(err) {
+		return grpc.ProxyConfig{}, err
+	}
+
+	deadlines := make([]grpc.MethodDeadline, len(deadlineConfigs))
+	for i, deadline := range deadlineConfigs {
+		deadlines[i] = grpc.MethodDeadline{Pattern: deadline.Pattern, Timeout: deadline.Timeout}
+	}
+	if len(deadlines) > 0 {
+		fmt.Printf("⏱️  gRPC method deadlines loaded: %d from %s\n", len(deadlines), config.GRPCDeadlineFile)
+	}
+
+	return grpc.ProxyConfig{
+		DiscoveryInterval: config.GRPCDiscoveryInterval,
+		LoadBalancing:     config.GRPCLoadBalancing,
+		HealthChecks:      config.GRPCHealthChecks,
+		MetadataAllow:     config.GRPCMetadataAllow,
+		MetadataDeny:      config.GRPCMetadataDeny,
+		Deadlines:         deadlines,
+		DefaultDeadline:   config.GRPCDefaultDeadline,
+		MaxDeadline:       config.GRPCMaxDeadline,
+	}, nil
+}
+
+// edgeAuthEnabled reports whether the keys needed to validate tokens at the gateway are set
+func edgeAuthEnabled(config *utils.Config) bool {
+	return config.IdentitySecret != "" && (config.JWKSURL != "" || config.JWTSecret != "")
+}
+
+// newAuthenticator creates the token validator shared by the GraphQL router and the gRPC proxy
+// Tokens are verified with the auth service's published public keys when JWT_JWKS_URL is set
+func newAuthenticator(config *utils.Config) *auth.Authenticator {
+	keys := jwt.SecretKeys(config.JWTSecret)
+	if config.JWKSURL != "" {
+		keys = jwt.NewJWKSClient(config.JWKSURL, config.JWKSCacheTTL)
+	}
+	return auth.NewAuthenticator(keys, utils.Client, config.IdentitySecret, config.IdentityTTL)
 }
 
 // schemaAdapter adapts the schema manager to the router's interface
@@ -69,11 +315,247 @@ func (s *schemaAdapter) GetMergedSchema() interface{} {
 	return s.manager.GetMergedSchema()
 }
 
-// GetRoutes returns the routes map from the schema manager
-// This is needed for REST API forwarding
-func (s *schemaAdapter) GetRoutes() map[string]string {
-	// Get a copy of the routes map
-	return s.manager.GetRoutesMap()
+// GetSchema delegates to the schema manager
+func (s *schemaAdapter) GetSchema() *ast.Schema {
+	return s.manager.GetSchema()
+}
+
+// HasEntities delegates to the schema manager
+func (s *schemaAdapter) HasEntities() bool {
+	return s.manager.HasEntities()
+}
+
+// GetEntity converts the schema manager's entity into the router's representation
+func (s *schemaAdapter) GetEntity(typeName string) *router.EntityInfo {
+	entity := s.manager.GetEntity(typeName)
+	if entity == nil {
+		return nil
+	}
+	return &router.EntityInfo{
+		KeyFields:    entity.KeyFields,
+		OwnerURL:     entity.OwnerURL,
+		Resolver:     entity.Resolver,
+		FieldOwners:  entity.FieldOwners,
+		EntitiesURLs: entity.EntitiesURLs,
+	}
+}
+
+// GetCacheHint converts the schema manager's cache hint into the router's representation
+func (s *schemaAdapter) GetCacheHint(typeName, fieldName string) *router.CacheHint {
+	hint, ok := s.manager.GetCacheHint(typeName, fieldName)
+	if !ok {
+		return nil
+	}
+	return &router.CacheHint{
+		MaxAge:  time.Duration(hint.MaxAge) * time.Second,
+		Private: hint.Private,
+	}
+}
+
+// GetFieldType delegates to the schema manager
+func (s *schemaAdapter) GetFieldType(typeName, fieldName string) string {
+	return s.manager.GetFieldType(typeName, fieldName)
+}
+
+// HandleSchemaRefresh re-collects service schemas on demand and reports the routing changes
+func HandleSchemaRefresh(w http.ResponseWriter, r *http.Request) {
+	result := schemaManager.Refresh()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Printf("Error encoding schema refresh result: %v", err)
+	}
+}
+
+// newSchemaRegistry creates the schema version registry selected by the configuration
+func newSchemaRegistry(config *utils.Config) *schema.Registry {
+	switch config.SchemaRegistry {
+	case "off", "":
+		return nil
+	case "file":
+		store, err := schema.NewFileStore(config.SchemaRegistryDir)
+		if err != nil {
+			log.Printf("Schema registry disabled, cannot use %s: %v", config.SchemaRegistryDir, err)
+			return nil
+		}
+		return schema.NewRegistry(store)
+	default:
+		if utils.Client == nil {
+			log.Println("Schema registry disabled, Redis is not connected")
+			return nil
+		}
+		return schema.NewRegistry(schema.NewRedisStore(utils.Client, "gateway:schema"))
+	}
+}
+
+// HandleSDL serves the composed schema as SDL, or a recorded version with ?version=N
+func HandleSDL(w http.ResponseWriter, r *http.Request) {
+	sdl := schemaManager.GetSDL()
+
+	if versionParam := r.URL.Query().Get("version"); versionParam != "" {
+		version, ok := lookupVersion(w, versionParam)
+		if !ok {
+			return
+		}
+		sdl = version.SDL
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(sdl))
+}
+
+// HandleSchemaVersions lists the recorded schema versions
+func HandleSchemaVersions(w http.ResponseWriter, r *http.Request) {
+	if schemaManager.Registry == nil {
+		http.Error(w, "Schema registry is disabled", http.StatusNotFound)
+		return
+	}
+
+	versions, err := schemaManager.Registry.Versions()
+	if err != nil {
+		log.Printf("Error listing schema versions: %v", err)
+		http.Error(w, "Failed to list schema versions", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(versions)
+}
+
+// HandleSchemaDiff compares two recorded versions given as ?from=N&to=M
+// to defaults to the latest version and from to the version before it
+func HandleSchemaDiff(w http.ResponseWriter, r *http.Request) {
+	to, ok := lookupVersion(w, r.URL.Query().Get("to"))
+	if !ok {
+		return
+	}
+
+	fromParam := r.URL.Query().Get("from")
+	if fromParam == "" {
+		fromParam = strconv.Itoa(to.Version - 1)
+	}
+	from, ok := lookupVersion(w, fromParam)
+	if !ok {
+		return
+	}
+
+	diff, err := schema.DiffSDL(from.SDL, to.SDL)
+	if err != nil {
+		log.Printf("Error comparing schema versions %d and %d: %v", from.Version, to.Version, err)
+		http.Error(w, "Failed to compare schema versions", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"from":     from.Version,
+		"to":       to.Version,
+		"breaking": diff.Breaking,
+		"changes":  diff.Changes,
+	})
+}
+
+// lookupVersion loads a recorded version by number, "" selecting the latest, and writes errors itself
+func lookupVersion(w http.ResponseWriter, param string) (*schema.SchemaVersion, bool) {
+	if schemaManager.Registry == nil {
+		http.Error(w, "Schema registry is disabled", http.StatusNotFound)
+		return nil, false
+	}
+
+	number := 0
+	if param != "" {
+		var err error
+		if number, err = strconv.Atoi(param); err != nil || number < 1 {
+			http.Error(w, fmt.Sprintf("Invalid schema version: %s", param), http.StatusBadRequest)
+			return nil, false
+		}
+	}
+
+	version, err := schemaManager.Registry.Version(number)
+	if errors.Is(err, schema.ErrVersionNotFound) {
+		http.Error(w, fmt.Sprintf("Schema version %s not found", param), http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("Error loading schema version %s: %v", param, err)
+		http.Error(w, "Failed to load schema version", http.StatusInternalServerError)
+		return nil, false
+	}
+	return version, true
+}
+
+// HandlePersistedQueryUpload registers the operations of a manifest generated by the frontend build
+// ?replace=true drops operations missing from the manifest
+func HandlePersistedQueryUpload(w http.ResponseWriter, r *http.Request) {
+	if persistedQueries == nil {
+		http.Error(w, "Persisted queries are disabled", http.StatusNotFound)
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxManifestSize))
+	if err != nil {
+		http.Error(w, "Failed to read manifest: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	operations, err := persisted.ParseManifest(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	replace := r.URL.Query().Get("replace") == "true"
+	if err := persistedQueries.Register(r.Context(), operations, replace); err != nil {
+		log.Printf("Error registering persisted queries: %v", err)
+		http.Error(w, "Failed to register persisted queries", http.StatusInternalServerError)
+		return
+	}
+	log.Printf("Registered %d persisted queries (replace: %t)", len(operations), replace)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"registered": len(operations),
+		"replaced":   replace,
+	})
+}
+
+// HandlePersistedQueryList lists the hashes of the registered operations
+func HandlePersistedQueryList(w http.ResponseWriter, r *http.Request) {
+	if persistedQueries == nil {
+		http.Error(w, "Persisted queries are disabled", http.StatusNotFound)
+		return
+	}
+
+	hashes, err := persistedQueries.Hashes(r.Context())
+	if err != nil {
+		log.Printf("Error listing persisted queries: %v", err)
+		http.Error(w, "Failed to list persisted queries", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"count":  len(hashes),
+		"hashes": hashes,
+	})
+}
+
+// adminOnly requires the configured admin token in the X-Admin-Token header
+// Without a configured token the admin endpoints do not exist
+func adminOnly(config *utils.Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if config.AdminToken == "" {
+				http.NotFound(w, r)
+				return
+			}
+			token := r.Header.Get("X-Admin-Token")
+			if subtle.ConstantTimeCompare([]byte(token), []byte(config.AdminToken)) != 1 {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
 }
 
 // setupLogging configures logging for the gateway
@@ -0,0 +1,244 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	pkggrpc "github.com/saurabh/entgo-microservices/pkg/grpc"
+	"github.com/saurabh/entgo-microservices/pkg/identity"
+	"github.com/saurabh/entgo-microservices/pkg/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// identityMetadata carries the identity the gateway established for a call, like identity.Header over HTTP
+var identityMetadata = strings.ToLower(identity.Header)
+
+// Authenticator validates end-user bearer tokens
+type Authenticator interface {
+	// Authenticate validates an authorization value and returns the caller and its signed identity to forward
+	Authenticate(ctx context.Context, authorization string) (*identity.Identity, string, error)
+}
+
+// MethodPolicy lists who may call the methods matching a pattern
+type MethodPolicy struct {
+	Pattern       string   // Full method, /package.Service/* or *
+	Public        bool     // Anyone, without credentials
+	Authenticated bool     // Any end user with a valid token
+	Roles         []string // End users with one of these roles
+	Services      []string // Microservices presenting a service credential, * for any
+}
+
+// Caller is the authenticated client of a proxied call
+type Caller struct {
+	User     *identity.Identity // End user from a bearer token, nil without one
+	Identity string             // Signed identity of User, forwarded to the backend
+	Service  string             // Microservice from a service credential, empty without one
+}
+
+// AccessControl authenticates callers of the proxy and authorizes them per method
+type AccessControl struct {
+	auth          Authenticator            // Validates bearer tokens, nil rejects them
+	serviceSecret string                   // Verifies service credentials, empty rejects them
+	methods       map[string]*MethodPolicy // Policies of full method names
+	services      map[string]*MethodPolicy // Policies of /package.Service/*, keyed by /package.Service/
+	fallback      *MethodPolicy            // Policy of *, nil denies methods without a policy
+	audit         *log.Logger              // Receives denied calls as JSON lines, nil only logs them
+}
+
+// auditEntry is a denied call as written to the audit log
+type auditEntry struct {
+	Time    time.Time `json:"time"`
+	Method  string    `json:"method"`
+	Code    string    `json:"code"`
+	Reason  string    `json:"reason"`
+	Peer    string    `json:"peer,omitempty"`
+	UserID  int       `json:"userId,omitempty"`
+	Role    string    `json:"role,omitempty"`
+	Service string    `json:"service,omitempty"`
+}
+
+// callerKey stores the authenticated caller in the context of a call
+type callerKey struct{}
+
+// NewAccessControl creates access control enforcing the given policies
+// The most specific policy of a method applies: its full name, then its service's /package.Service/*, then *
+func NewAccessControl(auth Authenticator, serviceSecret string, policies []MethodPolicy, audit *log.Logger) *AccessControl {
+	a := &AccessControl{
+		auth:          auth,
+		serviceSecret: serviceSecret,
+		methods:       make(map[string]*MethodPolicy),
+		services:      make(map[string]*MethodPolicy),
+		audit:         audit,
+	}
+	for i := range policies {
+		policy := &policies[i]
+		switch {
+		case policy.Pattern == "*":
+			a.fallback = policy
+		case strings.HasSuffix(policy.Pattern, "/*"):
+			a.services[strings.TrimSuffix(policy.Pattern, "*")] = policy
+		default:
+			a.methods[policy.Pattern] = policy
+		}
+	}
+	return a
+}
+
+// policy returns the most specific policy of a method, or nil when there is none
+func (a *AccessControl) policy(method string) *MethodPolicy {
+	if policy, ok := a.methods[method]; ok {
+		return policy
+	}
+	if i := strings.LastIndex(method, "/"); i > 0 {
+		if policy, ok := a.services[method[:i+1]]; ok {
+			return policy
+		}
+	}
+	return a.fallback
+}
+
+// authorize authenticates the caller of a method and checks the method's policy
+func (a *AccessControl) authorize(ctx context.Context, method string) (*Caller, error) {
+	// The proxy's own health and reflection services are open to load balancers and tooling
+	if strings.HasPrefix(method, "/grpc.health.v1.Health/") || strings.HasPrefix(method, "/grpc.reflection.") {
+		return &Caller{}, nil
+	}
+
+	caller, err := a.authenticate(ctx)
+	if err != nil {
+		a.deny(ctx, method, nil, codes.Unauthenticated, err.Error())
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+
+	policy := a.policy(method)
+	switch {
+	case policy == nil:
+		a.deny(ctx, method, caller, codes.PermissionDenied, "no policy for method")
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not exposed through the gateway", method)
+	case policy.allows(caller):
+		return caller, nil
+	case caller.User == nil && caller.Service == "":
+		a.deny(ctx, method, caller, codes.Unauthenticated, "credentials required by "+policy.Pattern)
+		return nil, status.Error(codes.Unauthenticated, "credentials required")
+	default:
+		a.deny(ctx, method, caller, codes.PermissionDenied, "caller not allowed by "+policy.Pattern)
+		return nil, status.Errorf(codes.PermissionDenied, "not allowed to call %s", method)
+	}
+}
+
+// authenticate reads a service credential and a bearer token from the incoming metadata
+// Both are optional; a credential that is present must be valid
+func (a *AccessControl) authenticate(ctx context.Context) (*Caller, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	caller := &Caller{}
+
+	if values := md.Get(pkggrpc.ServiceTokenMetadata); len(values) > 0 {
+		claims, err := jwt.ValidateServiceToken(a.serviceSecret, values[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid service credential: %w", err)
+		}
+		caller.Service = claims.Service
+	}
+
+	if values := md.Get("authorization"); len(values) > 0 {
+		if a.auth == nil {
+			return nil, errors.New("bearer tokens are not accepted while edge authentication is disabled")
+		}
+		user, signed, err := a.auth.Authenticate(ctx, values[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid bearer token: %w", err)
+		}
+		caller.User, caller.Identity = user, signed
+	}
+
+	return caller, nil
+}
+
+// allows reports whether a policy admits a caller
+func (p *MethodPolicy) allows(caller *Caller) bool {
+	if p.Public {
+		return true
+	}
+	if caller.Service != "" {
+		for _, service := range p.Services {
+			if service == "*" || service == caller.Service {
+				return true
+			}
+		}
+	}
+	if caller.User != nil {
+		if p.Authenticated {
+			return true
+		}
+		for _, role := range p.Roles {
+			if caller.User.Role == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deny logs a refused call and writes it to the audit log
+func (a *AccessControl) deny(ctx context.Context, method string, caller *Caller, code codes.Code, reason string) {
+	entry := auditEntry{Time: time.Now().UTC(), Method: method, Code: code.String(), Reason: reason}
+	if p, ok := peer.FromContext(ctx); ok {
+		entry.Peer = p.Addr.String()
+	}
+	if caller != nil {
+		entry.Service = caller.Service
+		if caller.User != nil {
+			entry.UserID, entry.Role = caller.User.UserID, caller.User.Role
+		}
+	}
+
+	log.Printf("🚫 gRPC call to %s denied (%s): %s", method, code, reason)
+	if a.audit != nil {
+		if line, err := json.Marshal(entry); err == nil {
+			a.audit.Println(string(line))
+		}
+	}
+}
+
+// authInterceptor authorizes unary calls to the proxy's own services
+func (p *ProxyServer) authInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	caller, err := p.access.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, callerKey{}, caller), req)
+}
+
+// streamAuthInterceptor authorizes streaming calls, which include every proxied call
+func (p *ProxyServer) streamAuthInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	caller, err := p.access.authorize(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: stream, ctx: context.WithValue(stream.Context(), callerKey{}, caller)})
+}
+
+// authenticatedStream carries the authenticated caller in its context
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// callerFromContext returns the caller authorized for a call, or nil
+func callerFromContext(ctx context.Context) *Caller {
+	caller, _ := ctx.Value(callerKey{}).(*Caller)
+	return caller
+}
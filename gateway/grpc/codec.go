@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// frame is a proxied message, passed through as the bytes received without decoding it
+type frame struct {
+	payload []byte
+}
+
+// passthroughCodec forwards frames as raw bytes and encodes the proxy's own messages, such as health checks, as protobuf
+type passthroughCodec struct{}
+
+func (passthroughCodec) Marshal(v interface{}) ([]byte, error) {
+	switch msg := v.(type) {
+	case *frame:
+		return msg.payload, nil
+	case proto.Message:
+		return proto.Marshal(msg)
+	}
+	return nil, fmt.Errorf("cannot encode %T", v)
+}
+
+func (passthroughCodec) Unmarshal(data []byte, v interface{}) error {
+	switch msg := v.(type) {
+	case *frame:
+		// The buffer is reused by the transport once Unmarshal returns
+		msg.payload = append(msg.payload[:0], data...)
+		return nil
+	case proto.Message:
+		return proto.Unmarshal(data, msg)
+	}
+	return fmt.Errorf("cannot decode into %T", v)
+}
+
+// Name registers the codec for the default content-subtype, so clients need no configuration
+func (passthroughCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// MethodDeadline is the deadline given to calls of the methods matching a pattern when the client sets none
+type MethodDeadline struct {
+	Pattern string        // Full method, /package.Service/* or *
+	Timeout time.Duration // 0 leaves such calls without a deadline, e.g. long-lived streams
+}
+
+// deadlinePolicy bounds how long a proxied call may take
+type deadlinePolicy struct {
+	timeouts map[string]time.Duration // Default deadlines keyed by method pattern
+	fallback time.Duration            // Default deadline of methods without a pattern, 0 for none
+	max      time.Duration            // Upper bound of every deadline, 0 for none
+}
+
+// newDeadlinePolicy creates a policy; a * pattern takes precedence over fallback
+func newDeadlinePolicy(deadlines []MethodDeadline, fallback, max time.Duration) *deadlinePolicy {
+	d := &deadlinePolicy{timeouts: make(map[string]time.Duration), fallback: fallback, max: max}
+	for _, deadline := range deadlines {
+		d.timeouts[deadline.Pattern] = deadline.Timeout
+	}
+	return d
+}
+
+// timeout returns the default deadline of a method, from its most specific pattern:
+// its full name, then its service's /package.Service/*, then *
+func (d *deadlinePolicy) timeout(method string) time.Duration {
+	if timeout, ok := d.timeouts[method]; ok {
+		return timeout
+	}
+	if i := strings.LastIndex(method, "/"); i > 0 {
+		if timeout, ok := d.timeouts[method[:i+1]+"*"]; ok {
+			return timeout
+		}
+	}
+	if timeout, ok := d.timeouts["*"]; ok {
+		return timeout
+	}
+	return d.fallback
+}
+
+// apply gives a call without a deadline its method's default and clamps every deadline to the maximum
+func (d *deadlinePolicy) apply(ctx context.Context, method string) (context.Context, context.CancelFunc) {
+	timeout := d.max
+	if _, ok := ctx.Deadline(); !ok {
+		if def := d.timeout(method); def > 0 && (timeout == 0 || def < timeout) {
+			timeout = def
+		}
+	}
+	if timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	// The earlier of the client's deadline and the timeout applies
+	return context.WithTimeout(ctx, timeout)
+}
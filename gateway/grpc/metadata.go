@@ -0,0 +1,126 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"strings"
+
+	pkggrpc "github.com/saurabh/entgo-microservices/pkg/grpc"
+	"github.com/saurabh/entgo-microservices/pkg/identity"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// requestIDMetadata correlates a proxied call across the gateway and the backend
+const requestIDMetadata = "x-request-id"
+
+// serviceMetadata names the microservice whose credential the proxy verified for a call
+// It is reserved like every x-gateway-* key, so clients cannot set it themselves
+const serviceMetadata = "x-gateway-service"
+
+// maxRequestIDLength bounds request ids accepted from clients
+const maxRequestIDLength = 128
+
+// hopMetadata describes the client's connection to the proxy and is never forwarded
+var hopMetadata = map[string]bool{
+	"connection":                 true,
+	"keep-alive":                 true,
+	"proxy-connection":           true,
+	"proxy-authorization":        true,
+	"te":                         true,
+	"transfer-encoding":          true,
+	"upgrade":                    true,
+	"host":                       true,
+	"content-type":               true,
+	"user-agent":                 true,
+	"grpc-timeout":               true,
+	"grpc-encoding":              true,
+	"grpc-accept-encoding":       true,
+	pkggrpc.ServiceTokenMetadata: true, // Consumed by the proxy, backends get the verified service name instead
+	requestIDMetadata:            true, // Injected by the proxy
+	"x-forwarded-for":            true, // Injected by the proxy
+}
+
+// metadataFilter decides which incoming metadata keys are forwarded to backends
+type metadataFilter struct {
+	allow []string // Keys or prefixes ending in *; empty allows every key that is not denied
+	deny  []string // Keys or prefixes ending in *, on top of hop-specific and identity keys
+}
+
+// newMetadataFilter creates a filter from configured keys, which are matched case-insensitively
+func newMetadataFilter(allow, deny []string) *metadataFilter {
+	f := &metadataFilter{}
+	for _, key := range allow {
+		f.allow = append(f.allow, strings.ToLower(key))
+	}
+	for _, key := range deny {
+		f.deny = append(f.deny, strings.ToLower(key))
+	}
+	return f
+}
+
+// forwards reports whether a client metadata key is passed on to the backend
+func (f *metadataFilter) forwards(key string) bool {
+	// Pseudo-headers, hop-specific keys and identities the client could spoof
+	if strings.HasPrefix(key, ":") || hopMetadata[key] || identity.Reserved(key) {
+		return false
+	}
+	if matchesKey(f.deny, key) {
+		return false
+	}
+	return len(f.allow) == 0 || matchesKey(f.allow, key)
+}
+
+// matchesKey reports whether a key is listed, or starts with a listed prefix ending in *
+func matchesKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key || (strings.HasSuffix(k, "*") && strings.HasPrefix(key, strings.TrimSuffix(k, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// outgoingMetadata builds the metadata sent to the backend: the forwarded client keys,
+// the request id, the client address and the identity or service the proxy established for the caller
+func (f *metadataFilter) outgoingMetadata(ctx context.Context, requestID string) metadata.MD {
+	in, _ := metadata.FromIncomingContext(ctx)
+	out := metadata.MD{}
+	for key, values := range in {
+		if f.forwards(key) {
+			out[key] = append([]string(nil), values...)
+		}
+	}
+
+	out.Set(requestIDMetadata, requestID)
+	if p, ok := peer.FromContext(ctx); ok {
+		forwardedFor := in.Get("x-forwarded-for")
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			forwardedFor = append(forwardedFor, host)
+		}
+		out.Set("x-forwarded-for", strings.Join(forwardedFor, ", "))
+	}
+	if caller := callerFromContext(ctx); caller != nil {
+		if caller.Identity != "" {
+			out.Set(identityMetadata, caller.Identity)
+		}
+		if caller.Service != "" {
+			out.Set(serviceMetadata, caller.Service)
+		}
+	}
+	return out
+}
+
+// requestID returns the client's request id, or a new one when it sent none or an unusable one
+func requestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(requestIDMetadata); len(values) > 0 && values[0] != "" && len(values[0]) <= maxRequestIDLength {
+		return values[0]
+	}
+
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -2,10 +2,12 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -21,10 +23,31 @@ type ProxyServer struct {
 	grpcServer *grpc.Server
 	listener   net.Listener
 	registry   *ServiceRegistry
+	access     *AccessControl
+	metadata   *metadataFilter // Which client metadata reaches the backends
+	deadlines  *deadlinePolicy // Default and maximum deadlines of proxied calls
+}
+
+// ProxyConfig controls how the proxy finds and balances its backends
+type ProxyConfig struct {
+	DiscoveryInterval time.Duration // How often backends are asked for their services, 0 disables the refresh
+	LoadBalancing     string        // round_robin or least_request across the endpoints of a service
+	HealthChecks      bool          // Eject endpoints whose grpc.health.v1 status is not SERVING until they recover
+
+	MetadataAllow   []string         // Client metadata keys forwarded to backends, a trailing * matches a prefix; empty forwards every key not denied
+	MetadataDeny    []string         // Client metadata keys never forwarded, on top of hop-specific and identity keys
+	Deadlines       []MethodDeadline // Default deadlines of calls without one, by method pattern
+	DefaultDeadline time.Duration    // Default deadline of methods without a pattern, 0 for none
+	MaxDeadline     time.Duration    // Upper bound of every call's deadline, 0 leaves client deadlines as they are
 }
 
 // NewProxyServer creates a new gRPC proxy server
-func NewProxyServer(port int) (*ProxyServer, error) {
+// Backends are the configured services with a gRPC address; every call is authenticated and checked against the method policies of access
+func NewProxyServer(port int, config ProxyConfig, access *AccessControl) (*ProxyServer, error) {
+	if config.LoadBalancing != "round_robin" && config.LoadBalancing != "least_request" {
+		return nil, fmt.Errorf("unknown load balancing policy %q, expected round_robin or least_request", config.LoadBalancing)
+	}
+
 	// Create listener
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
@@ -32,15 +55,19 @@ func NewProxyServer(port int) (*ProxyServer, error) {
 	}
 
 	// Initialize service registry
-	registry := NewServiceRegistry()
+	registry := NewServiceRegistry(config)
 	if err := registry.LoadFromConfig(); err != nil {
 		return nil, fmt.Errorf("failed to load service registry: %w", err)
 	}
+	registry.Watch(config.DiscoveryInterval)
 
 	// Create proxy server with interceptors
 	proxy := &ProxyServer{
-		registry: registry,
-		listener: listener,
+		registry:  registry,
+		listener:  listener,
+		access:    access,
+		metadata:  newMetadataFilter(config.MetadataAllow, config.MetadataDeny),
+		deadlines: newDeadlinePolicy(config.Deadlines, config.DefaultDeadline, config.MaxDeadline),
 	}
 
 	// Create gRPC server with unknown service handler (transparent proxy)
@@ -48,12 +75,15 @@ func NewProxyServer(port int) (*ProxyServer, error) {
 		grpc.ChainUnaryInterceptor(
 			proxy.loggingInterceptor,
 			proxy.recoveryInterceptor,
+			proxy.authInterceptor,
 		),
 		grpc.ChainStreamInterceptor(
 			proxy.streamLoggingInterceptor,
 			proxy.streamRecoveryInterceptor,
+			proxy.streamAuthInterceptor,
 		),
 		grpc.UnknownServiceHandler(proxy.transparentHandler()),
+		grpc.ForceServerCodec(passthroughCodec{}),
 	)
 
 	proxy.grpcServer = grpcServer
@@ -83,57 +113,62 @@ func (p *ProxyServer) transparentHandler() grpc.StreamHandler {
 
 		// Get backend connection
 		backendConn, serviceName, err := p.registry.GetConnectionByMethod(method)
+		if errors.Is(err, errUnknownMethod) {
+			log.Printf("❌ Failed to route request: %v", err)
+			return status.Errorf(codes.Unimplemented, "unknown method %s: no backend serves it", method)
+		}
 		if err != nil {
 			log.Printf("❌ Failed to route request: %v", err)
 			return status.Errorf(codes.Unavailable, "service unavailable: %v", err)
 		}
 
-		// Get metadata from incoming request
-		md, ok := metadata.FromIncomingContext(stream.Context())
-		if !ok {
-			md = metadata.New(nil)
-		}
+		// Default deadline for calls without one, clamped to the maximum
+		ctx, cancel := p.deadlines.apply(stream.Context(), method)
+		defer cancel()
 
-		// Create outgoing context with metadata
-		outCtx := metadata.NewOutgoingContext(stream.Context(), md)
+		// Forward the allowed client metadata; only the gateway asserts identities and request ids
+		id := requestID(stream.Context())
+		outCtx := metadata.NewOutgoingContext(ctx, p.metadata.outgoingMetadata(stream.Context(), id))
 
 		// Create client stream to backend
 		clientStream, err := backendConn.NewStream(outCtx, &grpc.StreamDesc{
 			StreamName:    method,
 			ServerStreams: true,
 			ClientStreams: true,
-		}, method)
+		}, method, grpc.ForceCodec(passthroughCodec{}))
 		if err != nil {
 			log.Printf("❌ Failed to create stream to backend: %v", err)
 			return status.Errorf(codes.Internal, "failed to create backend stream: %v", err)
 		}
 
 		// Forward request and response
-		return p.forwardStream(stream, clientStream, serviceName, method)
+		return p.forwardStream(stream, clientStream, serviceName, method, id)
 	}
 }
 
 // forwardStream bidirectionally forwards data between client and backend
-func (p *ProxyServer) forwardStream(serverStream grpc.ServerStream, clientStream grpc.ClientStream, serviceName, method string) error {
-	// Channel for errors
-	errChan := make(chan error, 2)
+// The call ends when the backend has answered; its headers, trailers and status are returned to the client unchanged
+func (p *ProxyServer) forwardStream(serverStream grpc.ServerStream, clientStream grpc.ClientStream, serviceName, method, requestID string) error {
+	requestDone := make(chan error, 1)
+	responseDone := make(chan error, 1)
 
 	// Forward client -> backend (request)
 	go func() {
 		for {
-			var msg interface{}
-			if err := serverStream.RecvMsg(&msg); err != nil {
+			msg := &frame{}
+			if err := serverStream.RecvMsg(msg); err != nil {
 				if err == io.EOF {
 					_ = clientStream.CloseSend()
-					errChan <- nil
+					requestDone <- nil
 					return
 				}
-				errChan <- status.Errorf(codes.Internal, "failed to receive from client: %v", err)
+				requestDone <- status.Errorf(codes.Internal, "failed to receive from client: %v", err)
 				return
 			}
 
+			// A backend that ended the call reports why through RecvMsg
 			if err := clientStream.SendMsg(msg); err != nil {
-				errChan <- status.Errorf(codes.Internal, "failed to send to backend: %v", err)
+				requestDone <- nil
 				return
 			}
 		}
@@ -141,32 +176,52 @@ func (p *ProxyServer) forwardStream(serverStream grpc.ServerStream, clientStream
 
 	// Forward backend -> client (response)
 	go func() {
+		// Response headers come before the first message, or with the status of a call without messages
+		header, err := clientStream.Header()
+		if err != nil {
+			header = metadata.MD{}
+		}
+		header = header.Copy()
+		header.Set(requestIDMetadata, requestID)
+		if err := serverStream.SendHeader(header); err != nil {
+			responseDone <- status.Errorf(codes.Internal, "failed to send headers to client: %v", err)
+			return
+		}
+
 		for {
-			var msg interface{}
-			if err := clientStream.RecvMsg(&msg); err != nil {
+			msg := &frame{}
+			if err := clientStream.RecvMsg(msg); err != nil {
+				serverStream.SetTrailer(clientStream.Trailer())
 				if err == io.EOF {
-					errChan <- nil
+					responseDone <- nil
 					return
 				}
-				errChan <- status.Errorf(codes.Internal, "failed to receive from backend: %v", err)
+				responseDone <- err
 				return
 			}
 
 			if err := serverStream.SendMsg(msg); err != nil {
-				errChan <- status.Errorf(codes.Internal, "failed to send to client: %v", err)
+				responseDone <- status.Errorf(codes.Internal, "failed to send to client: %v", err)
 				return
 			}
 		}
 	}()
 
-	// Wait for first error or completion
-	err := <-errChan
+	// Wait for the backend's answer, or for the client's side to fail
+	var err error
+	select {
+	case err = <-responseDone:
+	case err = <-requestDone:
+		if err == nil {
+			err = <-responseDone
+		}
+	}
 
 	// Log completion
 	if err != nil {
-		log.Printf("❌ [%s] %s: %v", serviceName, method, err)
+		log.Printf("❌ [%s] %s (%s): %v", serviceName, method, requestID, err)
 	} else {
-		log.Printf("✅ [%s] %s: completed", serviceName, method)
+		log.Printf("✅ [%s] %s (%s): completed", serviceName, method, requestID)
 	}
 
 	return err
@@ -2,101 +2,123 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
-
 	"time"
 
 	"github.com/saurabh/entgo-microservices/gateway/utils"
-	pkggrpc "github.com/saurabh/entgo-microservices/pkg/grpc"
 	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/balancer/leastrequest" // Registers the least_request policy
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
 )
 
-// ServiceRegistry manages gRPC connections to backend microservices
+// errUnknownMethod is returned for methods none of the discovered backends serves
+var errUnknownMethod = errors.New("unknown method")
+
+// discoveryTimeout bounds asking one backend for its services
+const discoveryTimeout = 10 * time.Second
+
+// endpointsScheme resolves a configured list of replica addresses
+const endpointsScheme = "endpoints"
+
+// backend is the gRPC servers of a service and the methods they reported through server reflection
+type backend struct {
+	name       string
+	address    string           // Configured address: a list of replicas or one target resolving to them
+	endpoints  []string         // Configured replica addresses, one entry for targets resolved by DNS
+	conn       *grpc.ClientConn // Balances calls across the healthy replicas
+	services   []string         // Fully qualified services, e.g. user.v1.UserService
+	methods    []string         // Full method names, e.g. /user.v1.UserService/GetUserByID
+	discovered bool             // Reflection succeeded at least once
+}
+
+// ServiceRegistry manages gRPC connections to backend microservices and routes methods to them
 type ServiceRegistry struct {
-	connections map[string]*grpc.ClientConn
-	mu          sync.RWMutex
+	backends map[string]*backend
+	order    []string          // Backend names in configuration order, earlier ones win a conflict
+	methods  map[string]string // Full method name → backend name
+	config   ProxyConfig       // Load balancing and health checks of new connections
+	mu       sync.RWMutex
+
+	ctx    context.Context // Cancelled on Close to stop the watchers
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
 // NewServiceRegistry creates a new service registry
-func NewServiceRegistry() *ServiceRegistry {
+func NewServiceRegistry(config ProxyConfig) *ServiceRegistry {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &ServiceRegistry{
-		connections: make(map[string]*grpc.ClientConn),
+		backends: make(map[string]*backend),
+		methods:  make(map[string]string),
+		config:   config,
+		ctx:      ctx,
+		cancel:   cancel,
 	}
 }
 
-// LoadFromConfig loads service configurations and establishes connections
+// LoadFromConfig connects to the gRPC servers of the configured services and discovers their methods
+// A backend that cannot be reached yet is discovered once it comes up
 func (r *ServiceRegistry) LoadFromConfig() error {
-	// Load service configurations from environment
+	// Same service list as the GraphQL router
 	serviceConfigs := utils.LoadServicesFromEnv()
 
 	fmt.Println("🔌 Loading gRPC service connections:")
 
 	for _, svc := range serviceConfigs {
-		// Convert GraphQL URL to gRPC URL
-		// Expected format: {SERVICE}_GRPC_URL env var or derive from service name
-		grpcURL := r.getGRPCURL(svc.Name)
-
-		if grpcURL == "" {
-			fmt.Printf("  ⚠️  %s: No gRPC URL configured, skipping\n", svc.Name)
+		if svc.GRPCURL == "" {
+			fmt.Printf("  ⚠️  %s: No gRPC address configured, skipping\n", svc.Name)
 			continue
 		}
 
 		// Establish connection
-		if err := r.Connect(svc.Name, grpcURL); err != nil {
-			fmt.Printf("  ❌ %s: Failed to connect to %s: %v\n", svc.Name, grpcURL, err)
+		if err := r.Connect(svc.Name, svc.GRPCURL); err != nil {
+			fmt.Printf("  ❌ %s: Failed to connect to %s: %v\n", svc.Name, svc.GRPCURL, err)
 			continue
 		}
 
-		fmt.Printf("  ✅ %s: Connected to %s\n", svc.Name, grpcURL)
-	}
-
-	return nil
-}
+		if err := r.Discover(r.ctx, svc.Name); err != nil {
+			fmt.Printf("  ⚠️  %s: %v\n", svc.Name, err)
+			continue
+		}
 
-// getGRPCURL determines the gRPC URL for a service
-func (r *ServiceRegistry) getGRPCURL(serviceName string) string {
-	// Try environment variable first: {SERVICE}_GRPC_URL
-	envVarName := strings.ToUpper(serviceName) + "_GRPC_URL"
-	if url := utils.GetEnv(envVarName, ""); url != "" {
-		return url
+		r.mu.RLock()
+		services := r.backends[svc.Name].services
+		r.mu.RUnlock()
+		fmt.Printf("  ✅ %s: Connected to %s, %s (%s)\n", svc.Name, svc.GRPCURL, r.config.LoadBalancing, strings.Join(services, ", "))
 	}
 
-	// Try to derive from docker container name pattern
-	// Default pattern: entgo_{service}_dev:{grpc_port}
-	switch serviceName {
-	case "auth":
-		return utils.GetEnv("AUTH_GRPC_URL", "entgo_auth_dev:9081")
-	case "route":
-		return utils.GetEnv("ROUTE_GRPC_URL", "entgo_route_dev:9082")
-	case "attendance":
-		return utils.GetEnv("ATTENDANCE_GRPC_URL", "entgo_attendance_dev:9083")
-	case "yard":
-		return utils.GetEnv("YARD_GRPC_URL", "entgo_yard_dev:9084")
-	default:
-		// Return empty string if unknown service
-		return ""
-	}
+	return nil
 }
 
 // Connect establishes a gRPC connection to a service
+// address is a comma-separated list of replicas, or a single target such as dns:///auth:9081 whose records are the replicas
 func (r *ServiceRegistry) Connect(serviceName, address string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	// Check if already connected
-	if _, exists := r.connections[serviceName]; exists {
+	if _, exists := r.backends[serviceName]; exists {
 		return nil
 	}
 
-	// Create connection with keepalive and interceptors
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	conn, err := grpc.NewClient(address,
+	target, endpoints := address, strings.Split(address, ",")
+	options := []grpc.DialOption{
+		grpc.WithDefaultServiceConfig(r.config.serviceConfig()),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                30 * time.Second,
@@ -107,94 +129,212 @@ func (r *ServiceRegistry) Connect(serviceName, address string) error {
 			grpc.MaxCallRecvMsgSize(10*1024*1024), // 10MB
 			grpc.MaxCallSendMsgSize(10*1024*1024), // 10MB
 		),
-	)
+	}
+	if len(endpoints) > 1 {
+		state := resolver.State{}
+		for i, endpoint := range endpoints {
+			endpoints[i] = strings.TrimSpace(endpoint)
+			state.Endpoints = append(state.Endpoints, resolver.Endpoint{Addresses: []resolver.Address{{Addr: endpoints[i]}}})
+		}
+		replicas := manual.NewBuilderWithScheme(endpointsScheme)
+		replicas.InitialState(state)
+		target = endpointsScheme + ":///" + serviceName
+		options = append(options, grpc.WithResolvers(replicas))
+	}
+
+	conn, err := grpc.NewClient(target, options...)
 	if err != nil {
 		return fmt.Errorf("failed to connect to %s at %s: %w", serviceName, address, err)
 	}
 
-	// Use context to avoid unused warning
-	_ = ctx
-
-	r.connections[serviceName] = conn
+	r.backends[serviceName] = &backend{name: serviceName, address: address, endpoints: endpoints, conn: conn}
+	r.order = append(r.order, serviceName)
 	return nil
 }
 
-// GetConnection returns a connection for a service by name
-func (r *ServiceRegistry) GetConnection(serviceName string) (*grpc.ClientConn, error) {
+// Discover asks a backend for its services through server reflection and rebuilds the method table when they changed
+func (r *ServiceRegistry) Discover(ctx context.Context, serviceName string) error {
 	r.mu.RLock()
-	defer r.mu.RUnlock()
-
-	conn, exists := r.connections[serviceName]
+	b, exists := r.backends[serviceName]
+	r.mu.RUnlock()
 	if !exists {
-		return nil, fmt.Errorf("no connection found for service: %s", serviceName)
+		return fmt.Errorf("no connection found for service: %s", serviceName)
 	}
 
-	return conn, nil
+	ctx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	defer cancel()
+
+	services, methods, err := reflectMethods(ctx, b.conn)
+	if err != nil {
+		return fmt.Errorf("failed to discover services at %s: %w", b.address, err)
+	}
+
+	r.mu.Lock()
+	changed := !b.discovered || !slices.Equal(b.methods, methods)
+	b.services, b.methods, b.discovered = services, methods, true
+	if changed {
+		r.rebuildMethods()
+	}
+	r.mu.Unlock()
+
+	if changed {
+		log.Printf("🔎 gRPC backend %s serves %d methods of %s", serviceName, len(methods), strings.Join(services, ", "))
+	}
+	return nil
 }
 
-// GetConnectionByMethod extracts service name from gRPC method path and returns connection
-// Method format: /package.ServiceName/MethodName
-func (r *ServiceRegistry) GetConnectionByMethod(method string) (*grpc.ClientConn, string, error) {
-	// Parse method to extract service name
-	// Format: /user.v1.UserService/GetUserByID -> extract "user" or map to service name
-	parts := strings.Split(strings.TrimPrefix(method, "/"), "/")
-	if len(parts) < 2 {
-		return nil, "", fmt.Errorf("invalid method format: %s", method)
+// Refresh discovers the services of every backend again
+func (r *ServiceRegistry) Refresh(ctx context.Context) {
+	for _, name := range r.GetAllServices() {
+		if err := r.Discover(ctx, name); err != nil && ctx.Err() == nil {
+			log.Printf("⚠️  gRPC backend %s: %v", name, err)
+		}
 	}
+}
 
-	fullService := parts[0] // e.g., "user.v1.UserService"
+// Watch re-discovers a backend whenever its connection becomes ready again, e.g. after it was redeployed,
+// and every backend at the given interval; 0 disables the periodic refresh
+func (r *ServiceRegistry) Watch(interval time.Duration) {
+	r.mu.RLock()
+	backends := make([]*backend, 0, len(r.order))
+	for _, name := range r.order {
+		backends = append(backends, r.backends[name])
+	}
+	r.mu.RUnlock()
 
-	// Extract service name from proto package
-	serviceName := r.extractServiceName(fullService)
+	for _, b := range backends {
+		r.wg.Add(1)
+		go r.watchBackend(b)
+	}
 
-	conn, err := r.GetConnection(serviceName)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to get connection for method %s: %w", method, err)
+	if interval > 0 {
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					r.Refresh(r.ctx)
+				case <-r.ctx.Done():
+					return
+				}
+			}
+		}()
 	}
+}
+
+// watchBackend follows the connectivity state of a backend until the registry is closed
+func (r *ServiceRegistry) watchBackend(b *backend) {
+	defer r.wg.Done()
 
-	return conn, serviceName, nil
+	state := b.conn.GetState()
+	ready := state == connectivity.Ready
+	for {
+		// Keep the connection up so that a restarted backend is noticed without waiting for a call
+		if state == connectivity.Idle {
+			b.conn.Connect()
+		}
+		if !b.conn.WaitForStateChange(r.ctx, state) {
+			return
+		}
+
+		state = b.conn.GetState()
+		if state == connectivity.Ready && !ready {
+			// A backend that comes back may have registered other services
+			if err := r.Discover(r.ctx, b.name); err != nil && r.ctx.Err() == nil {
+				log.Printf("⚠️  gRPC backend %s: %v", b.name, err)
+			}
+		}
+		ready = state == connectivity.Ready
+	}
 }
 
-// extractServiceName maps proto service to microservice name using service mapper
-func (r *ServiceRegistry) extractServiceName(fullService string) string {
-	// Parse proto package format: package.version.Service
-	// Example: user.v1.UserService -> extract "user.v1" -> lookup "auth"
+// serviceConfig selects the load balancing policy and client-side health checking of backend connections
+// Health checks use each replica's grpc.health.v1 server; replicas without one are treated as healthy
+func (c ProxyConfig) serviceConfig() string {
+	policy := `{"round_robin":{}}`
+	if c.LoadBalancing == "least_request" {
+		policy = `{"least_request_experimental":{"choiceCount":2}}`
+	}
 
-	// Extract proto package (everything before the last dot)
-	lastDot := strings.LastIndex(fullService, ".")
-	if lastDot == -1 {
-		return "unknown"
+	config := `{"loadBalancingConfig":[` + policy + `]`
+	if c.HealthChecks {
+		config += `,"healthCheckConfig":{"serviceName":""}`
 	}
+	return config + "}"
+}
 
-	protoPackage := fullService[:lastDot] // e.g., "user.v1"
+// rebuildMethods maps every discovered method to its backend; callers hold the write lock
+func (r *ServiceRegistry) rebuildMethods() {
+	methods := make(map[string]string)
+	for _, name := range r.order {
+		for _, method := range r.backends[name].methods {
+			if owner, exists := methods[method]; exists {
+				log.Printf("⚠️  gRPC method %s is served by %s and %s, routing it to %s", method, owner, name, owner)
+				continue
+			}
+			methods[method] = name
+		}
+	}
+	r.methods = methods
+}
 
-	// Use service mapper for dynamic lookup
-	mapper := pkggrpc.GetGlobalMapper()
-	microservice, err := mapper.GetMicroserviceByProtoPackage(protoPackage)
-	if err != nil {
-		// Fallback: try to extract first part before dot
-		parts := strings.Split(fullService, ".")
-		if len(parts) > 0 {
-			return strings.ToLower(parts[0])
+// GetConnection returns a connection for a service by name
+func (r *ServiceRegistry) GetConnection(serviceName string) (*grpc.ClientConn, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	b, exists := r.backends[serviceName]
+	if !exists {
+		return nil, fmt.Errorf("no connection found for service: %s", serviceName)
+	}
+
+	return b.conn, nil
+}
+
+// GetConnectionByMethod returns the connection of the backend serving a method
+// Method format: /package.ServiceName/MethodName
+func (r *ServiceRegistry) GetConnectionByMethod(method string) (*grpc.ClientConn, string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	serviceName, exists := r.methods[method]
+	if !exists {
+		// The method may belong to a backend that has not answered yet
+		var pending []string
+		for _, name := range r.order {
+			if !r.backends[name].discovered {
+				pending = append(pending, name)
+			}
+		}
+		if len(pending) > 0 {
+			return nil, "", fmt.Errorf("no backend for method %s, services of %s not discovered yet", method, strings.Join(pending, ", "))
 		}
-		return "unknown"
+		return nil, "", fmt.Errorf("%w %s", errUnknownMethod, method)
 	}
 
-	return microservice
+	return r.backends[serviceName].conn, serviceName, nil
 }
 
-// Close closes all connections
+// Close stops the watchers and closes all connections
 func (r *ServiceRegistry) Close() error {
+	r.cancel()
+	r.wg.Wait()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	for name, conn := range r.connections {
-		if err := conn.Close(); err != nil {
+	for name, b := range r.backends {
+		if err := b.conn.Close(); err != nil {
 			fmt.Printf("⚠️  Failed to close connection to %s: %v\n", name, err)
 		}
 	}
 
-	r.connections = make(map[string]*grpc.ClientConn)
+	r.backends = make(map[string]*backend)
+	r.order = nil
+	r.methods = make(map[string]string)
 	return nil
 }
 
@@ -203,9 +343,92 @@ func (r *ServiceRegistry) GetAllServices() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	services := make([]string, 0, len(r.connections))
-	for name := range r.connections {
+	return append([]string(nil), r.order...)
+}
+
+// reflectMethods lists the services of a backend and their methods through server reflection
+// The backend's own health and reflection services are left out, the proxy serves those itself
+func reflectMethods(ctx context.Context, conn *grpc.ClientConn) ([]string, []string, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer stream.CloseSend()
+
+	resp, err := reflectionRequest(stream, &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var services, methods []string
+	for _, svc := range resp.GetListServicesResponse().GetService() {
+		name := svc.GetName()
+		if name == "grpc.health.v1.Health" || strings.HasPrefix(name, "grpc.reflection.") {
+			continue
+		}
+
+		resp, err := reflectionRequest(stream, &reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: name},
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("describing %s: %w", name, err)
+		}
+
+		serviceMethods, err := describedMethods(name, resp.GetFileDescriptorResponse().GetFileDescriptorProto())
+		if err != nil {
+			return nil, nil, err
+		}
 		services = append(services, name)
+		methods = append(methods, serviceMethods...)
+	}
+
+	sort.Strings(services)
+	sort.Strings(methods)
+	return services, methods, nil
+}
+
+// reflectionRequest sends one reflection request and turns an error response into a status error
+func reflectionRequest(stream reflectionpb.ServerReflection_ServerReflectionInfoClient,
+	req *reflectionpb.ServerReflectionRequest) (*reflectionpb.ServerReflectionResponse, error) {
+
+	if err := stream.Send(req); err != nil {
+		return nil, err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, status.Error(codes.Code(errResp.GetErrorCode()), errResp.GetErrorMessage())
+	}
+	return resp, nil
+}
+
+// describedMethods finds a service in serialized file descriptors and returns its full method names
+func describedMethods(service string, files [][]byte) ([]string, error) {
+	for _, data := range files {
+		var file descriptorpb.FileDescriptorProto
+		if err := proto.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("invalid descriptor of %s: %w", service, err)
+		}
+
+		for _, svc := range file.GetService() {
+			name := svc.GetName()
+			if pkg := file.GetPackage(); pkg != "" {
+				name = pkg + "." + name
+			}
+			if name != service {
+				continue
+			}
+
+			methods := make([]string, 0, len(svc.GetMethod()))
+			for _, method := range svc.GetMethod() {
+				methods = append(methods, "/"+service+"/"+method.GetName())
+			}
+			return methods, nil
+		}
 	}
-	return services
+	return nil, fmt.Errorf("no descriptor found for %s", service)
 }
@@ -15,10 +15,12 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
+	"github.com/gorilla/websocket"
 	"github.com/joho/godotenv"
 )
 
 var grpcProxyServer *grpc.ProxyServer
+var gatewayRouter *router.Router
 
 func main() {
 	// Load environment variables
@@ -36,10 +38,23 @@ func main() {
 	}
 	defer cleanupDependencies()
 
+	// Authentication and method policies of the gRPC proxy
+	grpcAccess, err := SetupGRPCAccess(config)
+	if err != nil {
+		fmt.Printf("❌ Failed to load gRPC access policies: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Backends, forwarded metadata and deadlines of the gRPC proxy
+	grpcConfig, err := GRPCProxyConfig(config)
+	if err != nil {
+		fmt.Printf("❌ Failed to load gRPC deadlines: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Initialize gRPC proxy server
 	grpcPort := getGRPCPort()
-	var err error
-	grpcProxyServer, err = grpc.NewProxyServer(grpcPort)
+	grpcProxyServer, err = grpc.NewProxyServer(grpcPort, grpcConfig, grpcAccess)
 	if err != nil {
 		fmt.Printf("❌ Failed to initialize gRPC proxy: %v\n", err)
 		os.Exit(1)
@@ -54,7 +69,11 @@ func main() {
 	}()
 
 	// Setup gateway components
-	gatewayRouter := Setup()
+	gatewayRouter, err = Setup(config)
+	if err != nil {
+		fmt.Printf("❌ Failed to compose service schemas: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Setup HTTP router
 	r := chi.NewRouter()
@@ -70,20 +89,59 @@ func main() {
 		gatewayRouter.HandleRequest(w, r)
 	})
 
+	// GET serves subscriptions over WebSocket (graphql-transport-ws and graphql-ws) and queries in the URL
+	r.Get("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		if websocket.IsWebSocketUpgrade(r) {
+			gatewayRouter.HandleWebSocket(w, r)
+			return
+		}
+		gatewayRouter.HandleRequest(w, r)
+	})
+
 	// Add GraphQL Playground route
 	r.Get("/playground", router.ServePlayground)
 
 	// Add REST API routes for service proxying
-	// This will handle routes like /api/v1/{service_name}/{path}
-	r.HandleFunc("/api/v1/*", func(w http.ResponseWriter, r *http.Request) {
+	// Routes come from the REST routes file, with /api/v1/{service_name}/{path} as the default
+	r.HandleFunc("/api/*", func(w http.ResponseWriter, r *http.Request) {
 		gatewayRouter.HandleRESTRequest(w, r)
 	})
 
+	// Composed schema as SDL for client code generation
+	r.Get("/schema.graphql", HandleSDL)
+
+	// Admin endpoints, protected by GATEWAY_ADMIN_TOKEN and answering 404 without it
+	r.Route("/admin", func(admin chi.Router) {
+		admin.Use(adminOnly(config))
+
+		// Re-collect service schemas without a restart
+		admin.Post("/schema/refresh", HandleSchemaRefresh)
+
+		// Schema registry history and breaking change reports
+		admin.Get("/schema/versions", HandleSchemaVersions)
+		admin.Get("/schema/diff", HandleSchemaDiff)
+
+		// Persisted query manifests generated by the frontend build
+		// Uploads replace what the allow-list runs, so they are only mounted behind an admin token
+		if config.AdminToken != "" {
+			admin.Post("/persisted-queries", HandlePersistedQueryUpload)
+		}
+		admin.Get("/persisted-queries", HandlePersistedQueryList)
+	})
+
 	// Print info about available endpoints
 	fmt.Println("📊 API Endpoints:")
 	fmt.Println("  • GraphQL API: http://localhost:" + config.Port + "/graphql")
+	fmt.Println("  • GraphQL Subscriptions: ws://localhost:" + config.Port + "/graphql")
 	fmt.Println("  • GraphQL Playground: http://localhost:" + config.Port + "/playground")
-	fmt.Println("  • REST API: http://localhost:" + config.Port + "/api/v1/{service_name}/{path}")
+	fmt.Println("  • REST API: http://localhost:" + config.Port + "/api/v1/{service_name}/{path} and routes from " + config.RESTRoutesFile)
+	fmt.Println("  • Schema SDL: http://localhost:" + config.Port + "/schema.graphql")
+	if config.AdminToken != "" {
+		fmt.Println("  • Schema Refresh: POST http://localhost:" + config.Port + "/admin/schema/refresh")
+		fmt.Println("  • Persisted Queries: POST http://localhost:" + config.Port + "/admin/persisted-queries")
+	} else {
+		fmt.Println("  • Admin endpoints: disabled, GATEWAY_ADMIN_TOKEN is not set")
+	}
 	fmt.Printf("  • gRPC Proxy: localhost:%d\n", grpcPort)
 
 	// Setup graceful shutdown
@@ -139,6 +197,12 @@ func initDependencies() error {
 // cleanupDependencies performs cleanup of resources
 func cleanupDependencies() {
 	utils.CloseRedis()
+	if schemaManager != nil {
+		schemaManager.StopRefresher()
+	}
+	if gatewayRouter != nil {
+		gatewayRouter.Close()
+	}
 	if grpcProxyServer != nil {
 		grpcProxyServer.Stop()
 	}
@@ -0,0 +1,143 @@
+package persisted
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Store keeps persisted queries in Redis
+// Registered operations from manifests live in one hash without expiry, APQ registrations expire after the TTL
+type Store struct {
+	client    *redis.Client
+	ttl       time.Duration
+	keyPrefix string
+}
+
+// NewStore creates a persisted query store; APQ registrations expire after ttl, 0 keeps them forever
+func NewStore(client *redis.Client, ttl time.Duration) *Store {
+	return &Store{client: client, ttl: ttl, keyPrefix: "gateway:pq"}
+}
+
+// Hash returns the hex SHA-256 of a query, the id used by APQ and manifests
+func Hash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// Lookup returns the query registered for a hash, from the manifest or, unless registeredOnly, the APQ cache
+func (s *Store) Lookup(ctx context.Context, hash string, registeredOnly bool) (string, bool, error) {
+	query, err := s.client.HGet(ctx, s.manifestKey(), hash).Result()
+	if err == nil {
+		return query, true, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return "", false, err
+	}
+	if registeredOnly {
+		return "", false, nil
+	}
+
+	query, err = s.client.Get(ctx, s.apqKey(hash)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return query, true, nil
+}
+
+// Registered reports whether a hash is part of the uploaded manifests
+func (s *Store) Registered(ctx context.Context, hash string) (bool, error) {
+	return s.client.HExists(ctx, s.manifestKey(), hash).Result()
+}
+
+// Save caches a query sent with its hash by an APQ client
+func (s *Store) Save(ctx context.Context, hash, query string) error {
+	return s.client.Set(ctx, s.apqKey(hash), query, s.ttl).Err()
+}
+
+// Register adds the operations of a manifest, keyed by hash; replace drops previously registered operations
+func (s *Store) Register(ctx context.Context, operations map[string]string, replace bool) error {
+	pipe := s.client.TxPipeline()
+	if replace {
+		pipe.Del(ctx, s.manifestKey())
+	}
+	if len(operations) > 0 {
+		values := make(map[string]interface{}, len(operations))
+		for hash, query := range operations {
+			values[hash] = query
+		}
+		pipe.HSet(ctx, s.manifestKey(), values)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Hashes returns the hashes of every registered operation in sorted order
+func (s *Store) Hashes(ctx context.Context) ([]string, error) {
+	hashes, err := s.client.HKeys(ctx, s.manifestKey()).Result()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(hashes)
+	return hashes, nil
+}
+
+func (s *Store) manifestKey() string {
+	return s.keyPrefix + ":manifest"
+}
+
+func (s *Store) apqKey(hash string) string {
+	return s.keyPrefix + ":apq:" + hash
+}
+
+// apolloManifest is the persisted query manifest written by Apollo's generate-persisted-query-manifest
+type apolloManifest struct {
+	Format     string `json:"format"`
+	Operations []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Body string `json:"body"`
+	} `json:"operations"`
+}
+
+// ParseManifest reads an Apollo persisted query manifest or a plain {"hash": "query"} object
+// Every hash must be the SHA-256 of its query
+func ParseManifest(data []byte) (map[string]string, error) {
+	operations := make(map[string]string)
+
+	var apollo apolloManifest
+	if err := json.Unmarshal(data, &apollo); err == nil && apollo.Format != "" {
+		for _, op := range apollo.Operations {
+			operations[op.ID] = op.Body
+		}
+	} else if err := json.Unmarshal(data, &operations); err != nil {
+		return nil, fmt.Errorf("manifest must be an Apollo persisted query manifest or a hash to query object: %w", err)
+	}
+
+	// Hashes are stored in lower case, the form clients send
+	normalized := make(map[string]string, len(operations))
+	var mismatched []string
+	for hash, query := range operations {
+		if !strings.EqualFold(hash, Hash(query)) {
+			mismatched = append(mismatched, hash)
+		}
+		normalized[strings.ToLower(hash)] = query
+	}
+	if len(mismatched) > 0 {
+		sort.Strings(mismatched)
+		return nil, fmt.Errorf("hash does not match the query of %d operations: %s", len(mismatched), strings.Join(mismatched, ", "))
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,282 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Rule allows Limit requests per key within a sliding window
+type Rule struct {
+	Limit  int           // Requests allowed per window, 0 disables the rule
+	Window time.Duration // Length of the sliding window
+}
+
+// Config selects the limits applied to each request
+type Config struct {
+	User              Rule            // Per authenticated user, across operations
+	Tenant            Rule            // Per tenant, across its users
+	IP                Rule            // Per client IP, for anonymous requests
+	Operations        map[string]Rule // Per root field and caller; anonymous callers are keyed by IP
+	TrustForwardedFor bool            // Take the client IP from X-Forwarded-For, only safe behind a proxy that appends it
+	TrustedProxies    []*net.IPNet    // Proxies in front of the gateway, whose hops in X-Forwarded-For are skipped
+}
+
+// Subject is the caller and the operations of one request
+type Subject struct {
+	UserID     int      // Authenticated user, 0 for anonymous requests
+	TenantID   int      // Tenant of the user, 0 when unknown
+	IP         string   // Client IP
+	Operations []string // Root fields of a GraphQL request, once per execution
+}
+
+// Decision is the outcome of checking a request against every applicable rule
+type Decision struct {
+	Allowed    bool
+	Limit      int           // Limit of the most constrained rule
+	Remaining  int           // Requests left in the window of that rule
+	RetryAfter time.Duration // Time until the request would be allowed, when rejected
+	Rule       string        // Key of the rule that rejected the request
+}
+
+// Limiter enforces sliding window rate limits with counters in Redis
+type Limiter struct {
+	client    *redis.Client
+	config    Config
+	keyPrefix string
+}
+
+// check is one rule applied to one key of a request
+type check struct {
+	key  string
+	rule Rule
+	hits int // Requests the request counts as, e.g. a root field executed under several aliases
+}
+
+// slidingWindow checks every rule of a request and counts the request only if all of them allow it
+// KEYS holds the current and previous window counter of each rule; ARGV holds limit, previous window weight,
+// TTL in ms and hits of each rule
+// Returns {1, rule index, remaining} when allowed, {0, rule index, current, previous} when rejected
+var slidingWindow = redis.NewScript(`
+local rules = #KEYS / 2
+local remaining, tightest = -1, 0
+for i = 1, rules do
+	local current = tonumber(redis.call('GET', KEYS[2 * i - 1]) or '0')
+	local previous = tonumber(redis.call('GET', KEYS[2 * i]) or '0')
+	local limit = tonumber(ARGV[4 * i - 3])
+	local hits = tonumber(ARGV[4 * i])
+	local estimate = math.floor(previous * tonumber(ARGV[4 * i - 2])) + current
+	if estimate + hits > limit then
+		return {0, i, current, previous}
+	end
+	if remaining < 0 or limit - estimate - hits < remaining then
+		remaining, tightest = limit - estimate - hits, i
+	end
+end
+for i = 1, rules do
+	redis.call('INCRBY', KEYS[2 * i - 1], ARGV[4 * i])
+	redis.call('PEXPIRE', KEYS[2 * i - 1], ARGV[4 * i - 1])
+end
+return {1, tightest, remaining}
+`)
+
+// New creates a rate limiter storing its counters under gateway:ratelimit
+func New(client *redis.Client, config Config) *Limiter {
+	return &Limiter{client: client, config: config, keyPrefix: "gateway:ratelimit"}
+}
+
+// Allow checks a request against the user, tenant, IP and operation rules and counts it when allowed
+func (l *Limiter) Allow(ctx context.Context, subject Subject) (*Decision, error) {
+	checks := l.checks(subject)
+	if len(checks) == 0 {
+		return &Decision{Allowed: true, Remaining: -1}, nil
+	}
+
+	now := time.Now().UnixMilli()
+	keys := make([]string, 0, 2*len(checks))
+	args := make([]interface{}, 0, 4*len(checks))
+	for _, c := range checks {
+		window := c.rule.Window.Milliseconds()
+		index := now / window
+		elapsed := float64(now%window) / float64(window)
+
+		keys = append(keys,
+			fmt.Sprintf("%s:%s:%d", l.keyPrefix, c.key, index),
+			fmt.Sprintf("%s:%s:%d", l.keyPrefix, c.key, index-1))
+		args = append(args, c.rule.Limit, strconv.FormatFloat(1-elapsed, 'f', 4, 64), 2*window, c.hits)
+	}
+
+	result, err := slidingWindow.Run(ctx, l.client, keys, args...).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("rate limit check failed: %w", err)
+	}
+
+	c := checks[result[1]-1]
+	decision := &Decision{Allowed: result[0] == 1, Limit: c.rule.Limit, Rule: c.key}
+	if decision.Allowed {
+		decision.Remaining = int(result[2])
+		return decision, nil
+	}
+
+	// More executions than the limit never fit in a window
+	if c.hits > c.rule.Limit {
+		decision.RetryAfter = c.rule.Window
+		return decision, nil
+	}
+	window := c.rule.Window.Milliseconds()
+	decision.RetryAfter = retryAfter(c.rule.Limit-c.hits+1, result[2], result[3], window, now%window)
+	return decision, nil
+}
+
+// checks lists the rules that apply to a request
+func (l *Limiter) checks(subject Subject) []check {
+	var checks []check
+	add := func(key string, rule Rule, hits int) {
+		if rule.Limit > 0 && rule.Window >= time.Millisecond {
+			checks = append(checks, check{key: key, rule: rule, hits: hits})
+		}
+	}
+
+	caller := "ip:" + subject.IP
+	if subject.UserID != 0 {
+		caller = fmt.Sprintf("user:%d", subject.UserID)
+		add(caller, l.config.User, 1)
+		if subject.TenantID != 0 {
+			add(fmt.Sprintf("tenant:%d", subject.TenantID), l.config.Tenant, 1)
+		}
+	} else {
+		add(caller, l.config.IP, 1)
+	}
+
+	// An operation counts once per execution, so aliases cannot multiply the attempts of one request
+	var operations []string
+	executions := make(map[string]int, len(subject.Operations))
+	for _, operation := range subject.Operations {
+		if executions[operation] == 0 {
+			operations = append(operations, operation)
+		}
+		executions[operation]++
+	}
+	for _, operation := range operations {
+		add("op:"+operation+":"+caller, l.config.Operations[operation], executions[operation])
+	}
+
+	return checks
+}
+
+// retryAfter estimates when the sliding window count drops below the limit again
+// A request of several hits passes a limit reduced by the hits beyond the first
+func retryAfter(limit int, current, previous, window, elapsed int64) time.Duration {
+	var wait float64
+	if current >= int64(limit) {
+		// Only after the current window has become the previous one and partly slid out
+		wait = float64(window-elapsed) + float64(window)*(1-float64(limit)/float64(current))
+	} else {
+		// The previous window's weight has to drop until the estimate is below the limit
+		wait = float64(window)*(1-float64(int64(limit)-current)/float64(previous)) - float64(elapsed)
+	}
+
+	seconds := math.Ceil(wait / 1000)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// ClientIP returns the IP of the client that sent a request
+// With TrustForwardedFor it is the rightmost X-Forwarded-For hop that is not a trusted proxy, since clients
+// can put anything in front of the hops appended by the proxies
+func (l *Limiter) ClientIP(req *http.Request) string {
+	if l.config.TrustForwardedFor {
+		hops := strings.Split(strings.Join(req.Header.Values("X-Forwarded-For"), ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if hop == "" || l.trustedProxy(hop) {
+				continue
+			}
+			return hop
+		}
+	}
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// trustedProxy reports whether an address belongs to one of the trusted proxies
+func (l *Limiter) trustedProxy(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range l.config.TrustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseNetworks reads IP addresses and CIDR ranges such as "10.0.0.0/8,192.168.1.10"
+func ParseNetworks(spec string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR range %q", entry)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// ParseRules reads per-operation rules written as "login:5,register:5/10m,roles:300"
+// A rule without a window uses the default window
+func ParseRules(spec string, defaultWindow time.Duration) map[string]Rule {
+	rules := make(map[string]Rule)
+	for _, entry := range strings.Split(spec, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || name == "" {
+			continue
+		}
+
+		limitStr, windowStr, hasWindow := strings.Cut(value, "/")
+		limit, err := strconv.Atoi(strings.TrimSpace(limitStr))
+		if err != nil {
+			continue
+		}
+		rule := Rule{Limit: limit, Window: defaultWindow}
+		if hasWindow {
+			if window, err := time.ParseDuration(strings.TrimSpace(windowStr)); err == nil {
+				rule.Window = window
+			}
+		}
+		rules[strings.TrimSpace(name)] = rule
+	}
+	return rules
+}
@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newLimiter creates a limiter backed by an in-memory Redis
+func newLimiter(t *testing.T, config Config) *Limiter {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return New(client, config)
+}
+
+func TestAllowCountsEveryAliasedExecution(t *testing.T) {
+	limiter := newLimiter(t, Config{Operations: map[string]Rule{"login": {Limit: 5, Window: time.Minute}}})
+	ctx := context.Background()
+
+	// Three aliased logins leave room for two more
+	decision, err := limiter.Allow(ctx, Subject{IP: "203.0.113.7", Operations: []string{"login", "login", "login"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decision.Allowed || decision.Remaining != 2 {
+		t.Fatalf("expected 2 remaining after 3 logins, got %+v", decision)
+	}
+
+	decision, err = limiter.Allow(ctx, Subject{IP: "203.0.113.7", Operations: []string{"login", "login", "login"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decision.Allowed {
+		t.Fatal("expected 3 more logins to exceed the limit of 5")
+	}
+
+	decision, err = limiter.Allow(ctx, Subject{IP: "203.0.113.7", Operations: []string{"login", "login"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decision.Allowed || decision.Remaining != 0 {
+		t.Fatalf("expected the last 2 logins to be allowed, got %+v", decision)
+	}
+}
+
+func TestAllowRejectsMoreExecutionsThanTheLimit(t *testing.T) {
+	limiter := newLimiter(t, Config{Operations: map[string]Rule{"login": {Limit: 5, Window: time.Minute}}})
+
+	operations := make([]string, 30)
+	for i := range operations {
+		operations[i] = "login"
+	}
+	decision, err := limiter.Allow(context.Background(), Subject{IP: "203.0.113.7", Operations: operations})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decision.Allowed || decision.RetryAfter != time.Minute {
+		t.Fatalf("expected 30 aliased logins to be rejected for a window, got %+v", decision)
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	proxies, err := ParseNetworks("10.0.0.0/8, 192.168.1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		config    Config
+		forwarded []string
+		want      string
+	}{
+		{"ignored without trust", Config{}, []string{"198.51.100.1"}, "192.0.2.1"},
+		{"rightmost hop", Config{TrustForwardedFor: true}, []string{"198.51.100.1, 203.0.113.7"}, "203.0.113.7"},
+		{"spoofed hops before the proxy's", Config{TrustForwardedFor: true, TrustedProxies: proxies},
+			[]string{"198.51.100.1, 203.0.113.7, 10.1.2.3"}, "203.0.113.7"},
+		{"several headers", Config{TrustForwardedFor: true, TrustedProxies: proxies},
+			[]string{"198.51.100.1", "203.0.113.7, 192.168.1.10"}, "203.0.113.7"},
+		{"only proxies", Config{TrustForwardedFor: true, TrustedProxies: proxies}, []string{"10.1.2.3"}, "192.0.2.1"},
+		{"no header", Config{TrustForwardedFor: true}, nil, "192.0.2.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/graphql", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			for _, value := range tt.forwarded {
+				req.Header.Add("X-Forwarded-For", value)
+			}
+			if got := (&Limiter{config: tt.config}).ClientIP(req); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseNetworksRejectsInvalidEntries(t *testing.T) {
+	for _, spec := range []string{"10.0.0.0/33", "proxy.internal"} {
+		if _, err := ParseNetworks(spec); err == nil {
+			t.Fatalf("expected %q to be rejected", spec)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/saurabh/entgo-microservices/pkg/identity"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// QueryLimits bounds the shape and estimated cost of operations before they are forwarded
+type QueryLimits struct {
+	MaxDepth              int            // Deepest field nesting, 0 for no limit
+	MaxIntrospectionDepth int            // Deepest nesting of __schema and __type, 0 for no limit
+	MaxAliases            int            // Aliased fields per operation, 0 for no limit
+	MaxCost               int            // Default cost budget, 0 for no limit
+	CostByRole            map[string]int // Cost budgets by role name
+	CostByTenant          map[string]int // Cost budgets by tenant id, taking precedence over role budgets
+	DefaultListSize       int            // Assumed length of lists requested without first or last
+}
+
+// queryCost is the result of analysing an operation, returned to clients in extensions.cost
+type queryCost struct {
+	Depth              int `json:"depth"`
+	IntrospectionDepth int `json:"introspectionDepth,omitempty"` // Deepest nesting of __schema and __type
+	Aliases            int `json:"aliases"`
+	Cost               int `json:"cost"`             // Estimated number of objects the operation returns
+	Budget             int `json:"budget,omitempty"` // Cost budget of the caller, 0 for no limit
+}
+
+// queryLimitError rejects an operation that exceeds a limit
+type queryLimitError struct {
+	message string
+	cost    *queryCost
+}
+
+func (e *queryLimitError) Error() string {
+	return e.message
+}
+
+// SetQueryLimits enables depth, alias and cost checks of operations
+func (r *Router) SetQueryLimits(limits QueryLimits) {
+	r.limits = &limits
+}
+
+// analyzeOperation computes depth, aliases and cost of an operation and checks them against the caller's limits
+// It returns nil when no limits are set
+func (r *Router) analyzeOperation(doc *ast.QueryDocument, op *ast.OperationDefinition,
+	variables map[string]interface{}, caller *identity.Identity) (*queryCost, error) {
+
+	if r.limits == nil {
+		return nil, nil
+	}
+
+	schema := r.SchemaManager.GetSchema()
+	analyzer := &costAnalyzer{
+		schema:    schema,
+		doc:       doc,
+		variables: variables,
+		listSize:  r.limits.DefaultListSize,
+		fragments: make(map[string]bool),
+	}
+	if analyzer.listSize <= 0 {
+		analyzer.listSize = 1
+	}
+
+	var root string
+	switch op.Operation {
+	case ast.Mutation:
+		root = typeName(schema.Mutation)
+	case ast.Subscription:
+		root = typeName(schema.Subscription)
+	default:
+		root = typeName(schema.Query)
+	}
+
+	cost := &analyzer.result
+	cost.Cost = analyzer.selections(op.SelectionSet, root, 0, 1, 0)
+	cost.Budget = r.limits.budget(caller)
+
+	switch {
+	case r.limits.MaxDepth > 0 && cost.Depth > r.limits.MaxDepth:
+		return cost, &queryLimitError{fmt.Sprintf("Query depth %d exceeds the maximum of %d", cost.Depth, r.limits.MaxDepth), cost}
+	case r.limits.MaxIntrospectionDepth > 0 && cost.IntrospectionDepth > r.limits.MaxIntrospectionDepth:
+		return cost, &queryLimitError{fmt.Sprintf("Introspection depth %d exceeds the maximum of %d",
+			cost.IntrospectionDepth, r.limits.MaxIntrospectionDepth), cost}
+	case r.limits.MaxAliases > 0 && cost.Aliases > r.limits.MaxAliases:
+		return cost, &queryLimitError{fmt.Sprintf("Query uses %d aliases, the maximum is %d", cost.Aliases, r.limits.MaxAliases), cost}
+	case cost.Budget > 0 && cost.Cost > cost.Budget:
+		return cost, &queryLimitError{fmt.Sprintf("Query cost %d exceeds the budget of %d", cost.Cost, cost.Budget), cost}
+	}
+	return cost, nil
+}
+
+// budget returns the cost budget of a caller: its tenant's, else its role's, else the default
+func (l *QueryLimits) budget(caller *identity.Identity) int {
+	if caller != nil {
+		if budget, ok := l.CostByTenant[strconv.Itoa(caller.TenantID)]; ok && caller.TenantID != 0 {
+			return budget
+		}
+		if budget, ok := l.CostByRole[caller.Role]; ok && caller.Role != "" {
+			return budget
+		}
+	}
+	return l.MaxCost
+}
+
+// costAnalyzer walks an operation with the merged schema
+type costAnalyzer struct {
+	schema    *ast.Schema
+	doc       *ast.QueryDocument
+	variables map[string]interface{}
+	listSize  int
+	fragments map[string]bool // Fragments being expanded, to stop on cycles
+	result    queryCost
+}
+
+// selections returns the cost of a selection set whose parent objects are expected multiplier times
+// page is the first or last argument of a parent connection, applied to the first list below it
+func (a *costAnalyzer) selections(selections ast.SelectionSet, parent string, depth, multiplier, page int) int {
+	cost := 0
+
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if !shouldInclude(sel.Directives, a.variables) || sel.Name == "__typename" {
+				continue
+			}
+			if sel.Alias != "" && sel.Alias != sel.Name {
+				a.result.Aliases++
+			}
+			if isIntrospectionField(sel.Name) {
+				a.introspection(sel.SelectionSet, 1)
+				continue
+			}
+			if depth+1 > a.result.Depth {
+				a.result.Depth = depth + 1
+			}
+			if len(sel.SelectionSet) == 0 {
+				continue
+			}
+
+			var fieldDef *ast.FieldDefinition
+			if def := a.schema.Types[parent]; def != nil {
+				fieldDef = def.Fields.ForName(sel.Name)
+			}
+			pageSize := a.pageSize(sel)
+
+			// objects is the number of values of this field and the parents of its selections
+			objects, childPage := multiplier, 0
+			switch {
+			case fieldDef != nil && fieldDef.Type.Elem != nil:
+				size := pageSize
+				if size == 0 {
+					size = page
+				}
+				if size == 0 {
+					size = a.listSize
+				}
+				objects = saturatingMul(multiplier, size)
+			case pageSize > 0:
+				// A Relay connection: the page size applies to its edges or nodes
+				childPage = pageSize
+			}
+
+			childType := ""
+			if fieldDef != nil {
+				childType = fieldDef.Type.Name()
+			}
+			cost = saturatingAdd(cost, objects)
+			cost = saturatingAdd(cost, a.selections(sel.SelectionSet, childType, depth+1, objects, childPage))
+
+		case *ast.InlineFragment:
+			if !shouldInclude(sel.Directives, a.variables) {
+				continue
+			}
+			typeCondition := parent
+			if sel.TypeCondition != "" {
+				typeCondition = sel.TypeCondition
+			}
+			cost = saturatingAdd(cost, a.selections(sel.SelectionSet, typeCondition, depth, multiplier, page))
+
+		case *ast.FragmentSpread:
+			fragment := a.doc.Fragments.ForName(sel.Name)
+			if fragment == nil || a.fragments[sel.Name] || !shouldInclude(sel.Directives, a.variables) {
+				continue
+			}
+			a.fragments[sel.Name] = true
+			cost = saturatingAdd(cost, a.selections(fragment.SelectionSet, fragment.TypeCondition, depth, multiplier, page))
+			delete(a.fragments, sel.Name)
+		}
+	}
+
+	return cost
+}
+
+// introspection counts the aliases and depth of a __schema or __type field answered by the gateway
+// Its cost is not estimated, as the standard introspection query alone would exceed most budgets
+func (a *costAnalyzer) introspection(selections ast.SelectionSet, depth int) {
+	if depth > a.result.IntrospectionDepth {
+		a.result.IntrospectionDepth = depth
+	}
+
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if !shouldInclude(sel.Directives, a.variables) || sel.Name == "__typename" {
+				continue
+			}
+			if sel.Alias != "" && sel.Alias != sel.Name {
+				a.result.Aliases++
+			}
+			a.introspection(sel.SelectionSet, depth+1)
+
+		case *ast.InlineFragment:
+			if shouldInclude(sel.Directives, a.variables) {
+				a.introspection(sel.SelectionSet, depth)
+			}
+
+		case *ast.FragmentSpread:
+			fragment := a.doc.Fragments.ForName(sel.Name)
+			if fragment == nil || a.fragments[sel.Name] || !shouldInclude(sel.Directives, a.variables) {
+				continue
+			}
+			a.fragments[sel.Name] = true
+			a.introspection(fragment.SelectionSet, depth)
+			delete(a.fragments, sel.Name)
+		}
+	}
+}
+
+// pageSize returns the largest first or last argument of a field, or 0 without either
+func (a *costAnalyzer) pageSize(field *ast.Field) int {
+	size := 0
+	for _, name := range []string{"first", "last"} {
+		arg := field.Arguments.ForName(name)
+		if arg == nil || arg.Value == nil {
+			continue
+		}
+		value, err := arg.Value.Value(a.variables)
+		if err != nil {
+			continue
+		}
+
+		var n int
+		switch v := value.(type) {
+		case int64:
+			n = int(v)
+		case int:
+			n = v
+		case float64:
+			n = int(v)
+		case json.Number:
+			parsed, _ := v.Int64()
+			n = int(parsed)
+		}
+		if n > size {
+			size = n
+		}
+	}
+	return size
+}
+
+// writeQueryLimitError rejects an operation over its limits, reporting the computed cost
+func writeQueryLimitError(w http.ResponseWriter, err *queryLimitError) {
+	body, _ := json.Marshal(map[string]interface{}{
+		"errors": []map[string]interface{}{
+			{
+				"message": err.message,
+				"extensions": map[string]interface{}{
+					"code": "QUERY_TOO_COMPLEX",
+					"cost": err.cost,
+				},
+			},
+		},
+	})
+	w.WriteHeader(http.StatusBadRequest)
+	w.Write(body)
+}
+
+// withExtension adds a top-level extension to a service's JSON response
+// Responses that are not JSON objects are returned unchanged
+func withExtension(body []byte, name string, value interface{}) []byte {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err != nil {
+		return body
+	}
+
+	extensions := make(map[string]json.RawMessage)
+	if raw, ok := response["extensions"]; ok {
+		json.Unmarshal(raw, &extensions)
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	extensions[name] = encoded
+
+	merged := newOrderedData()
+	for _, key := range []string{"data", "errors"} {
+		if raw, ok := response[key]; ok {
+			merged.Set(key, raw)
+		}
+	}
+	encodedExtensions, _ := json.Marshal(extensions)
+	merged.Set("extensions", encodedExtensions)
+	for key, raw := range response {
+		if key != "data" && key != "errors" && key != "extensions" {
+			merged.Set(key, raw)
+		}
+	}
+
+	result, err := merged.MarshalJSON()
+	if err != nil {
+		return body
+	}
+	return result
+}
+
+// typeName returns the name of a definition, or "" for nil
+func typeName(def *ast.Definition) string {
+	if def == nil {
+		return ""
+	}
+	return def.Name
+}
+
+// saturatingAdd adds two non-negative costs without overflowing
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt32-b {
+		return math.MaxInt32
+	}
+	return a + b
+}
+
+// saturatingMul multiplies two non-negative counts without overflowing
+func saturatingMul(a, b int) int {
+	if a != 0 && b > math.MaxInt32/a {
+		return math.MaxInt32
+	}
+	return a * b
+}
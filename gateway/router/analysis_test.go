@@ -0,0 +1,183 @@
+package router
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// standardIntrospection is the introspection query sent by GraphiQL and gqlgen clients
+const standardIntrospection = `
+query IntrospectionQuery {
+  __schema {
+    description
+    queryType {
+      name
+    }
+    mutationType {
+      name
+    }
+    subscriptionType {
+      name
+    }
+    types {
+      ...FullType
+    }
+    directives {
+      name
+      description
+      locations
+      args {
+        ...InputValue
+      }
+    }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description
+  specifiedByURL
+  fields(includeDeprecated: true) {
+    name
+    description
+    args {
+      ...InputValue
+    }
+    type {
+      ...TypeRef
+    }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields {
+    ...InputValue
+  }
+  interfaces {
+    ...TypeRef
+  }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes {
+    ...TypeRef
+  }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type {
+    ...TypeRef
+  }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+// analyze runs the query analysis of the default limits on a query
+func analyze(t *testing.T, query string) (*queryCost, error) {
+	t.Helper()
+
+	schema, err := gqlparser.LoadSchema(&ast.Source{Input: `
+		type Query { posts(first: Int): [Post!]! }
+		type Post { id: ID! title: String }
+	`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, op, err := parseOperation(query, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRouter(&fakeSchema{schema: schema})
+	defer r.Close()
+	r.SetQueryLimits(QueryLimits{MaxDepth: 10, MaxIntrospectionDepth: 15, MaxAliases: 30, MaxCost: 5000, DefaultListSize: 10})
+	return r.analyzeOperation(doc, op, nil, nil)
+}
+
+func TestAnalyzeAllowsStandardIntrospection(t *testing.T) {
+	cost, err := analyze(t, standardIntrospection)
+	if err != nil {
+		t.Fatalf("the standard introspection query was rejected: %v", err)
+	}
+	if cost.IntrospectionDepth != 13 || cost.Cost != 0 {
+		t.Fatalf("expected introspection depth 13 without cost, got %+v", cost)
+	}
+}
+
+func TestAnalyzeLimitsIntrospection(t *testing.T) {
+	// Each level lists the fields of the types of the level above
+	nestedFields := strings.Repeat("fields { type { ", 7) + "name" + strings.Repeat(" } }", 7)
+
+	tests := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{
+			"nested types",
+			`{ __schema { types { ` + nestedFields + ` } } }`,
+			"Introspection depth 17",
+		},
+		{
+			"nested through a fragment",
+			`{ __type(name: "Post") { ...T } } fragment T on __Type { ` + nestedFields + ` }`,
+			"Introspection depth 16",
+		},
+		{
+			"aliases",
+			`{ __schema { ` + strings.Repeat("a: types { name } b: types { name } c: types { name } ", 11) + `} }`,
+			"Query uses 33 aliases",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := analyze(t, tt.query)
+			var limitErr *queryLimitError
+			if !errors.As(err, &limitErr) || !strings.HasPrefix(limitErr.message, tt.message) {
+				t.Fatalf("expected %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
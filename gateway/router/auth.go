@@ -0,0 +1,39 @@
+package router
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/saurabh/entgo-microservices/pkg/identity"
+)
+
+// Authenticator validates client credentials once at the edge
+type Authenticator interface {
+	// Authenticate validates an Authorization header value and returns the caller and its signed identity to forward
+	// An empty header is anonymous and yields no identity; invalid, expired and revoked tokens fail
+	Authenticate(ctx context.Context, authorization string) (*identity.Identity, string, error)
+}
+
+// SetAuthenticator enables token validation at the gateway
+// Without one, client tokens are forwarded for the services to validate
+func (r *Router) SetAuthenticator(auth Authenticator) {
+	r.auth = auth
+}
+
+// authenticate replaces client-supplied identity headers with the identity validated by the gateway
+// It returns the caller, or nil for anonymous requests and when no authenticator is set
+func (r *Router) authenticate(ctx context.Context, header http.Header) (*identity.Identity, error) {
+	identity.StripHeaders(header)
+	if r.auth == nil {
+		return nil, nil
+	}
+
+	caller, signed, err := r.auth.Authenticate(ctx, header.Get("Authorization"))
+	if err != nil {
+		return nil, err
+	}
+	if signed != "" {
+		header.Set(identity.Header, signed)
+	}
+	return caller, nil
+}
@@ -0,0 +1,123 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/saurabh/entgo-microservices/pkg/identity"
+)
+
+// SetMaxBatchSize sets how many operations a batched request may contain, 0 disables batching
+func (r *Router) SetMaxBatchSize(size int) {
+	r.maxBatchSize = size
+}
+
+// readRequests decodes the operations of a request
+// GET requests carry one query in the URL; POST bodies hold one operation or, when batched, an array of them
+func (r *Router) readRequests(req *http.Request) ([]GraphQLRequest, bool, error) {
+	if req.Method == http.MethodGet {
+		graphQLReq, err := urlRequest(req)
+		if err != nil {
+			return nil, false, err
+		}
+		return []GraphQLRequest{*graphQLReq}, false, nil
+	}
+
+	// Check if content type is application/json
+	contentType := req.Header.Get("Content-Type")
+	if !strings.Contains(contentType, "application/json") {
+		return nil, false, fmt.Errorf("Content-Type must be application/json")
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("Invalid GraphQL request: %v", err)
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 || body[0] != '[' {
+		var graphQLReq GraphQLRequest
+		if err := json.Unmarshal(body, &graphQLReq); err != nil {
+			return nil, false, fmt.Errorf("Invalid GraphQL request: %v", err)
+		}
+		return []GraphQLRequest{graphQLReq}, false, nil
+	}
+
+	if r.maxBatchSize <= 0 {
+		return nil, true, errors.New("Batched requests are not supported")
+	}
+	var requests []GraphQLRequest
+	if err := json.Unmarshal(body, &requests); err != nil {
+		return nil, true, fmt.Errorf("Invalid GraphQL request: %v", err)
+	}
+	if len(requests) == 0 {
+		return nil, true, errors.New("Batched request contains no operations")
+	}
+	if len(requests) > r.maxBatchSize {
+		return nil, true, fmt.Errorf("Batched request contains %d operations, the maximum is %d", len(requests), r.maxBatchSize)
+	}
+	return requests, true, nil
+}
+
+// urlRequest reads the query, operationName, variables and extensions parameters of a GET request
+func urlRequest(req *http.Request) (*GraphQLRequest, error) {
+	params := req.URL.Query()
+	graphQLReq := &GraphQLRequest{
+		Query:         params.Get("query"),
+		OperationName: params.Get("operationName"),
+	}
+
+	if variables := params.Get("variables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &graphQLReq.Variables); err != nil {
+			return nil, fmt.Errorf("Invalid variables parameter: %v", err)
+		}
+	}
+	if extensions := params.Get("extensions"); extensions != "" {
+		if err := json.Unmarshal([]byte(extensions), &graphQLReq.Extensions); err != nil {
+			return nil, fmt.Errorf("Invalid extensions parameter: %v", err)
+		}
+	}
+	return graphQLReq, nil
+}
+
+// serveBatch executes the operations of a batched request concurrently and returns their results in order
+// Every operation is authorised, limited and cached on its own; failures are reported in its result
+func (r *Router) serveBatch(w http.ResponseWriter, req *http.Request, requests []GraphQLRequest, caller *identity.Identity) {
+	responses := make([]*bufferedResponse, len(requests))
+
+	var wg sync.WaitGroup
+	for i, graphQLReq := range requests {
+		wg.Add(1)
+		go func(i int, graphQLReq GraphQLRequest) {
+			defer wg.Done()
+			responses[i] = newBufferedResponse()
+			r.serveOperation(responses[i], req, graphQLReq, caller)
+		}(i, graphQLReq)
+	}
+	wg.Wait()
+
+	var body bytes.Buffer
+	body.WriteByte('[')
+	for i, response := range responses {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		result := bytes.TrimSpace(response.body.Bytes())
+		if !json.Valid(result) {
+			result, _ = json.Marshal(map[string]interface{}{
+				"errors": []map[string]string{{"message": fmt.Sprintf("Service returned an invalid response (status %d)", response.status)}},
+			})
+		}
+		body.Write(result)
+	}
+	body.WriteByte(']')
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
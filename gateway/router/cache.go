@@ -0,0 +1,360 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/saurabh/entgo-microservices/gateway/cache"
+	"github.com/saurabh/entgo-microservices/pkg/identity"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// CacheHint allows responses containing a type or field to be cached for MaxAge
+type CacheHint struct {
+	MaxAge  time.Duration // How long the response may be served from the cache
+	Private bool          // The response may only be cached per user
+}
+
+// ResponseCacheConfig selects which responses are cached and which mutations invalidate them
+type ResponseCacheConfig struct {
+	Hints       map[string]CacheHint // Hints keyed by "Type" or "Type.field", taking precedence over schema hints
+	Invalidates map[string][]string  // Mutation field → types it changes besides the ones it returns
+}
+
+// cacheableQuery is a query whose response can be cached, with its combined hint
+type cacheableQuery struct {
+	key     string
+	maxAge  time.Duration
+	private bool
+	public  bool     // Cached for anonymous callers, so shared HTTP caches may keep it too
+	types   []string // Types in the response, whose mutations invalidate it
+}
+
+// cacheExtension is returned to clients in extensions.cache
+type cacheExtension struct {
+	Hit    bool `json:"hit"`
+	MaxAge int  `json:"maxAge"`
+	Age    int  `json:"age"`
+}
+
+// SetResponseCache enables caching of query responses with cache hints
+func (r *Router) SetResponseCache(store *cache.Store, config ResponseCacheConfig) {
+	r.cache = store
+	r.cacheConfig = config
+}
+
+// cacheHint returns the configured or schema hint of a field, or of a type when fieldName is empty
+func (r *Router) cacheHint(typeName, fieldName string) *CacheHint {
+	key := typeName
+	if fieldName != "" {
+		key += "." + fieldName
+	}
+	if hint, ok := r.cacheConfig.Hints[key]; ok {
+		return &hint
+	}
+	return r.SchemaManager.GetCacheHint(typeName, fieldName)
+}
+
+// cacheableQuery combines the hints of a query and builds its cache key for the caller
+// It returns nil for mutations, subscriptions and queries without a hint on every root field
+func (r *Router) cacheableQuery(req *http.Request, graphQLReq *GraphQLRequest,
+	doc *ast.QueryDocument, op *ast.OperationDefinition, caller *identity.Identity) *cacheableQuery {
+
+	if r.cache == nil || op.Operation != ast.Query {
+		return nil
+	}
+	// Without edge authentication the gateway cannot tell callers apart
+	if caller == nil && req.Header.Get("Authorization") != "" {
+		return nil
+	}
+
+	walker := &cacheWalker{router: r, doc: doc, variables: graphQLReq.Variables,
+		schema: r.SchemaManager.GetSchema(), types: make(map[string]bool), fragments: make(map[string]bool), maxAge: -1}
+	walker.walk(op.SelectionSet, typeName(walker.schema.Query), true)
+	if walker.maxAge <= 0 {
+		return nil
+	}
+
+	cached := &cacheableQuery{maxAge: walker.maxAge, private: walker.private, types: walker.typeNames()}
+	var scope string
+	switch {
+	case caller == nil && walker.private:
+		return nil
+	case caller == nil:
+		scope, cached.public = "public", true
+	case walker.private || caller.TenantID == 0:
+		scope = fmt.Sprintf("user:%d", caller.UserID)
+	default:
+		// Privacy rules filter results by role, so a tenant only shares responses between callers of one role
+		scope = fmt.Sprintf("tenant:%d:role:%s", caller.TenantID, caller.Role)
+	}
+
+	variables, _ := json.Marshal(graphQLReq.Variables)
+	sum := sha256.Sum256([]byte(graphQLReq.Query + "\x00" + graphQLReq.OperationName + "\x00" + string(variables)))
+	cached.key = scope + ":" + hex.EncodeToString(sum[:])
+	return cached
+}
+
+// serveCached writes a fresh cached response and reports whether there was one
+func (r *Router) serveCached(ctx context.Context, w http.ResponseWriter, cached *cacheableQuery) bool {
+	entry, err := r.cache.Get(ctx, cached.key)
+	if err != nil {
+		log.Printf("Skipping response cache: %v", err)
+		return false
+	}
+	if entry == nil {
+		return false
+	}
+
+	age := entry.Age()
+	setCacheHeaders(w, entry.MaxAge, age, cached.public)
+	w.WriteHeader(http.StatusOK)
+	w.Write(withExtension(entry.Body, "cache", cacheExtension{Hit: true, MaxAge: entry.MaxAge, Age: age}))
+	return true
+}
+
+// cacheResponse stores a successful response and writes it to the client
+// Responses with errors are passed through without being cached
+func (r *Router) cacheResponse(ctx context.Context, w http.ResponseWriter, cached *cacheableQuery, response *bufferedResponse) {
+	for key, values := range response.header {
+		w.Header()[key] = values
+	}
+
+	body := response.body.Bytes()
+	maxAge := int(cached.maxAge.Seconds())
+	if response.status == http.StatusOK && !hasErrors(body) {
+		entry := &cache.Entry{Body: body, MaxAge: maxAge, Private: cached.private, StoredAt: time.Now()}
+		if err := r.cache.Set(ctx, cached.key, entry, cached.types); err != nil {
+			log.Printf("Failed to cache response: %v", err)
+		} else {
+			setCacheHeaders(w, maxAge, 0, cached.public)
+			body = withExtension(body, "cache", cacheExtension{MaxAge: maxAge})
+		}
+	}
+
+	w.WriteHeader(response.status)
+	w.Write(body)
+}
+
+// invalidationTypes lists the types a mutation may change: the types it returns and the configured ones
+func (r *Router) invalidationTypes(doc *ast.QueryDocument, op *ast.OperationDefinition, variables map[string]interface{}) []string {
+	if r.cache == nil || op.Operation != ast.Mutation {
+		return nil
+	}
+
+	walker := &cacheWalker{router: r, doc: doc, variables: variables, schema: r.SchemaManager.GetSchema(),
+		types: make(map[string]bool), fragments: make(map[string]bool), typesOnly: true}
+	walker.walk(op.SelectionSet, typeName(walker.schema.Mutation), true)
+	for _, field := range walker.rootFields {
+		for _, name := range r.cacheConfig.Invalidates[field] {
+			walker.types[name] = true
+		}
+	}
+	return walker.typeNames()
+}
+
+// invalidateCache deletes the cached responses containing any of the types
+func (r *Router) invalidateCache(ctx context.Context, types []string) {
+	if r.cache == nil || len(types) == 0 {
+		return
+	}
+	removed, err := r.cache.Invalidate(ctx, types)
+	if err != nil {
+		log.Printf("Failed to invalidate cached responses of %s: %v", strings.Join(types, ", "), err)
+		return
+	}
+	if removed > 0 {
+		log.Printf("Invalidated %d cached responses of %s", removed, strings.Join(types, ", "))
+	}
+}
+
+// setCacheHeaders describes a cacheable response to HTTP caches
+func setCacheHeaders(w http.ResponseWriter, maxAge, age int, public bool) {
+	scope := "private"
+	if public {
+		scope = "public"
+	}
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d, %s", maxAge, scope))
+	w.Header().Set("Age", strconv.Itoa(age))
+}
+
+// hasErrors reports whether a GraphQL response carries errors or cannot be decoded
+func hasErrors(body []byte) bool {
+	var response struct {
+		Errors []json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return true
+	}
+	return len(response.Errors) > 0
+}
+
+// cacheWalker combines the cache hints of the fields of an operation and collects their types
+type cacheWalker struct {
+	router     *Router
+	schema     *ast.Schema
+	doc        *ast.QueryDocument
+	variables  map[string]interface{}
+	fragments  map[string]bool // Fragments being expanded, to stop on cycles
+	typesOnly  bool            // Only collect types, for mutations
+	maxAge     time.Duration   // Shortest max age so far, -1 before the first hint
+	private    bool
+	types      map[string]bool
+	rootFields []string
+}
+
+// walk applies the hints of a selection set
+// Root fields without a hint make the operation uncacheable, nested fields without one inherit their parent's
+func (c *cacheWalker) walk(selections ast.SelectionSet, parent string, root bool) {
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if !shouldInclude(sel.Directives, c.variables) || isIntrospectionField(sel.Name) || sel.Name == "__typename" {
+				continue
+			}
+			if root {
+				c.rootFields = append(c.rootFields, sel.Name)
+			}
+
+			childType := ""
+			if def := c.schema.Types[parent]; def != nil {
+				if fieldDef := def.Fields.ForName(sel.Name); fieldDef != nil {
+					childType = fieldDef.Type.Name()
+				}
+			}
+			if len(sel.SelectionSet) > 0 && childType != "" {
+				c.types[childType] = true
+			}
+
+			if !c.typesOnly {
+				hint := c.router.cacheHint(parent, sel.Name)
+				if hint == nil && len(sel.SelectionSet) > 0 {
+					hint = c.router.cacheHint(childType, "")
+				}
+				switch {
+				case hint != nil:
+					c.apply(*hint)
+				case root:
+					c.apply(CacheHint{})
+				}
+			}
+
+			c.walk(sel.SelectionSet, childType, false)
+
+		case *ast.InlineFragment:
+			if !shouldInclude(sel.Directives, c.variables) {
+				continue
+			}
+			typeCondition := parent
+			if sel.TypeCondition != "" {
+				typeCondition = sel.TypeCondition
+			}
+			if !root {
+				c.types[typeCondition] = true
+			}
+			c.walk(sel.SelectionSet, typeCondition, root)
+
+		case *ast.FragmentSpread:
+			fragment := c.doc.Fragments.ForName(sel.Name)
+			if fragment == nil || c.fragments[sel.Name] || !shouldInclude(sel.Directives, c.variables) {
+				continue
+			}
+			c.fragments[sel.Name] = true
+			if !root {
+				c.types[fragment.TypeCondition] = true
+			}
+			c.walk(fragment.SelectionSet, fragment.TypeCondition, root)
+			delete(c.fragments, sel.Name)
+		}
+	}
+}
+
+// apply lowers the max age to a hint's and keeps the private scope
+func (c *cacheWalker) apply(hint CacheHint) {
+	if c.maxAge < 0 || hint.MaxAge < c.maxAge {
+		c.maxAge = hint.MaxAge
+	}
+	c.private = c.private || hint.Private
+}
+
+// typeNames returns the collected types in sorted order
+func (c *cacheWalker) typeNames() []string {
+	names := make([]string, 0, len(c.types))
+	for name := range c.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// bufferedResponse holds a response until it is known whether it can be cached
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBufferedResponse() *bufferedResponse {
+	return &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	b.status = status
+}
+
+func (b *bufferedResponse) Write(data []byte) (int, error) {
+	return b.body.Write(data)
+}
+
+// ParseCacheHints reads hints written as "Query.Roles:60,Role:5m,Query.me:30:private"
+// Max ages are seconds or Go durations; the scope is public unless given as private
+func ParseCacheHints(spec string) map[string]CacheHint {
+	hints := make(map[string]CacheHint)
+	for _, entry := range strings.Split(spec, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) < 2 || parts[0] == "" {
+			continue
+		}
+
+		maxAge, err := time.ParseDuration(parts[1])
+		if seconds, atoiErr := strconv.Atoi(parts[1]); atoiErr == nil {
+			maxAge, err = time.Duration(seconds)*time.Second, nil
+		}
+		if err != nil {
+			continue
+		}
+		hints[parts[0]] = CacheHint{MaxAge: maxAge, Private: len(parts) > 2 && strings.EqualFold(parts[2], "private")}
+	}
+	return hints
+}
+
+// ParseInvalidations reads the types changed by mutations, written as "deleteRole:Role,assignRole:Role|User"
+func ParseInvalidations(spec string) map[string][]string {
+	invalidates := make(map[string][]string)
+	for _, entry := range strings.Split(spec, ",") {
+		field, types, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || field == "" {
+			continue
+		}
+		for _, name := range strings.Split(types, "|") {
+			if name = strings.TrimSpace(name); name != "" {
+				invalidates[field] = append(invalidates[field], name)
+			}
+		}
+	}
+	return invalidates
+}
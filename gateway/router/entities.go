@@ -0,0 +1,409 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+// Prefix of response keys added by the gateway to fetch entity keys; stripped before responding
+const gatewayKeyPrefix = "_gw_"
+
+// EntityInfo describes a federated entity type for cross-service resolution
+type EntityInfo struct {
+	KeyFields    []string          // Fields identifying an instance
+	OwnerURL     string            // Service that owns the base type
+	Resolver     string            // Batch lookup field on the owner, e.g. nodes(ids:)
+	FieldOwners  map[string]string // Field name → URL of the service that resolves it
+	EntitiesURLs map[string]bool   // Services exposing _entities(representations:)
+}
+
+// entityFetch fetches fields of federated entities from another service after its parent ran
+type entityFetch struct {
+	Path       []string               // Response keys from the parent object down to the entities
+	TypeName   string                 // Entity type whose fields are fetched
+	ServiceURL string                 // Service resolving the fields
+	KeyFields  []string               // Key fields sent to the service
+	Field      string                 // _entities or the owner's batch lookup field
+	Query      string                 // Printed fetch document
+	Variables  map[string]interface{} // Client variables referenced by the fetch
+	Children   []*entityFetch         // Fetches relative to the fetched entities
+}
+
+// entityPlanner rewrites selections so every field is requested from the service resolving it
+type entityPlanner struct {
+	router    *Router
+	doc       *ast.QueryDocument
+	op        *ast.OperationDefinition
+	variables map[string]interface{}
+	expanding map[string]bool // Fragments being inlined, to stop on cycles
+}
+
+// splitSelections keeps the fields resolved by serviceURL and turns the others into entity fetches
+// Fragment spreads are inlined so that the rewritten selections are self-contained
+func (p *entityPlanner) splitSelections(selections ast.SelectionSet, typeName, serviceURL string,
+	path []string) (ast.SelectionSet, []*entityFetch) {
+
+	var kept ast.SelectionSet
+	var fetches []*entityFetch
+	var pendingOrder []string
+	pending := make(map[string]ast.SelectionSet)
+
+	entity := p.router.SchemaManager.GetEntity(typeName)
+
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if entity != nil && !strings.HasPrefix(sel.Name, "__") {
+				owner := entity.FieldOwners[sel.Name]
+				if owner != "" && owner != serviceURL && !isKeyField(entity, sel.Name) {
+					if _, ok := pending[owner]; !ok {
+						pendingOrder = append(pendingOrder, owner)
+					}
+					pending[owner] = append(pending[owner], sel)
+					continue
+				}
+			}
+
+			copied := *sel
+			if len(sel.SelectionSet) > 0 {
+				childType := p.router.SchemaManager.GetFieldType(typeName, sel.Name)
+				childPath := append(append([]string{}, path...), sel.Alias)
+				childSelections, childFetches := p.splitSelections(sel.SelectionSet, childType, serviceURL, childPath)
+				copied.SelectionSet = childSelections
+				fetches = append(fetches, childFetches...)
+			}
+			kept = append(kept, &copied)
+
+		case *ast.InlineFragment:
+			condition := sel.TypeCondition
+			if condition == "" {
+				condition = typeName
+			}
+			childSelections, childFetches := p.splitSelections(sel.SelectionSet, condition, serviceURL, path)
+			kept = append(kept, &ast.InlineFragment{
+				TypeCondition: sel.TypeCondition,
+				Directives:    sel.Directives,
+				SelectionSet:  childSelections,
+			})
+			fetches = append(fetches, childFetches...)
+
+		case *ast.FragmentSpread:
+			fragment := p.doc.Fragments.ForName(sel.Name)
+			if fragment == nil {
+				continue
+			}
+			// A fragment spreading itself is left to the service, which rejects the cycle
+			if p.expanding[sel.Name] {
+				kept = append(kept, sel)
+				continue
+			}
+			p.expanding[sel.Name] = true
+			childSelections, childFetches := p.splitSelections(fragment.SelectionSet, fragment.TypeCondition, serviceURL, path)
+			delete(p.expanding, sel.Name)
+			kept = append(kept, &ast.InlineFragment{
+				TypeCondition: fragment.TypeCondition,
+				Directives:    sel.Directives,
+				SelectionSet:  childSelections,
+			})
+			fetches = append(fetches, childFetches...)
+		}
+	}
+
+	if len(pendingOrder) == 0 {
+		return kept, fetches
+	}
+
+	// Request the entity keys and type name so the fetched fields can be matched back
+	kept = append(kept, &ast.Field{Alias: gatewayKeyPrefix + "typename", Name: "__typename"})
+	for _, keyField := range entity.KeyFields {
+		kept = append(kept, &ast.Field{Alias: gatewayKeyPrefix + keyField, Name: keyField})
+	}
+
+	for _, owner := range pendingOrder {
+		fetch, err := p.buildFetch(entity, typeName, owner, pending[owner], path)
+		if err != nil {
+			log.Printf("Cannot resolve %s fields from %s: %v", typeName, owner, err)
+			continue
+		}
+		fetches = append(fetches, fetch)
+	}
+
+	return kept, fetches
+}
+
+// buildFetch creates the batched fetch of entity fields from the service resolving them
+func (p *entityPlanner) buildFetch(entity *EntityInfo, typeName, serviceURL string,
+	fields ast.SelectionSet, path []string) (*entityFetch, error) {
+
+	fetch := &entityFetch{
+		Path:       path,
+		TypeName:   typeName,
+		ServiceURL: serviceURL,
+		KeyFields:  entity.KeyFields,
+	}
+
+	// Prefer _entities, fall back to the owner's batch lookup by a single key
+	var argName string
+	var argType *ast.Type
+	switch {
+	case entity.EntitiesURLs[serviceURL]:
+		fetch.Field = "_entities"
+		argName = "representations"
+		argType = ast.NonNullListType(ast.NonNullNamedType("_Any", nil), nil)
+	case serviceURL == entity.OwnerURL && len(entity.KeyFields) == 1:
+		fetch.Field = entity.Resolver
+		argName = "ids"
+		argType = ast.NonNullListType(ast.NonNullNamedType("ID", nil), nil)
+	default:
+		return nil, fmt.Errorf("service does not expose _entities")
+	}
+
+	selections, children := p.splitSelections(fields, typeName, serviceURL, nil)
+	fetch.Children = children
+
+	usedVars := make(map[string]bool)
+	collectSelectionUsage(p.doc, selections, usedVars, make(map[string]bool))
+
+	op := &ast.OperationDefinition{
+		Operation: ast.Query,
+		VariableDefinitions: ast.VariableDefinitionList{
+			{Variable: gatewayKeyPrefix + argName, Type: argType},
+		},
+		SelectionSet: ast.SelectionSet{
+			&ast.Field{
+				Alias: fetch.Field,
+				Name:  fetch.Field,
+				Arguments: ast.ArgumentList{
+					{Name: argName, Value: &ast.Value{Kind: ast.Variable, Raw: gatewayKeyPrefix + argName}},
+				},
+				SelectionSet: ast.SelectionSet{
+					&ast.InlineFragment{TypeCondition: typeName, SelectionSet: selections},
+				},
+			},
+		},
+	}
+
+	fetch.Variables = make(map[string]interface{})
+	for _, def := range p.op.VariableDefinitions {
+		if usedVars[def.Variable] {
+			op.VariableDefinitions = append(op.VariableDefinitions, def)
+			if value, ok := p.variables[def.Variable]; ok {
+				fetch.Variables[def.Variable] = value
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf, formatter.WithIndent("  ")).FormatQueryDocument(&ast.QueryDocument{
+		Operations: ast.OperationList{op},
+	})
+	fetch.Query = buf.String()
+
+	return fetch, nil
+}
+
+// isKeyField reports whether a field is part of the entity key and thus known to every service
+func isKeyField(entity *EntityInfo, fieldName string) bool {
+	for _, keyField := range entity.KeyFields {
+		if keyField == fieldName {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveEntities runs entity fetches against the decoded objects and merges the results in place
+func (r *Router) resolveEntities(originalReq *http.Request, roots []interface{}, fetches []*entityFetch) []json.RawMessage {
+	type fetchResult struct {
+		targets map[string][]map[string]interface{}
+		entries []interface{}
+		order   []string
+		errors  []json.RawMessage
+	}
+
+	results := make([]*fetchResult, len(fetches))
+	var wg sync.WaitGroup
+
+	for i, fetch := range fetches {
+		// Group target objects by their key so each entity is requested once
+		result := &fetchResult{targets: make(map[string][]map[string]interface{})}
+		results[i] = result
+
+		var keys []interface{}
+		for _, root := range roots {
+			for _, obj := range objectsAtPath(root, fetch.Path) {
+				if typename, ok := obj[gatewayKeyPrefix+"typename"].(string); ok && typename != fetch.TypeName {
+					continue
+				}
+				representation, id, ok := entityRepresentation(obj, fetch)
+				if !ok {
+					continue
+				}
+				if _, seen := result.targets[id]; !seen {
+					result.order = append(result.order, id)
+					if fetch.Field == "_entities" {
+						keys = append(keys, representation)
+					} else {
+						keys = append(keys, representation[fetch.KeyFields[0]])
+					}
+				}
+				result.targets[id] = append(result.targets[id], obj)
+			}
+		}
+
+		if len(keys) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(fetch *entityFetch, result *fetchResult, keys []interface{}) {
+			defer wg.Done()
+			result.entries, result.errors = r.sendEntityFetch(originalReq, fetch, keys)
+		}(fetch, result, keys)
+	}
+	wg.Wait()
+
+	// Merge sequentially, then resolve fetches that depend on the merged entities
+	var errors []json.RawMessage
+	for i, fetch := range fetches {
+		result := results[i]
+		errors = append(errors, result.errors...)
+
+		var merged []interface{}
+		for j, id := range result.order {
+			if j >= len(result.entries) {
+				break
+			}
+			entry, ok := result.entries[j].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, obj := range result.targets[id] {
+				mergeObjects(obj, entry)
+				merged = append(merged, obj)
+			}
+		}
+
+		if len(fetch.Children) > 0 && len(merged) > 0 {
+			errors = append(errors, r.resolveEntities(originalReq, merged, fetch.Children)...)
+		}
+	}
+
+	return errors
+}
+
+// sendEntityFetch requests entity fields for the given keys and returns the entries in key order
+func (r *Router) sendEntityFetch(originalReq *http.Request, fetch *entityFetch, keys []interface{}) ([]interface{}, []json.RawMessage) {
+	variables := make(map[string]interface{}, len(fetch.Variables)+1)
+	for name, value := range fetch.Variables {
+		variables[name] = value
+	}
+	if fetch.Field == "_entities" {
+		variables[gatewayKeyPrefix+"representations"] = keys
+	} else {
+		variables[gatewayKeyPrefix+"ids"] = keys
+	}
+
+	resp, err := r.sendToService(originalReq, GraphQLRequest{Query: fetch.Query, Variables: variables}, fetch.ServiceURL, true)
+	if err != nil {
+		log.Printf("Error fetching %s entities from %s: %v", fetch.TypeName, fetch.ServiceURL, err)
+		errorJSON, _ := json.Marshal(map[string]interface{}{
+			"message": fmt.Sprintf("Service unavailable while resolving %s", fetch.TypeName),
+		})
+		return nil, []json.RawMessage{errorJSON}
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data   map[string][]interface{} `json:"data"`
+		Errors []json.RawMessage        `json:"errors"`
+	}
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&result); err != nil {
+		log.Printf("Invalid entity response from %s: %v", fetch.ServiceURL, err)
+		errorJSON, _ := json.Marshal(map[string]interface{}{
+			"message": fmt.Sprintf("Invalid response while resolving %s", fetch.TypeName),
+		})
+		return nil, []json.RawMessage{errorJSON}
+	}
+
+	log.Printf("Resolved %d %s entities from %s", len(keys), fetch.TypeName, fetch.ServiceURL)
+	return result.Data[fetch.Field], result.Errors
+}
+
+// entityRepresentation builds the representation of an entity object and a stable key for it
+func entityRepresentation(obj map[string]interface{}, fetch *entityFetch) (map[string]interface{}, string, bool) {
+	representation := map[string]interface{}{"__typename": fetch.TypeName}
+	for _, keyField := range fetch.KeyFields {
+		value, ok := obj[gatewayKeyPrefix+keyField]
+		if !ok || value == nil {
+			return nil, "", false
+		}
+		representation[keyField] = value
+	}
+
+	id, err := json.Marshal(representation)
+	if err != nil {
+		return nil, "", false
+	}
+	return representation, string(id), true
+}
+
+// objectsAtPath returns all objects reached by following response keys, flattening lists
+func objectsAtPath(value interface{}, path []string) []map[string]interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		var objects []map[string]interface{}
+		for _, item := range v {
+			objects = append(objects, objectsAtPath(item, path)...)
+		}
+		return objects
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return []map[string]interface{}{v}
+		}
+		return objectsAtPath(v[path[0]], path[1:])
+	default:
+		return nil
+	}
+}
+
+// mergeObjects deep-merges src into dst
+func mergeObjects(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcObj, srcIsObj := value.(map[string]interface{})
+		dstObj, dstIsObj := dst[key].(map[string]interface{})
+		if srcIsObj && dstIsObj {
+			mergeObjects(dstObj, srcObj)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// stripGatewayKeys removes the keys added by the gateway from a decoded response value
+func stripGatewayKeys(value interface{}) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			stripGatewayKeys(item)
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			if strings.HasPrefix(key, gatewayKeyPrefix) {
+				delete(v, key)
+				continue
+			}
+			stripGatewayKeys(item)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package router
+
+import (
+	"strings"
+	"testing"
+)
+
+// federatedSchema has posts resolved by the posts service, with the author of a post resolved by users
+func federatedSchema() *fakeSchema {
+	return &fakeSchema{
+		routes: map[string]string{"posts": "http://posts.invalid"},
+		entities: map[string]*EntityInfo{
+			"Post": {
+				KeyFields:    []string{"id"},
+				OwnerURL:     "http://posts.invalid",
+				FieldOwners:  map[string]string{"title": "http://posts.invalid", "author": "http://users.invalid"},
+				EntitiesURLs: map[string]bool{"http://users.invalid": true},
+			},
+		},
+		fieldTypes: map[string]string{"Query.posts": "Post", "Post.author": "User"},
+	}
+}
+
+func TestSplitSelectionsStopsOnFragmentCycles(t *testing.T) {
+	r := NewRouter(federatedSchema())
+	defer r.Close()
+
+	doc, op, err := parseOperation(`query { posts { ...P } } fragment P on Post { title author { name } ...P }`, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := r.planOperation(doc, op, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Steps) != 1 || len(plan.Steps[0].Fetches) != 1 {
+		t.Fatalf("expected one step with one entity fetch, got %+v", plan.Steps)
+	}
+	// The cycle is forwarded for the service to reject instead of being expanded
+	if query := plan.Steps[0].Query; !strings.Contains(query, "... P") {
+		t.Fatalf("expected the self spread to be kept, got %s", query)
+	}
+}
+
+func TestSplitSelectionsInlinesRepeatedFragments(t *testing.T) {
+	r := NewRouter(federatedSchema())
+	defer r.Close()
+
+	doc, op, err := parseOperation(`query { posts { ...P ...P } } fragment P on Post { author { name } }`, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := r.planOperation(doc, op, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query := plan.Steps[0].Query; strings.Contains(query, "... P") {
+		t.Fatalf("expected the fragment to be inlined, got %s", query)
+	}
+	if len(plan.Steps[0].Fetches) == 0 {
+		t.Fatal("expected the author to be fetched from users")
+	}
+}
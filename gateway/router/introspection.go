@@ -0,0 +1,631 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Root fields answered by the gateway from the merged schema
+const (
+	schemaField = "__schema"
+	typeField   = "__type"
+)
+
+// isIntrospectionField reports whether a root field is resolved by the introspection executor
+func isIntrospectionField(name string) bool {
+	return name == schemaField || name == typeField
+}
+
+// introspectionExecutor resolves __schema and __type selections against the merged schema
+type introspectionExecutor struct {
+	schema    *ast.Schema
+	doc       *ast.QueryDocument
+	variables map[string]interface{}
+	cycle     string // Fragment spreading itself, directly or through its fields, "" without cycles
+	errors    []json.RawMessage
+}
+
+// introspectionObject is a value of one of the introspection types (__Schema, __Type, ...)
+type introspectionObject interface {
+	// typeName returns the introspection type of the object, used for __typename and fragments
+	typeName() string
+
+	// resolve returns the value of a field as nil, a scalar, an object or a list of objects
+	resolve(e *introspectionExecutor, field *ast.Field) (interface{}, error)
+}
+
+// newIntrospectionExecutor creates an executor for one operation
+func newIntrospectionExecutor(schema *ast.Schema, doc *ast.QueryDocument, variables map[string]interface{}) *introspectionExecutor {
+	return &introspectionExecutor{schema: schema, doc: doc, variables: variables, cycle: fragmentCycle(doc)}
+}
+
+// fragmentCycle returns the name of a fragment of the document that spreads itself, or ""
+// Such a fragment would expand forever, as the merged schema has types referencing each other
+func fragmentCycle(doc *ast.QueryDocument) string {
+	const (
+		expanding = 1
+		done      = 2
+	)
+	state := make(map[string]int)
+
+	var visit func(name string) string
+	var visitSelections func(selections ast.SelectionSet) string
+	visit = func(name string) string {
+		switch state[name] {
+		case expanding:
+			return name
+		case done:
+			return ""
+		}
+		fragment := doc.Fragments.ForName(name)
+		if fragment == nil {
+			return ""
+		}
+		state[name] = expanding
+		cycle := visitSelections(fragment.SelectionSet)
+		state[name] = done
+		return cycle
+	}
+	visitSelections = func(selections ast.SelectionSet) string {
+		for _, selection := range selections {
+			var cycle string
+			switch sel := selection.(type) {
+			case *ast.Field:
+				cycle = visitSelections(sel.SelectionSet)
+			case *ast.InlineFragment:
+				cycle = visitSelections(sel.SelectionSet)
+			case *ast.FragmentSpread:
+				cycle = visit(sel.Name)
+			}
+			if cycle != "" {
+				return cycle
+			}
+		}
+		return ""
+	}
+
+	for _, fragment := range doc.Fragments {
+		if cycle := visit(fragment.Name); cycle != "" {
+			return cycle
+		}
+	}
+	return ""
+}
+
+// executeRootField resolves a root __schema or __type field
+func (e *introspectionExecutor) executeRootField(field *ast.Field) json.RawMessage {
+	path := []interface{}{field.Alias}
+
+	if e.cycle != "" {
+		e.addError(fmt.Sprintf("Cannot spread fragment %q within itself", e.cycle), path)
+		return json.RawMessage("null")
+	}
+
+	switch field.Name {
+	case schemaField:
+		return e.object(&schemaObject{}, field.SelectionSet, path)
+
+	case typeField:
+		name, _ := e.argument(field, "name").(string)
+		def := e.schema.Types[name]
+		if def == nil {
+			return json.RawMessage("null")
+		}
+		return e.object(&namedTypeObject{def: def}, field.SelectionSet, path)
+	}
+
+	e.addError(fmt.Sprintf("Cannot query field %q on the root type", field.Name), path)
+	return json.RawMessage("null")
+}
+
+// object resolves the selection set of an introspection object into an ordered JSON object
+func (e *introspectionExecutor) object(obj introspectionObject, selections ast.SelectionSet, path []interface{}) json.RawMessage {
+	data := newOrderedData()
+
+	for _, field := range e.collectFields(selections, obj.typeName()) {
+		fieldPath := append(append([]interface{}{}, path...), field.Alias)
+
+		if field.Name == "__typename" {
+			typename, _ := json.Marshal(obj.typeName())
+			data.Set(field.Alias, typename)
+			continue
+		}
+
+		value, err := obj.resolve(e, field)
+		if err != nil {
+			e.addError(err.Error(), fieldPath)
+			data.Set(field.Alias, json.RawMessage("null"))
+			continue
+		}
+		data.Set(field.Alias, e.complete(value, field, fieldPath))
+	}
+
+	encoded, err := data.MarshalJSON()
+	if err != nil {
+		e.addError("Failed to encode introspection result: "+err.Error(), path)
+		return json.RawMessage("null")
+	}
+	return encoded
+}
+
+// complete encodes a resolved value, resolving nested objects with the field's selection set
+func (e *introspectionExecutor) complete(value interface{}, field *ast.Field, path []interface{}) json.RawMessage {
+	switch v := value.(type) {
+	case nil:
+		return json.RawMessage("null")
+
+	case introspectionObject:
+		return e.object(v, field.SelectionSet, path)
+
+	case []introspectionObject:
+		items := make([]json.RawMessage, len(v))
+		for i, item := range v {
+			items[i] = e.object(item, field.SelectionSet, append(append([]interface{}{}, path...), i))
+		}
+		encoded, _ := json.Marshal(items)
+		return encoded
+
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			e.addError("Failed to encode introspection value: "+err.Error(), path)
+			return json.RawMessage("null")
+		}
+		return encoded
+	}
+}
+
+// collectFields expands fragments applying to the type and merges fields sharing a response key
+func (e *introspectionExecutor) collectFields(selections ast.SelectionSet, typeName string) []*ast.Field {
+	var fields []*ast.Field
+	byAlias := make(map[string]*ast.Field)
+	visited := make(map[string]bool) // Fragments already expanded into this selection set
+
+	var collect func(selections ast.SelectionSet)
+	collect = func(selections ast.SelectionSet) {
+		for _, selection := range selections {
+			switch sel := selection.(type) {
+			case *ast.Field:
+				if !shouldInclude(sel.Directives, e.variables) {
+					continue
+				}
+				alias := sel.Alias
+				if alias == "" {
+					alias = sel.Name
+				}
+				if existing, ok := byAlias[alias]; ok {
+					existing.SelectionSet = append(existing.SelectionSet, sel.SelectionSet...)
+					continue
+				}
+				copied := *sel
+				copied.Alias = alias
+				copied.SelectionSet = append(ast.SelectionSet{}, sel.SelectionSet...)
+				byAlias[alias] = &copied
+				fields = append(fields, &copied)
+
+			case *ast.InlineFragment:
+				if !shouldInclude(sel.Directives, e.variables) {
+					continue
+				}
+				if sel.TypeCondition == "" || sel.TypeCondition == typeName {
+					collect(sel.SelectionSet)
+				}
+
+			case *ast.FragmentSpread:
+				if visited[sel.Name] || !shouldInclude(sel.Directives, e.variables) {
+					continue
+				}
+				visited[sel.Name] = true
+				fragment := e.doc.Fragments.ForName(sel.Name)
+				if fragment != nil && fragment.TypeCondition == typeName {
+					collect(fragment.SelectionSet)
+				}
+			}
+		}
+	}
+	collect(selections)
+
+	return fields
+}
+
+// argument returns the value of a field argument with variables substituted, or nil
+func (e *introspectionExecutor) argument(field *ast.Field, name string) interface{} {
+	arg := field.Arguments.ForName(name)
+	if arg == nil || arg.Value == nil {
+		return nil
+	}
+	value, err := arg.Value.Value(e.variables)
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+// includeDeprecated reads the includeDeprecated argument, which defaults to false
+func (e *introspectionExecutor) includeDeprecated(field *ast.Field) bool {
+	include, _ := e.argument(field, "includeDeprecated").(bool)
+	return include
+}
+
+// addError records a GraphQL error at the given response path
+func (e *introspectionExecutor) addError(message string, path []interface{}) {
+	errorJSON, _ := json.Marshal(map[string]interface{}{
+		"message": message,
+		"path":    path,
+	})
+	e.errors = append(e.errors, errorJSON)
+}
+
+// typeRef wraps an AST type reference as a __Type, unwrapping NON_NULL and LIST one level at a time
+func (e *introspectionExecutor) typeRef(t *ast.Type) introspectionObject {
+	if t == nil {
+		return nil
+	}
+	if t.NonNull {
+		inner := *t
+		inner.NonNull = false
+		return &wrappedTypeObject{kind: "NON_NULL", ofType: &inner}
+	}
+	if t.Elem != nil {
+		return &wrappedTypeObject{kind: "LIST", ofType: t.Elem}
+	}
+	if def := e.schema.Types[t.NamedType]; def != nil {
+		return &namedTypeObject{def: def}
+	}
+	return &namedTypeObject{def: &ast.Definition{Kind: ast.Scalar, Name: t.NamedType}}
+}
+
+// definitionRef returns the __Type of a named definition, or nil
+func definitionRef(def *ast.Definition) interface{} {
+	if def == nil {
+		return nil
+	}
+	return &namedTypeObject{def: def}
+}
+
+// schemaObject resolves __Schema
+type schemaObject struct{}
+
+func (o *schemaObject) typeName() string { return "__Schema" }
+
+func (o *schemaObject) resolve(e *introspectionExecutor, field *ast.Field) (interface{}, error) {
+	switch field.Name {
+	case "description":
+		return optionalString(e.schema.Description), nil
+	case "types":
+		names := make([]string, 0, len(e.schema.Types))
+		for name := range e.schema.Types {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		types := make([]introspectionObject, len(names))
+		for i, name := range names {
+			types[i] = &namedTypeObject{def: e.schema.Types[name]}
+		}
+		return types, nil
+	case "queryType":
+		return definitionRef(e.schema.Query), nil
+	case "mutationType":
+		return definitionRef(e.schema.Mutation), nil
+	case "subscriptionType":
+		return definitionRef(e.schema.Subscription), nil
+	case "directives":
+		names := make([]string, 0, len(e.schema.Directives))
+		for name := range e.schema.Directives {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		directives := make([]introspectionObject, len(names))
+		for i, name := range names {
+			directives[i] = &directiveObject{def: e.schema.Directives[name]}
+		}
+		return directives, nil
+	}
+	return nil, unknownField(o, field)
+}
+
+// namedTypeObject resolves a __Type for a named type definition
+type namedTypeObject struct {
+	def *ast.Definition
+}
+
+func (o *namedTypeObject) typeName() string { return "__Type" }
+
+func (o *namedTypeObject) resolve(e *introspectionExecutor, field *ast.Field) (interface{}, error) {
+	def := o.def
+
+	switch field.Name {
+	case "kind":
+		return string(def.Kind), nil
+	case "name":
+		return def.Name, nil
+	case "description":
+		return optionalString(def.Description), nil
+	case "specifiedByURL", "specifiedByUrl":
+		return nil, nil
+	case "ofType":
+		return nil, nil
+	case "isOneOf":
+		if def.Kind != ast.InputObject {
+			return nil, nil
+		}
+		return def.Directives.ForName("oneOf") != nil, nil
+
+	case "fields":
+		if def.Kind != ast.Object && def.Kind != ast.Interface {
+			return nil, nil
+		}
+		includeDeprecated := e.includeDeprecated(field)
+		fields := []introspectionObject{}
+		for _, fieldDef := range def.Fields {
+			if isIntrospectionField(fieldDef.Name) || (!includeDeprecated && isDeprecated(fieldDef.Directives)) {
+				continue
+			}
+			fields = append(fields, &fieldObject{def: fieldDef})
+		}
+		return fields, nil
+
+	case "interfaces":
+		if def.Kind != ast.Object && def.Kind != ast.Interface {
+			return nil, nil
+		}
+		interfaces := []introspectionObject{}
+		for _, name := range def.Interfaces {
+			interfaces = append(interfaces, e.typeRef(ast.NamedType(name, nil)))
+		}
+		return interfaces, nil
+
+	case "possibleTypes":
+		if def.Kind != ast.Interface && def.Kind != ast.Union {
+			return nil, nil
+		}
+		possible := e.schema.GetPossibleTypes(def)
+		names := make([]string, 0, len(possible))
+		for _, possibleDef := range possible {
+			names = append(names, possibleDef.Name)
+		}
+		sort.Strings(names)
+		types := make([]introspectionObject, len(names))
+		for i, name := range names {
+			types[i] = e.typeRef(ast.NamedType(name, nil))
+		}
+		return types, nil
+
+	case "enumValues":
+		if def.Kind != ast.Enum {
+			return nil, nil
+		}
+		includeDeprecated := e.includeDeprecated(field)
+		values := []introspectionObject{}
+		for _, value := range def.EnumValues {
+			if !includeDeprecated && isDeprecated(value.Directives) {
+				continue
+			}
+			values = append(values, &enumValueObject{def: value})
+		}
+		return values, nil
+
+	case "inputFields":
+		if def.Kind != ast.InputObject {
+			return nil, nil
+		}
+		includeDeprecated := e.includeDeprecated(field)
+		inputs := []introspectionObject{}
+		for _, fieldDef := range def.Fields {
+			if !includeDeprecated && isDeprecated(fieldDef.Directives) {
+				continue
+			}
+			inputs = append(inputs, &inputValueObject{
+				name:         fieldDef.Name,
+				description:  fieldDef.Description,
+				typ:          fieldDef.Type,
+				defaultValue: fieldDef.DefaultValue,
+				directives:   fieldDef.Directives,
+			})
+		}
+		return inputs, nil
+	}
+	return nil, unknownField(o, field)
+}
+
+// wrappedTypeObject resolves a NON_NULL or LIST __Type
+type wrappedTypeObject struct {
+	kind   string
+	ofType *ast.Type
+}
+
+func (o *wrappedTypeObject) typeName() string { return "__Type" }
+
+func (o *wrappedTypeObject) resolve(e *introspectionExecutor, field *ast.Field) (interface{}, error) {
+	switch field.Name {
+	case "kind":
+		return o.kind, nil
+	case "ofType":
+		return e.typeRef(o.ofType), nil
+	case "name", "description", "specifiedByURL", "specifiedByUrl", "fields", "interfaces",
+		"possibleTypes", "enumValues", "inputFields", "isOneOf":
+		return nil, nil
+	}
+	return nil, unknownField(o, field)
+}
+
+// fieldObject resolves __Field
+type fieldObject struct {
+	def *ast.FieldDefinition
+}
+
+func (o *fieldObject) typeName() string { return "__Field" }
+
+func (o *fieldObject) resolve(e *introspectionExecutor, field *ast.Field) (interface{}, error) {
+	switch field.Name {
+	case "name":
+		return o.def.Name, nil
+	case "description":
+		return optionalString(o.def.Description), nil
+	case "args":
+		return argumentObjects(o.def.Arguments, e.includeDeprecated(field)), nil
+	case "type":
+		return e.typeRef(o.def.Type), nil
+	case "isDeprecated":
+		return isDeprecated(o.def.Directives), nil
+	case "deprecationReason":
+		return deprecationReason(o.def.Directives), nil
+	}
+	return nil, unknownField(o, field)
+}
+
+// inputValueObject resolves __InputValue for arguments and input object fields
+type inputValueObject struct {
+	name         string
+	description  string
+	typ          *ast.Type
+	defaultValue *ast.Value
+	directives   ast.DirectiveList
+}
+
+func (o *inputValueObject) typeName() string { return "__InputValue" }
+
+func (o *inputValueObject) resolve(e *introspectionExecutor, field *ast.Field) (interface{}, error) {
+	switch field.Name {
+	case "name":
+		return o.name, nil
+	case "description":
+		return optionalString(o.description), nil
+	case "type":
+		return e.typeRef(o.typ), nil
+	case "defaultValue":
+		if o.defaultValue == nil {
+			return nil, nil
+		}
+		return o.defaultValue.String(), nil
+	case "isDeprecated":
+		return isDeprecated(o.directives), nil
+	case "deprecationReason":
+		return deprecationReason(o.directives), nil
+	}
+	return nil, unknownField(o, field)
+}
+
+// enumValueObject resolves __EnumValue
+type enumValueObject struct {
+	def *ast.EnumValueDefinition
+}
+
+func (o *enumValueObject) typeName() string { return "__EnumValue" }
+
+func (o *enumValueObject) resolve(e *introspectionExecutor, field *ast.Field) (interface{}, error) {
+	switch field.Name {
+	case "name":
+		return o.def.Name, nil
+	case "description":
+		return optionalString(o.def.Description), nil
+	case "isDeprecated":
+		return isDeprecated(o.def.Directives), nil
+	case "deprecationReason":
+		return deprecationReason(o.def.Directives), nil
+	}
+	return nil, unknownField(o, field)
+}
+
+// directiveObject resolves __Directive
+type directiveObject struct {
+	def *ast.DirectiveDefinition
+}
+
+func (o *directiveObject) typeName() string { return "__Directive" }
+
+func (o *directiveObject) resolve(e *introspectionExecutor, field *ast.Field) (interface{}, error) {
+	switch field.Name {
+	case "name":
+		return o.def.Name, nil
+	case "description":
+		return optionalString(o.def.Description), nil
+	case "locations":
+		locations := make([]string, len(o.def.Locations))
+		for i, location := range o.def.Locations {
+			locations[i] = string(location)
+		}
+		return locations, nil
+	case "args":
+		return argumentObjects(o.def.Arguments, e.includeDeprecated(field)), nil
+	case "isRepeatable":
+		return o.def.IsRepeatable, nil
+	}
+	return nil, unknownField(o, field)
+}
+
+// argumentObjects converts argument definitions into __InputValue objects
+func argumentObjects(args ast.ArgumentDefinitionList, includeDeprecated bool) []introspectionObject {
+	objects := []introspectionObject{}
+	for _, arg := range args {
+		if !includeDeprecated && isDeprecated(arg.Directives) {
+			continue
+		}
+		objects = append(objects, &inputValueObject{
+			name:         arg.Name,
+			description:  arg.Description,
+			typ:          arg.Type,
+			defaultValue: arg.DefaultValue,
+			directives:   arg.Directives,
+		})
+	}
+	return objects
+}
+
+// isDeprecated reports whether a schema member carries @deprecated
+func isDeprecated(directives ast.DirectiveList) bool {
+	return directives.ForName("deprecated") != nil
+}
+
+// deprecationReason returns the reason of @deprecated, or nil when the member is not deprecated
+func deprecationReason(directives ast.DirectiveList) interface{} {
+	directive := directives.ForName("deprecated")
+	if directive == nil {
+		return nil
+	}
+	if reason := directive.Arguments.ForName("reason"); reason != nil && reason.Value != nil {
+		return reason.Value.Raw
+	}
+	return "No longer supported"
+}
+
+// optionalString returns nil for an empty string so that it is encoded as null
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// unknownField reports a field that does not exist on an introspection type
+func unknownField(obj introspectionObject, field *ast.Field) error {
+	return fmt.Errorf("Cannot query field %q on type %q", field.Name, obj.typeName())
+}
+
+// shouldInclude evaluates @skip and @include on a selection
+func shouldInclude(directives ast.DirectiveList, variables map[string]interface{}) bool {
+	if skip := directives.ForName("skip"); skip != nil && directiveCondition(skip, variables) {
+		return false
+	}
+	if include := directives.ForName("include"); include != nil && !directiveCondition(include, variables) {
+		return false
+	}
+	return true
+}
+
+// directiveCondition returns the boolean "if" argument of @skip or @include
+func directiveCondition(directive *ast.Directive, variables map[string]interface{}) bool {
+	arg := directive.Arguments.ForName("if")
+	if arg == nil || arg.Value == nil {
+		return false
+	}
+	value, err := arg.Value.Value(variables)
+	if err != nil {
+		return false
+	}
+	condition, _ := value.(bool)
+	return condition
+}
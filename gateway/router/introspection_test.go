@@ -0,0 +1,63 @@
+package router
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// executeIntrospection runs the root introspection field of a query against a small schema
+func executeIntrospection(t *testing.T, query string) (string, *introspectionExecutor) {
+	t.Helper()
+
+	schema, err := gqlparser.LoadSchema(&ast.Source{Input: `
+		type Query { posts: [Post!]! }
+		type Post { id: ID! title: String author: User }
+		type User { id: ID! posts: [Post!]! }
+	`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, op, err := parseOperation(query, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executor := newIntrospectionExecutor(schema, doc, nil)
+	return string(executor.executeRootField(op.SelectionSet[0].(*ast.Field))), executor
+}
+
+func TestIntrospectionRejectsFragmentCycles(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"self", `{ __schema { ...S } } fragment S on __Schema { ...S }`},
+		{"mutual", `{ __schema { ...S } } fragment S on __Schema { ...R } fragment R on __Schema { ...S }`},
+		{"nested", `{ __type(name: "Post") { ...T } } fragment T on __Type { name fields { type { ...T } } }`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, executor := executeIntrospection(t, tt.query)
+			if data != "null" {
+				t.Fatalf("expected null, got %s", data)
+			}
+			if len(executor.errors) != 1 || !strings.Contains(string(executor.errors[0]), "within itself") {
+				t.Fatalf("expected a fragment cycle error, got %s", executor.errors)
+			}
+		})
+	}
+}
+
+func TestIntrospectionExpandsFragments(t *testing.T) {
+	data, executor := executeIntrospection(t,
+		`{ __type(name: "Post") { ...T ...T fields { name } } } fragment T on __Type { name kind }`)
+	if len(executor.errors) > 0 {
+		t.Fatalf("unexpected errors: %s", executor.errors)
+	}
+	if !strings.HasPrefix(data, `{"name":"Post","kind":"OBJECT","fields":[{"name":"id"}`) {
+		t.Fatalf("unexpected result %s", data)
+	}
+}
@@ -0,0 +1,131 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/saurabh/entgo-microservices/gateway/persisted"
+)
+
+// persistedQueryExtension is the extensions.persistedQuery object sent by APQ clients
+type persistedQueryExtension struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+// persistedQueryError rejects a request that could not be resolved to a permitted query
+type persistedQueryError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *persistedQueryError) Error() string {
+	return e.message
+}
+
+var (
+	// errPersistedQueryNotFound asks an APQ client to send the query text with its hash
+	errPersistedQueryNotFound = &persistedQueryError{http.StatusOK, "PERSISTED_QUERY_NOT_FOUND", "PersistedQueryNotFound"}
+	// errPersistedQueryNotSupported tells an APQ client to stop sending hashes alone
+	errPersistedQueryNotSupported = &persistedQueryError{http.StatusBadRequest, "PERSISTED_QUERY_NOT_SUPPORTED", "PersistedQueryNotSupported"}
+	errPersistedQueryMismatch     = &persistedQueryError{http.StatusBadRequest, "PERSISTED_QUERY_HASH_MISMATCH", "provided sha does not match query"}
+	errPersistedQueryNotAllowed   = &persistedQueryError{http.StatusForbidden, "PERSISTED_QUERY_NOT_ALLOWED", "Operation is not in the persisted query allow-list"}
+	errPersistedQueryUnavailable  = &persistedQueryError{http.StatusServiceUnavailable, "PERSISTED_QUERY_UNAVAILABLE", "Persisted queries are temporarily unavailable"}
+)
+
+// SetPersistedQueries enables automatic persisted queries backed by store
+// With allowListOnly, only operations registered through a manifest are executed and APQ registration is disabled
+func (r *Router) SetPersistedQueries(store *persisted.Store, allowListOnly bool) {
+	r.persisted = store
+	r.allowListOnly = allowListOnly
+}
+
+// resolvePersistedQuery fills in the query of a request sent as a hash and enforces the allow-list
+// The persistedQuery extension is removed so services receive a plain request
+func (r *Router) resolvePersistedQuery(ctx context.Context, graphQLReq *GraphQLRequest) error {
+	var extension *persistedQueryExtension
+	if raw, ok := graphQLReq.Extensions["persistedQuery"]; ok {
+		extension = &persistedQueryExtension{}
+		if err := json.Unmarshal(raw, extension); err != nil || extension.Version != 1 || extension.Sha256Hash == "" {
+			return errPersistedQueryNotSupported
+		}
+		delete(graphQLReq.Extensions, "persistedQuery")
+	}
+
+	if r.persisted == nil {
+		if extension != nil && graphQLReq.Query == "" {
+			return errPersistedQueryNotSupported
+		}
+		return nil
+	}
+
+	registered := false
+	hash := ""
+	if extension != nil {
+		hash = strings.ToLower(extension.Sha256Hash)
+
+		if graphQLReq.Query == "" {
+			query, found, err := r.persisted.Lookup(ctx, hash, r.allowListOnly)
+			if err != nil {
+				log.Printf("Persisted query lookup failed: %v", err)
+				if r.allowListOnly {
+					return errPersistedQueryUnavailable
+				}
+				return errPersistedQueryNotFound
+			}
+			if !found {
+				if r.allowListOnly {
+					return errPersistedQueryNotAllowed
+				}
+				return errPersistedQueryNotFound
+			}
+			graphQLReq.Query = query
+			registered = r.allowListOnly
+		} else {
+			if persisted.Hash(graphQLReq.Query) != hash {
+				return errPersistedQueryMismatch
+			}
+			if !r.allowListOnly {
+				if err := r.persisted.Save(ctx, hash, graphQLReq.Query); err != nil {
+					log.Printf("Failed to save persisted query %s: %v", hash, err)
+				}
+			}
+		}
+	}
+
+	if !r.allowListOnly || registered {
+		return nil
+	}
+
+	// Query text is accepted in allow-list mode only when it is a registered operation
+	if hash == "" {
+		hash = persisted.Hash(graphQLReq.Query)
+	}
+	ok, err := r.persisted.Registered(ctx, hash)
+	if err != nil {
+		log.Printf("Persisted query lookup failed: %v", err)
+		return errPersistedQueryUnavailable
+	}
+	if !ok {
+		return errPersistedQueryNotAllowed
+	}
+	return nil
+}
+
+// writePersistedQueryError writes the GraphQL error APQ clients look for
+func writePersistedQueryError(w http.ResponseWriter, err *persistedQueryError) {
+	body, _ := json.Marshal(map[string]interface{}{
+		"errors": []map[string]interface{}{
+			{
+				"message":    err.message,
+				"extensions": map[string]interface{}{"code": err.code},
+			},
+		},
+	})
+	w.WriteHeader(err.status)
+	w.Write(body)
+}
@@ -0,0 +1,297 @@
+package router
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// queryPlan describes how a single client operation is split across services
+type queryPlan struct {
+	Operation ast.Operation // query, mutation or subscription
+	Steps     []*planStep   // One step per owning service in order of first appearance; for mutations, one per run of consecutive fields of a service
+	RootKeys  []string      // Response keys of all root fields, in document order
+	Typename  []string      // Response keys of root __typename fields answered by the gateway
+	Fields    []string      // Names of the root fields sent to services, once per response key
+
+	Introspection map[string]*ast.Field  // Root __schema and __type fields by response key, answered by the gateway
+	Document      *ast.QueryDocument     // Parsed client document, used to expand fragments of local fields
+	Variables     map[string]interface{} // Client variables
+}
+
+// planStep is a sub-operation that is sent to exactly one service
+type planStep struct {
+	ServiceURL string                 // Service that owns every root field of this step
+	Query      string                 // Printed sub-document for the service
+	Variables  map[string]interface{} // Only the variables referenced by the sub-document
+	Keys       []string               // Response keys produced by this step
+	Fetches    []*entityFetch         // Entity fields resolved by other services after this step
+}
+
+// parseOperation parses a query document and selects the operation to execute
+func parseOperation(query, operationName string) (*ast.QueryDocument, *ast.OperationDefinition, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, nil, fmt.Errorf("no operation found in document")
+	}
+
+	if operationName == "" {
+		if len(doc.Operations) > 1 {
+			return nil, nil, fmt.Errorf("operationName is required when the document contains multiple operations")
+		}
+		return doc, doc.Operations[0], nil
+	}
+
+	op := doc.Operations.ForName(operationName)
+	if op == nil {
+		return nil, nil, fmt.Errorf("unknown operation named %q", operationName)
+	}
+
+	return doc, op, nil
+}
+
+// planOperation splits the root fields of an operation by owning service
+func (r *Router) planOperation(doc *ast.QueryDocument, op *ast.OperationDefinition,
+	variables map[string]interface{}) (*queryPlan, error) {
+
+	fields, err := flattenRootFields(doc, op.SelectionSet, nil, make(map[string]bool))
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &queryPlan{
+		Operation:     op.Operation,
+		Introspection: make(map[string]*ast.Field),
+		Document:      doc,
+		Variables:     variables,
+	}
+	stepsByURL := make(map[string]*planStep)
+	fieldsByStep := make(map[*planStep]ast.SelectionSet)
+	seenKeys := make(map[string]bool)
+
+	for _, field := range fields {
+		// Fields left out by @skip or @include are not planned, so they are absent rather than null
+		if !shouldInclude(field.Directives, variables) {
+			continue
+		}
+
+		if !seenKeys[field.Alias] {
+			plan.RootKeys = append(plan.RootKeys, field.Alias)
+		}
+
+		// __schema and __type are executed by the gateway against the merged schema
+		if isIntrospectionField(field.Name) {
+			if op.Operation != ast.Query {
+				return nil, fmt.Errorf("Cannot query field '%s' on type '%s'", field.Name, rootTypeName(op.Operation))
+			}
+			if existing, ok := plan.Introspection[field.Alias]; ok {
+				merged := *existing
+				merged.SelectionSet = append(append(ast.SelectionSet{}, existing.SelectionSet...), field.SelectionSet...)
+				plan.Introspection[field.Alias] = &merged
+			} else {
+				plan.Introspection[field.Alias] = field
+			}
+			seenKeys[field.Alias] = true
+			continue
+		}
+
+		// __typename on the root type is answered by the gateway itself
+		if field.Name == "__typename" {
+			if !seenKeys[field.Alias] {
+				plan.Typename = append(plan.Typename, field.Alias)
+			}
+			seenKeys[field.Alias] = true
+			continue
+		}
+
+		serviceURL := r.SchemaManager.GetRouteForOperation(field.Name)
+		if serviceURL == "" {
+			return nil, fmt.Errorf("Operation '%s' not supported by any service", field.Name)
+		}
+
+		// Mutation fields run serially in document order, so a mutation step only groups consecutive
+		// fields of one service; query fields are grouped per service
+		var step *planStep
+		if op.Operation == ast.Mutation {
+			if last := len(plan.Steps) - 1; last >= 0 && plan.Steps[last].ServiceURL == serviceURL {
+				step = plan.Steps[last]
+			}
+		} else {
+			step = stepsByURL[serviceURL]
+		}
+		if step == nil {
+			step = &planStep{ServiceURL: serviceURL}
+			stepsByURL[serviceURL] = step
+			plan.Steps = append(plan.Steps, step)
+		}
+
+		// Every response key is a separate execution of its field, so aliases repeat a field
+		if !seenKeys[field.Alias] {
+			step.Keys = append(step.Keys, field.Alias)
+			plan.Fields = append(plan.Fields, field.Name)
+		}
+		seenKeys[field.Alias] = true
+		fieldsByStep[step] = append(fieldsByStep[step], field)
+	}
+
+	// Move fields of federated entities to the services resolving them
+	if r.SchemaManager.HasEntities() {
+		planner := &entityPlanner{router: r, doc: doc, op: op, variables: variables, expanding: make(map[string]bool)}
+		for _, step := range plan.Steps {
+			fieldsByStep[step], step.Fetches = planner.splitSelections(fieldsByStep[step], rootTypeName(op.Operation), step.ServiceURL, nil)
+		}
+	}
+
+	// Build one sub-document per service with only the fragments and variables it uses
+	for _, step := range plan.Steps {
+		query, usedVars := buildSubDocument(doc, op, fieldsByStep[step])
+		step.Query = query
+		step.Variables = make(map[string]interface{}, len(usedVars))
+		for name := range usedVars {
+			if value, ok := variables[name]; ok {
+				step.Variables[name] = value
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// flattenRootFields expands fragment spreads and inline fragments on the root selection set
+// Directives on an expanded fragment are pushed down onto each of its fields
+// expanding holds the fragments being expanded, a fragment spreading itself is rejected
+func flattenRootFields(doc *ast.QueryDocument, selections ast.SelectionSet, inherited ast.DirectiveList,
+	expanding map[string]bool) ([]*ast.Field, error) {
+	var fields []*ast.Field
+
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if len(inherited) == 0 {
+				fields = append(fields, sel)
+				continue
+			}
+			copied := *sel
+			copied.Directives = append(append(ast.DirectiveList{}, inherited...), sel.Directives...)
+			fields = append(fields, &copied)
+
+		case *ast.InlineFragment:
+			expanded, err := flattenRootFields(doc, sel.SelectionSet, append(append(ast.DirectiveList{}, inherited...), sel.Directives...), expanding)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, expanded...)
+
+		case *ast.FragmentSpread:
+			fragment := doc.Fragments.ForName(sel.Name)
+			if fragment == nil {
+				return nil, fmt.Errorf("unknown fragment %q", sel.Name)
+			}
+			if expanding[sel.Name] {
+				return nil, fmt.Errorf("Cannot spread fragment %q within itself", sel.Name)
+			}
+			expanding[sel.Name] = true
+			expanded, err := flattenRootFields(doc, fragment.SelectionSet, append(append(ast.DirectiveList{}, inherited...), sel.Directives...), expanding)
+			delete(expanding, sel.Name)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, expanded...)
+		}
+	}
+
+	return fields, nil
+}
+
+// buildSubDocument prints an operation containing only the given root fields
+// It returns the printed document and the set of variables it references
+func buildSubDocument(doc *ast.QueryDocument, op *ast.OperationDefinition, fields ast.SelectionSet) (string, map[string]bool) {
+	usedVars := make(map[string]bool)
+	usedFragments := make(map[string]bool)
+
+	collectDirectiveVariables(op.Directives, usedVars)
+	collectSelectionUsage(doc, fields, usedVars, usedFragments)
+
+	subOp := &ast.OperationDefinition{
+		Operation:    op.Operation,
+		Name:         op.Name,
+		Directives:   op.Directives,
+		SelectionSet: fields,
+	}
+	for _, def := range op.VariableDefinitions {
+		if usedVars[def.Variable] {
+			subOp.VariableDefinitions = append(subOp.VariableDefinitions, def)
+		}
+	}
+
+	subDoc := &ast.QueryDocument{Operations: ast.OperationList{subOp}}
+	for _, fragment := range doc.Fragments {
+		if usedFragments[fragment.Name] {
+			subDoc.Fragments = append(subDoc.Fragments, fragment)
+		}
+	}
+
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf, formatter.WithIndent("  ")).FormatQueryDocument(subDoc)
+
+	return buf.String(), usedVars
+}
+
+// collectSelectionUsage records variables and fragments referenced by a selection set
+func collectSelectionUsage(doc *ast.QueryDocument, selections ast.SelectionSet, usedVars, usedFragments map[string]bool) {
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			for _, arg := range sel.Arguments {
+				collectValueVariables(arg.Value, usedVars)
+			}
+			collectDirectiveVariables(sel.Directives, usedVars)
+			collectSelectionUsage(doc, sel.SelectionSet, usedVars, usedFragments)
+
+		case *ast.InlineFragment:
+			collectDirectiveVariables(sel.Directives, usedVars)
+			collectSelectionUsage(doc, sel.SelectionSet, usedVars, usedFragments)
+
+		case *ast.FragmentSpread:
+			collectDirectiveVariables(sel.Directives, usedVars)
+			if usedFragments[sel.Name] {
+				continue
+			}
+			usedFragments[sel.Name] = true
+			if fragment := doc.Fragments.ForName(sel.Name); fragment != nil {
+				collectDirectiveVariables(fragment.Directives, usedVars)
+				collectSelectionUsage(doc, fragment.SelectionSet, usedVars, usedFragments)
+			}
+		}
+	}
+}
+
+// collectDirectiveVariables records variables referenced by directive arguments
+func collectDirectiveVariables(directives ast.DirectiveList, usedVars map[string]bool) {
+	for _, directive := range directives {
+		for _, arg := range directive.Arguments {
+			collectValueVariables(arg.Value, usedVars)
+		}
+	}
+}
+
+// collectValueVariables records variables referenced anywhere inside a value literal
+func collectValueVariables(value *ast.Value, usedVars map[string]bool) {
+	if value == nil {
+		return
+	}
+	if value.Kind == ast.Variable {
+		usedVars[value.Raw] = true
+		return
+	}
+	for _, child := range value.Children {
+		collectValueVariables(child.Value, usedVars)
+	}
+}
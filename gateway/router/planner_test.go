@@ -0,0 +1,104 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// fakeSchema routes root fields to fixed services and knows the entities and field types it is given
+type fakeSchema struct {
+	routes     map[string]string      // Root field → service URL
+	entities   map[string]*EntityInfo // Entity type → federation details
+	fieldTypes map[string]string      // Type.field → named type
+	schema     *ast.Schema
+}
+
+func (f *fakeSchema) GetRouteForOperation(rootField string) string { return f.routes[rootField] }
+func (f *fakeSchema) GetMergedSchema() interface{}                 { return nil }
+func (f *fakeSchema) GetSchema() *ast.Schema                       { return f.schema }
+func (f *fakeSchema) HasEntities() bool                            { return len(f.entities) > 0 }
+func (f *fakeSchema) GetEntity(typeName string) *EntityInfo        { return f.entities[typeName] }
+func (f *fakeSchema) GetCacheHint(typeName, fieldName string) *CacheHint {
+	return nil
+}
+func (f *fakeSchema) GetFieldType(typeName, fieldName string) string {
+	return f.fieldTypes[typeName+"."+fieldName]
+}
+
+// postQuery sends a GraphQL query to the router and returns the recorded response
+func postQuery(t *testing.T, r *Router, query string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(GraphQLRequest{Query: query})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.HandleRequest(w, req)
+	return w
+}
+
+func TestPlanRejectsFragmentCycles(t *testing.T) {
+	r := NewRouter(&fakeSchema{routes: map[string]string{"posts": "http://posts.invalid"}})
+	defer r.Close()
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"self", `query { ...A } fragment A on Query { ...A }`},
+		{"mutual", `query { ...A } fragment A on Query { posts ...B } fragment B on Query { ...A }`},
+		{"inline", `query { ...A } fragment A on Query { ... on Query { ...A } }`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postQuery(t, r, tt.query)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", w.Code, w.Body)
+			}
+			if !strings.Contains(w.Body.String(), "within itself") {
+				t.Fatalf("expected a fragment cycle error, got %s", w.Body)
+			}
+		})
+	}
+}
+
+func TestPlanExpandsRepeatedFragments(t *testing.T) {
+	r := NewRouter(&fakeSchema{routes: map[string]string{"posts": "http://posts.invalid", "users": "http://users.invalid"}})
+	defer r.Close()
+
+	doc, op, err := parseOperation(`query { ...A ...A users } fragment A on Query { posts }`, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := r.planOperation(doc, op, nil)
+	if err != nil {
+		t.Fatalf("a fragment spread twice is not a cycle: %v", err)
+	}
+	if len(plan.Steps) != 2 || strings.Join(plan.RootKeys, ",") != "posts,users" {
+		t.Fatalf("unexpected plan: %d steps, keys %v", len(plan.Steps), plan.RootKeys)
+	}
+}
+
+func TestPlanListsAliasedFieldsPerExecution(t *testing.T) {
+	r := NewRouter(&fakeSchema{routes: map[string]string{"login": "http://auth.invalid"}})
+	defer r.Close()
+
+	doc, op, err := parseOperation(`mutation { a: login b: login login ...L } fragment L on Mutation { login }`, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := r.planOperation(doc, op, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// login and the fragment's login share a response key and run once
+	if got := strings.Join(plan.Fields, ","); got != "login,login,login" {
+		t.Fatalf("expected login once per response key, got %s", got)
+	}
+}
@@ -0,0 +1,87 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/saurabh/entgo-microservices/gateway/ratelimit"
+	"github.com/saurabh/entgo-microservices/pkg/identity"
+)
+
+// Rate limit response headers
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRetryAfter         = "Retry-After"
+)
+
+// SetRateLimiter enables rate limiting of GraphQL and REST requests
+func (r *Router) SetRateLimiter(limiter *ratelimit.Limiter) {
+	r.limiter = limiter
+}
+
+// rateLimited counts a request against the caller's limits and writes a 429 response when one is exceeded
+func (r *Router) rateLimited(w http.ResponseWriter, req *http.Request, caller *identity.Identity, operations []string) bool {
+	decision := r.checkRateLimit(req, caller, operations)
+	if decision == nil {
+		return false
+	}
+	if decision.Remaining >= 0 {
+		w.Header().Set(headerRateLimitLimit, strconv.Itoa(decision.Limit))
+		w.Header().Set(headerRateLimitRemaining, strconv.Itoa(decision.Remaining))
+	}
+	if decision.Allowed {
+		return false
+	}
+
+	retryAfter := int(decision.RetryAfter.Seconds())
+	body, _ := json.Marshal(map[string]interface{}{
+		"errors": []map[string]interface{}{
+			{
+				"message": rateLimitMessage(decision),
+				"extensions": map[string]interface{}{
+					"code":       "RATE_LIMITED",
+					"retryAfter": retryAfter,
+				},
+			},
+		},
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(headerRateLimitRemaining, "0")
+	w.Header().Set(headerRetryAfter, strconv.Itoa(retryAfter))
+	w.WriteHeader(http.StatusTooManyRequests)
+	w.Write(body)
+	return true
+}
+
+// checkRateLimit counts a request against the caller's limits
+// It returns nil when rate limiting is disabled or Redis cannot be reached, letting the request through
+func (r *Router) checkRateLimit(req *http.Request, caller *identity.Identity, operations []string) *ratelimit.Decision {
+	if r.limiter == nil {
+		return nil
+	}
+
+	subject := ratelimit.Subject{IP: r.limiter.ClientIP(req), Operations: operations}
+	if caller != nil {
+		subject.UserID = caller.UserID
+		subject.TenantID = caller.TenantID
+	}
+
+	decision, err := r.limiter.Allow(req.Context(), subject)
+	if err != nil {
+		log.Printf("Skipping rate limit: %v", err)
+		return nil
+	}
+	if !decision.Allowed {
+		log.Printf("Rate limit %s exceeded, retry after %s", decision.Rule, decision.RetryAfter)
+	}
+	return decision
+}
+
+// rateLimitMessage is the error message of a rejected request
+func rateLimitMessage(decision *ratelimit.Decision) string {
+	return fmt.Sprintf("Rate limit exceeded, retry in %d seconds", int(decision.RetryAfter.Seconds()))
+}
@@ -0,0 +1,270 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	pathpkg "path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/saurabh/entgo-microservices/pkg/identity"
+)
+
+// restPrefix is the default route, /api/v1/{service}/{path}, forwarded to {path} on the service
+const restPrefix = "/api/v1/"
+
+// RESTRoute forwards requests under a path prefix to a service
+type RESTRoute struct {
+	Prefix        string   // Path prefix, matched on segment boundaries
+	Service       string   // Name of the service the route forwards to
+	Methods       []string // Allowed methods, empty allows any
+	Rewrite       string   // Replaces the prefix in the forwarded path, empty forwards the path unchanged
+	Authenticated bool     // Requests need a valid token
+	Roles         []string // Requests need one of these roles
+}
+
+// restService is the REST base URL of a service and the GraphQL URL its client is keyed by
+type restService struct {
+	graphQLURL string
+	baseURL    *url.URL
+}
+
+// restTarget is where a REST request is forwarded
+type restTarget struct {
+	route    *RESTRoute // nil for the default route
+	service  restService
+	path     string
+	covering []*RESTRoute // For the default route, the routes forwarding to the same service path
+}
+
+// SetRESTRoutes sets the services reachable through /api/v1/{service}/ and the declarative routes
+// services maps service names to their GraphQL URLs; the REST base URL is the GraphQL URL without /graphql
+func (r *Router) SetRESTRoutes(services map[string]string, routes []RESTRoute) error {
+	restServices := make(map[string]restService, len(services))
+	for name, graphQLURL := range services {
+		baseURL, err := url.Parse(graphQLURL)
+		if err != nil {
+			return fmt.Errorf("invalid URL of service %s: %w", name, err)
+		}
+		baseURL.Path = strings.TrimSuffix(strings.TrimSuffix(baseURL.Path, "/"), "/graphql")
+		restServices[name] = restService{graphQLURL: graphQLURL, baseURL: baseURL}
+	}
+
+	for _, route := range routes {
+		if _, ok := restServices[route.Service]; !ok {
+			return fmt.Errorf("route %s forwards to unknown service %s", route.Prefix, route.Service)
+		}
+	}
+
+	// Longest prefixes first, so specific routes win over general ones
+	sorted := append([]RESTRoute(nil), routes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].Prefix) > len(sorted[j].Prefix)
+	})
+
+	r.restServices = restServices
+	r.restRoutes = sorted
+	return nil
+}
+
+// HandleRESTRequest processes incoming REST API requests and forwards them to appropriate services
+func (r *Router) HandleRESTRequest(w http.ResponseWriter, req *http.Request) {
+	// Validate the token once and forward the trusted identity instead of client headers
+	caller, err := r.authenticate(req.Context(), req.Header)
+	if err != nil {
+		log.Printf("Rejected REST request: %v", err)
+		http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+		return
+	}
+
+	target, status, message := r.resolveREST(req)
+	if target == nil {
+		log.Printf("No REST route for %s %s: %s", req.Method, req.URL.Path, message)
+		if status == http.StatusMethodNotAllowed {
+			w.Header().Set("Allow", strings.Join(r.allowedMethods(req.URL.Path), ", "))
+		}
+		http.Error(w, message, status)
+		return
+	}
+
+	// The default route reaches the service paths of declarative routes too, so it is held to their auth rules
+	rules := target.covering
+	if target.route != nil {
+		rules = []*RESTRoute{target.route}
+	}
+	for _, route := range rules {
+		if status, message := authorizeRoute(route, caller); status != 0 {
+			log.Printf("Rejected REST request %s %s: %s", req.Method, req.URL.Path, message)
+			http.Error(w, message, status)
+			return
+		}
+	}
+
+	if r.rateLimited(w, req, caller, nil) {
+		return
+	}
+
+	r.forwardRESTRequest(w, req, target)
+}
+
+// resolveREST finds the route of a request and the path to forward it to
+// On failure it returns the status and message to answer with
+func (r *Router) resolveREST(req *http.Request) (*restTarget, int, string) {
+	path := req.URL.Path
+
+	prefixMatched := false
+	for i := range r.restRoutes {
+		route := &r.restRoutes[i]
+		if !matchesPrefix(path, route.Prefix) {
+			continue
+		}
+		prefixMatched = true
+		if !allowsMethod(route, req.Method) {
+			continue
+		}
+
+		forwarded := path
+		if route.Rewrite != "" {
+			forwarded = strings.TrimSuffix(route.Rewrite, "/") + strings.TrimPrefix(path, strings.TrimSuffix(route.Prefix, "/"))
+		}
+		return &restTarget{route: route, service: r.restServices[route.Service], path: forwarded}, 0, ""
+	}
+	if prefixMatched {
+		return nil, http.StatusMethodNotAllowed, "Method not allowed"
+	}
+
+	// Default route: /api/v1/{service}/{path}
+	rest, ok := strings.CutPrefix(path, restPrefix)
+	if !ok {
+		return nil, http.StatusNotFound, "Not found"
+	}
+	serviceName, remaining, _ := strings.Cut(rest, "/")
+	service, ok := r.restServices[serviceName]
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Sprintf("Unknown service: %s", serviceName)
+	}
+	forwarded := "/" + remaining
+	return &restTarget{service: service, path: forwarded, covering: r.coveringRoutes(serviceName, forwarded)}, 0, ""
+}
+
+// coveringRoutes returns the declarative routes of a service that forward to a service path
+func (r *Router) coveringRoutes(serviceName, servicePath string) []*RESTRoute {
+	cleaned := pathpkg.Clean(servicePath)
+
+	var covering []*RESTRoute
+	for i := range r.restRoutes {
+		route := &r.restRoutes[i]
+		if route.Service != serviceName {
+			continue
+		}
+		forwardedPrefix := route.Prefix
+		if route.Rewrite != "" {
+			forwardedPrefix = route.Rewrite
+		}
+		if matchesPrefix(cleaned, forwardedPrefix) || matchesPrefix(servicePath, forwardedPrefix) {
+			covering = append(covering, route)
+		}
+	}
+	return covering
+}
+
+// allowedMethods lists the methods of the routes matching a path, for the Allow header
+func (r *Router) allowedMethods(path string) []string {
+	var methods []string
+	for _, route := range r.restRoutes {
+		if matchesPrefix(path, route.Prefix) {
+			methods = append(methods, route.Methods...)
+		}
+	}
+	return methods
+}
+
+// matchesPrefix reports whether a path is the prefix or lies below it
+func matchesPrefix(path, prefix string) bool {
+	if strings.HasSuffix(prefix, "/") {
+		return strings.HasPrefix(path, prefix)
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// allowsMethod reports whether a route accepts a method
+func allowsMethod(route *RESTRoute, method string) bool {
+	if len(route.Methods) == 0 {
+		return true
+	}
+	for _, allowed := range route.Methods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+// authorizeRoute checks a route's auth requirements, returning 401 or 403 and a message when they are not met
+func authorizeRoute(route *RESTRoute, caller *identity.Identity) (int, string) {
+	if !route.Authenticated && len(route.Roles) == 0 {
+		return 0, ""
+	}
+	if caller == nil {
+		return http.StatusUnauthorized, "Authentication required"
+	}
+	if len(route.Roles) == 0 {
+		return 0, ""
+	}
+	for _, role := range route.Roles {
+		if caller.Role == role {
+			return 0, ""
+		}
+	}
+	return http.StatusForbidden, "Forbidden"
+}
+
+// forwardRESTRequest proxies a REST request to its service
+// Bodies are streamed both ways, hop-by-hop headers are dropped and WebSocket upgrades are tunnelled
+func (r *Router) forwardRESTRequest(w http.ResponseWriter, req *http.Request, target *restTarget) {
+	client := r.clients.get(target.service.graphQLURL)
+	targetURL := *target.service.baseURL
+	targetURL.Path = targetURL.Path + target.path
+	targetURL.RawPath = ""
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.Out.URL.Scheme = targetURL.Scheme
+			pr.Out.URL.Host = targetURL.Host
+			pr.Out.URL.Path = targetURL.Path
+			pr.Out.URL.RawPath = ""
+			pr.Out.Host = ""
+			pr.SetXForwarded()
+		},
+		Transport:     client,
+		FlushInterval: -1, // Stream responses such as server-sent events as they arrive
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			if errors.Is(err, errCircuitOpen) {
+				log.Printf("Rejected REST request to %s: %v", targetURL.String(), err)
+				http.Error(w, "Service temporarily unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			log.Printf("Error forwarding request to %s: %v", targetURL.String(), err)
+			http.Error(w, "Service Unavailable", http.StatusBadGateway)
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			log.Printf("REST request forwarded to %s, status: %d", targetURL.String(), resp.StatusCode)
+			return nil
+		},
+	}
+
+	// The server's timeouts would cut off a tunnelled WebSocket connection
+	if websocket.IsWebSocketUpgrade(req) {
+		controller := http.NewResponseController(w)
+		controller.SetReadDeadline(time.Time{})
+		controller.SetWriteDeadline(time.Time{})
+	}
+
+	proxy.ServeHTTP(w, req)
+}
@@ -0,0 +1,75 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saurabh/entgo-microservices/pkg/identity"
+)
+
+// fakeAuthenticator accepts the bearer tokens it knows
+type fakeAuthenticator map[string]*identity.Identity
+
+func (f fakeAuthenticator) Authenticate(ctx context.Context, authorization string) (*identity.Identity, string, error) {
+	if authorization == "" {
+		return nil, "", nil
+	}
+	caller, ok := f[authorization]
+	if !ok {
+		return nil, "", errors.New("unknown token")
+	}
+	return caller, "", nil
+}
+
+func TestDefaultRESTRouteAppliesRouteAuth(t *testing.T) {
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer service.Close()
+
+	r := NewRouter(&fakeSchema{})
+	defer r.Close()
+	r.SetAuthenticator(fakeAuthenticator{
+		"Bearer admin": {UserID: 1, Role: "admin"},
+		"Bearer user":  {UserID: 2, Role: "user"},
+	})
+	err := r.SetRESTRoutes(map[string]string{"auth": service.URL + "/graphql"}, []RESTRoute{
+		{Prefix: "/api/admin/users", Service: "auth", Rewrite: "/admin/users", Roles: []string{"admin"}},
+		{Prefix: "/api/health", Service: "auth", Methods: []string{"GET"}, Rewrite: "/health"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		token  string
+		status int
+	}{
+		{"route without token", "/api/admin/users", "", http.StatusUnauthorized},
+		{"route as admin", "/api/admin/users", "Bearer admin", http.StatusOK},
+		{"default route without token", "/api/v1/auth/admin/users", "", http.StatusUnauthorized},
+		{"default route below the path", "/api/v1/auth/admin/users/2", "Bearer user", http.StatusForbidden},
+		{"default route with dot segments", "/api/v1/auth/x/../admin/users", "Bearer user", http.StatusForbidden},
+		{"default route as admin", "/api/v1/auth/admin/users", "Bearer admin", http.StatusOK},
+		{"default route to a public path", "/api/v1/auth/health", "", http.StatusOK},
+		{"default route to an uncovered path", "/api/v1/auth/admin/usersx", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+			w := httptest.NewRecorder()
+			r.HandleRESTRequest(w, req)
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body)
+			}
+		})
+	}
+}
@@ -3,21 +3,85 @@ package router
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
 	"io"
 	"log"
 	"net/http"
-	"regexp"
-	"strings"
-	"time"
+	"sync"
+
+	"github.com/saurabh/entgo-microservices/gateway/cache"
+	"github.com/saurabh/entgo-microservices/gateway/persisted"
+	"github.com/saurabh/entgo-microservices/gateway/ratelimit"
+	"github.com/saurabh/entgo-microservices/pkg/identity"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
 // GraphQLRequest represents a client's GraphQL request
 type GraphQLRequest struct {
-	Query         string                 `json:"query"`                   // GraphQL query/mutation string
-	Variables     map[string]interface{} `json:"variables"`               // Query variables
-	OperationName string                 `json:"operationName,omitempty"` // Optional operation name
+	Query         string                     `json:"query"`                   // GraphQL query/mutation string
+	Variables     map[string]interface{}     `json:"variables"`               // Query variables
+	OperationName string                     `json:"operationName,omitempty"` // Optional operation name
+	Extensions    map[string]json.RawMessage `json:"extensions,omitempty"`    // Protocol extensions such as persistedQuery
+}
+
+// GraphQLResponse is a response assembled by the gateway from one or more services
+type GraphQLResponse struct {
+	Data       *orderedData               `json:"data"`                 // Merged root fields in document order
+	Errors     []json.RawMessage          `json:"errors,omitempty"`     // Errors from all services
+	Extensions map[string]json.RawMessage `json:"extensions,omitempty"` // Merged extensions
+}
+
+// serviceResponse is the decoded response of a single service
+type serviceResponse struct {
+	Data       map[string]json.RawMessage `json:"data"`
+	Errors     []json.RawMessage          `json:"errors,omitempty"`
+	Extensions map[string]json.RawMessage `json:"extensions,omitempty"`
+}
+
+// orderedData is a JSON object that keeps its keys in insertion order
+type orderedData struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+// newOrderedData creates an empty ordered JSON object
+func newOrderedData() *orderedData {
+	return &orderedData{values: make(map[string]json.RawMessage)}
+}
+
+// Set adds or replaces a key, keeping the position of the first insertion
+func (d *orderedData) Set(key string, value json.RawMessage) {
+	if _, exists := d.values[key]; !exists {
+		d.keys = append(d.keys, key)
+	}
+	d.values[key] = value
+}
+
+// MarshalJSON writes the object with keys in insertion order
+func (d *orderedData) MarshalJSON() ([]byte, error) {
+	if d == nil {
+		return []byte("null"), nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range d.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyJSON)
+		buf.WriteByte(':')
+		buf.Write(d.values[key])
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
 }
 
 // SchemaManager interface defines methods needed from the schema manager
@@ -25,22 +89,58 @@ type SchemaManager interface {
 	// GetRouteForOperation returns the service URL for a given operation field name
 	GetRouteForOperation(rootField string) string
 
-	// GetMergedSchema returns the merged schema for introspection queries
+	// GetMergedSchema returns the merged schema as an introspection result
 	GetMergedSchema() interface{}
+
+	// GetSchema returns the merged schema the gateway executes introspection queries against
+	GetSchema() *ast.Schema
+
+	// HasEntities reports whether any service declared a federated entity
+	HasEntities() bool
+
+	// GetEntity returns federation details of an entity type, or nil for plain types
+	GetEntity(typeName string) *EntityInfo
+
+	// GetFieldType returns the named type of a field in the merged schema
+	GetFieldType(typeName, fieldName string) string
+
+	// GetCacheHint returns the @cacheControl hint of a field, or of a type when fieldName is empty
+	GetCacheHint(typeName, fieldName string) *CacheHint
 }
 
 // Router handles GraphQL request routing to microservices
 type Router struct {
-	SchemaManager SchemaManager // Schema manager for routing and introspection
+	SchemaManager SchemaManager          // Schema manager for routing and introspection
+	upstreams     *upstreamPool          // Pooled WebSocket connections for subscriptions
+	clients       *serviceClients        // Pooled HTTP clients and circuit breakers per service
+	auth          Authenticator          // Validates tokens at the edge, nil leaves validation to the services
+	limiter       *ratelimit.Limiter     // Per user, tenant, IP and operation limits, nil disables rate limiting
+	limits        *QueryLimits           // Depth, alias and cost limits, nil disables query analysis
+	persisted     *persisted.Store       // Persisted query store, nil disables APQ
+	allowListOnly bool                   // Only execute operations registered through a manifest
+	cache         *cache.Store           // Cached query responses, nil disables response caching
+	cacheConfig   ResponseCacheConfig    // Configured cache hints and mutation invalidations
+	maxBatchSize  int                    // Operations accepted in one batched request, 0 disables batching
+	uploadLimits  UploadLimits           // Size limits of multipart file uploads
+	restServices  map[string]restService // Services reachable through /api/v1/{service}/
+	restRoutes    []RESTRoute            // Declarative REST routes, longest prefix first
 }
 
 // NewRouter creates a new router with schema manager
 func NewRouter(manager SchemaManager) *Router {
 	return &Router{
 		SchemaManager: manager,
+		upstreams:     newUpstreamPool(),
+		clients:       newServiceClients(),
 	}
 }
 
+// Close releases the upstream subscription connections held by the router
+func (r *Router) Close() {
+	r.upstreams.closeAll()
+	r.clients.closeAll()
+}
+
 // HandleRequest processes incoming GraphQL requests
 func (r *Router) HandleRequest(w http.ResponseWriter, req *http.Request) {
 	// Set comprehensive CORS headers for external tools like Apollo Studio
@@ -58,167 +158,401 @@ func (r *Router) HandleRequest(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	// Check if content type is application/json
-	contentType := req.Header.Get("Content-Type")
-	if !strings.Contains(contentType, "application/json") {
-		log.Printf("Invalid content type: %s", contentType)
-		errorJSON, _ := json.Marshal(map[string]interface{}{
-			"errors": []map[string]interface{}{
-				{"message": "Content-Type must be application/json"},
-			},
-		})
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write(errorJSON)
+	// Validate the token once and forward the trusted identity instead of client headers
+	caller, err := r.authenticate(req.Context(), req.Header)
+	if err != nil {
+		log.Printf("Rejected GraphQL request: %v", err)
+		writeGraphQLError(w, http.StatusUnauthorized, "Invalid or expired token")
+		return
+	}
+
+	// GraphQL multipart uploads stream their files to the owning service
+	if isMultipartUpload(req) {
+		r.serveUpload(w, req, caller)
 		return
 	}
 
-	// Parse the GraphQL request
-	var graphQLReq GraphQLRequest
-	if err := json.NewDecoder(req.Body).Decode(&graphQLReq); err != nil {
+	// One operation, a batch of operations, or a query in the URL of a GET request
+	requests, batched, err := r.readRequests(req)
+	if err != nil {
 		log.Printf("Error parsing request: %v", err)
-		errorJSON, _ := json.Marshal(map[string]interface{}{
-			"errors": []map[string]interface{}{
-				{"message": "Invalid GraphQL request: " + err.Error()},
-			},
-		})
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write(errorJSON)
+		writeGraphQLError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Handle introspection queries (schema information) with special care
-	if isIntrospectionQuery(graphQLReq.Query) {
-		log.Printf("Handling introspection query: %s", graphQLReq.OperationName)
-		fmt.Println("📡 Introspection request received")
-
-		// Get merged schema
-		schema := r.SchemaManager.GetMergedSchema()
-
-		// Write response
-		if err := json.NewEncoder(w).Encode(schema); err != nil {
-			log.Printf("Error encoding schema response: %v", err)
-			errorJSON, _ := json.Marshal(map[string]interface{}{
-				"errors": []map[string]interface{}{
-					{"message": "Internal server error: Failed to encode response"},
-				},
-			})
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write(errorJSON)
-		}
+	if batched {
+		r.serveBatch(w, req, requests, caller)
 		return
 	}
+	r.serveOperation(w, req, requests[0], caller)
+}
 
-	// Extract the root operation field
-	rootField := extractRootField(graphQLReq.Query)
-	if rootField == "" {
-		log.Printf("Could not determine root operation field from query: %s", graphQLReq.Query)
-		errorJSON, _ := json.Marshal(map[string]interface{}{
-			"errors": []map[string]interface{}{
-				{"message": "Unable to parse GraphQL query - could not identify operation"},
-			},
-		})
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write(errorJSON)
+// preparedOperation is an operation that passed every check and is ready to execute
+type preparedOperation struct {
+	request GraphQLRequest
+	doc     *ast.QueryDocument
+	op      *ast.OperationDefinition
+	plan    *queryPlan
+	cost    *queryCost
+}
+
+// serveOperation plans, checks and executes one operation of a request
+func (r *Router) serveOperation(w http.ResponseWriter, req *http.Request, graphQLReq GraphQLRequest, caller *identity.Identity) {
+	prepared := r.prepareOperation(w, req, graphQLReq, caller)
+	if prepared == nil {
 		return
 	}
 
-	// Find which service should handle this operation
-	serviceURL := r.SchemaManager.GetRouteForOperation(rootField)
-	if serviceURL == "" {
-		log.Printf("No service found for operation: %s", rootField)
-		errorJSON, _ := json.Marshal(map[string]interface{}{
-			"errors": []map[string]interface{}{
-				{"message": fmt.Sprintf("Operation '%s' not supported by any service", rootField)},
-			},
-		})
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write(errorJSON)
+	// Queries with cache hints on every root field are answered from Redis while fresh
+	cached := r.cacheableQuery(req, &prepared.request, prepared.doc, prepared.op, caller)
+	if cached != nil {
+		if r.serveCached(req.Context(), w, cached) {
+			return
+		}
+		response := newBufferedResponse()
+		r.execute(response, req, prepared.request, prepared.plan, prepared.cost)
+		r.cacheResponse(req.Context(), w, cached, response)
 		return
 	}
 
-	// Forward the request to the appropriate service
-	r.forwardRequest(w, req, graphQLReq, serviceURL)
+	invalidates := r.invalidationTypes(prepared.doc, prepared.op, prepared.request.Variables)
+	r.execute(w, req, prepared.request, prepared.plan, prepared.cost)
+	r.invalidateCache(req.Context(), invalidates)
 }
 
-// forwardRequest sends the GraphQL request to the target service
-func (r *Router) forwardRequest(w http.ResponseWriter, originalReq *http.Request,
-	graphQLReq GraphQLRequest, serviceURL string) {
+// prepareOperation resolves, parses, plans and checks one operation
+// It writes the error response and returns nil when the operation is rejected
+func (r *Router) prepareOperation(w http.ResponseWriter, req *http.Request, graphQLReq GraphQLRequest, caller *identity.Identity) *preparedOperation {
+	// Look up queries sent as a hash and reject operations outside the allow-list
+	var persistedErr *persistedQueryError
+	if err := r.resolvePersistedQuery(req.Context(), &graphQLReq); errors.As(err, &persistedErr) {
+		if persistedErr != errPersistedQueryNotFound {
+			log.Printf("Rejected persisted query: %v", err)
+		}
+		writePersistedQueryError(w, persistedErr)
+		return nil
+	}
 
-	// Marshal request for forwarding
-	requestBody, err := json.Marshal(graphQLReq)
+	// Parse the document and pick the operation to execute
+	doc, op, err := parseOperation(graphQLReq.Query, graphQLReq.OperationName)
 	if err != nil {
-		log.Printf("Error marshaling request: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
+		log.Printf("Could not parse GraphQL query: %v", err)
+		writeGraphQLError(w, http.StatusBadRequest, "Unable to parse GraphQL query: "+err.Error())
+		return nil
+	}
+
+	// GET requests only read, so caches and link prefetching can never run a mutation
+	if req.Method == http.MethodGet && op.Operation != ast.Query {
+		w.Header().Set("Allow", http.MethodPost)
+		writeGraphQLError(w, http.StatusMethodNotAllowed, "Only queries can be sent with GET, use POST for "+string(op.Operation)+"s")
+		return nil
 	}
 
-	// Create request to the target service
-	req, err := http.NewRequest("POST", serviceURL, bytes.NewBuffer(requestBody))
+	// Split the root fields by the service that owns them
+	plan, err := r.planOperation(doc, op, graphQLReq.Variables)
 	if err != nil {
-		log.Printf("Error creating service request: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		log.Printf("Could not plan operation: %v", err)
+		writeGraphQLError(w, http.StatusBadRequest, err.Error())
+		return nil
+	}
+
+	// Reject operations over the depth, alias or cost limits of the caller
+	cost, err := r.analyzeOperation(doc, op, graphQLReq.Variables, caller)
+	var limitErr *queryLimitError
+	if errors.As(err, &limitErr) {
+		log.Printf("Rejected operation: %v", err)
+		writeQueryLimitError(w, limitErr)
+		return nil
+	}
+
+	if r.rateLimited(w, req, caller, plan.Fields) {
+		return nil
+	}
+
+	if plan.Operation == ast.Subscription && len(plan.Steps) > 1 {
+		writeGraphQLError(w, http.StatusBadRequest, "Subscriptions must select fields from a single service")
+		return nil
+	}
+
+	return &preparedOperation{request: graphQLReq, doc: doc, op: op, plan: plan, cost: cost}
+}
+
+// execute answers a planned operation, forwarding it to its only service or merging the results of several
+func (r *Router) execute(w http.ResponseWriter, req *http.Request, graphQLReq GraphQLRequest, plan *queryPlan, cost *queryCost) {
+	// A single owning service receives the original request untouched
+	if len(plan.Steps) == 1 && len(plan.Steps[0].Fetches) == 0 && len(plan.Introspection) == 0 {
+		r.forwardRequest(w, req, graphQLReq, plan.Steps[0].ServiceURL, plan.Operation == ast.Query, cost)
 		return
 	}
 
-	// Copy relevant headers
-	req.Header.Set("Content-Type", "application/json")
-	copyHeaders(originalReq.Header, req.Header)
+	// Fan out to every owning service and merge the results
+	response := r.executePlan(req, plan)
+	if cost != nil {
+		if response.Extensions == nil {
+			response.Extensions = make(map[string]json.RawMessage)
+		}
+		response.Extensions["cost"], _ = json.Marshal(cost)
+	}
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Error encoding merged response: %v", err)
+	}
+}
 
-	// Send the request with extended timeout and transport settings
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-		Transport: &http.Transport{
-			MaxResponseHeaderBytes: 10 * 1024 * 1024, // 10MB for headers
-			ResponseHeaderTimeout:  30 * time.Second,
-		},
+// forwardRequest sends the GraphQL request to the target service
+// The computed cost of the operation, if any, is added to the response extensions
+func (r *Router) forwardRequest(w http.ResponseWriter, originalReq *http.Request,
+	graphQLReq GraphQLRequest, serviceURL string, idempotent bool, cost *queryCost) {
+
+	resp, err := r.sendToService(originalReq, graphQLReq, serviceURL, idempotent)
+	if errors.Is(err, errCircuitOpen) {
+		log.Printf("Rejected request to %s: %v", serviceURL, err)
+		writeGraphQLError(w, http.StatusServiceUnavailable, "Service temporarily unavailable")
+		return
 	}
-	resp, err := client.Do(req)
 	if err != nil {
 		log.Printf("Error forwarding to service %s: %v", serviceURL, err)
-		http.Error(w, "Service unavailable", http.StatusBadGateway)
+		writeGraphQLError(w, http.StatusBadGateway, "Service unavailable")
 		return
 	}
 	defer resp.Body.Close()
 
+	relayResponse(w, resp, serviceURL, cost)
+}
+
+// relayResponse writes a service's response to the client, adding the cost of the operation if any
+func relayResponse(w http.ResponseWriter, resp *http.Response, serviceURL string, cost *queryCost) {
 	// Return the service response
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(resp.StatusCode)
-	io.Copy(w, resp.Body)
+	if cost != nil {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			log.Printf("Error reading response from %s: %v", serviceURL, err)
+			writeGraphQLError(w, http.StatusBadGateway, "Service unavailable")
+			return
+		}
+		w.WriteHeader(resp.StatusCode)
+		w.Write(withExtension(body, "cost", cost))
+	} else {
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, resp.Body)
+	}
 
 	log.Printf("Forwarded request to %s, status: %d", serviceURL, resp.StatusCode)
 }
 
-// copyHeaders copies HTTP headers except Host
-func copyHeaders(src, dst http.Header) {
-	for key, values := range src {
-		if key == "Host" {
+// sendToService posts a GraphQL request to a service on behalf of the original client request
+// Idempotent requests (queries) are retried on connection errors and gateway errors, mutations never are
+func (r *Router) sendToService(originalReq *http.Request, graphQLReq GraphQLRequest, serviceURL string, idempotent bool) (*http.Response, error) {
+	// Marshal request for forwarding
+	requestBody, err := json.Marshal(graphQLReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	ctx := originalReq.Context()
+	newRequest := func() (*http.Request, error) {
+		// Create request to the target service
+		req, err := http.NewRequestWithContext(ctx, "POST", serviceURL, bytes.NewReader(requestBody))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create service request: %w", err)
+		}
+
+		// Copy relevant headers
+		copyHeaders(originalReq.Header, req.Header)
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	}
+
+	// Send through the service's pooled client, timeouts and circuit breaker
+	return r.clients.get(serviceURL).do(ctx, newRequest, idempotent)
+}
+
+// executePlan runs every step of a query plan and merges the results in document order
+// Query steps run concurrently, mutation steps run one after another as the spec requires
+func (r *Router) executePlan(originalReq *http.Request, plan *queryPlan) *GraphQLResponse {
+	results := make([]*serviceResponse, len(plan.Steps))
+
+	if plan.Operation == ast.Mutation {
+		for i, step := range plan.Steps {
+			results[i] = r.executeStep(originalReq, step, false)
+		}
+	} else {
+		var wg sync.WaitGroup
+		for i, step := range plan.Steps {
+			wg.Add(1)
+			go func(i int, step *planStep) {
+				defer wg.Done()
+				results[i] = r.executeStep(originalReq, step, true)
+			}(i, step)
+		}
+		wg.Wait()
+	}
+
+	// Resolve fields of federated entities owned by other services
+	for i, step := range plan.Steps {
+		if len(step.Fetches) > 0 {
+			r.resolveStepEntities(originalReq, step, results[i])
+		}
+	}
+
+	// Index every response key by the step that produced it
+	keyOwner := make(map[string]int)
+	for i, step := range plan.Steps {
+		for _, key := range step.Keys {
+			keyOwner[key] = i
+		}
+	}
+
+	// Introspection fields are executed locally against the merged schema
+	var introspection *introspectionExecutor
+	if len(plan.Introspection) > 0 {
+		introspection = newIntrospectionExecutor(r.SchemaManager.GetSchema(), plan.Document, plan.Variables)
+	}
+
+	response := &GraphQLResponse{Data: newOrderedData()}
+	typenames := make(map[string]bool, len(plan.Typename))
+	for _, key := range plan.Typename {
+		typenames[key] = true
+	}
+
+	for _, key := range plan.RootKeys {
+		if typenames[key] {
+			typename, _ := json.Marshal(rootTypeName(plan.Operation))
+			response.Data.Set(key, typename)
 			continue
 		}
-		for _, value := range values {
-			dst.Add(key, value)
+		if field, ok := plan.Introspection[key]; ok {
+			response.Data.Set(key, introspection.executeRootField(field))
+			continue
+		}
+		result := results[keyOwner[key]]
+		if value, ok := result.Data[key]; ok {
+			response.Data.Set(key, value)
+		} else {
+			response.Data.Set(key, json.RawMessage("null"))
+		}
+	}
+
+	if introspection != nil {
+		response.Errors = append(response.Errors, introspection.errors...)
+	}
+	for _, result := range results {
+		response.Errors = append(response.Errors, result.Errors...)
+		for name, value := range result.Extensions {
+			if response.Extensions == nil {
+				response.Extensions = make(map[string]json.RawMessage)
+			}
+			response.Extensions[name] = value
 		}
 	}
+
+	return response
 }
 
-// isIntrospectionQuery detects if a query is requesting schema information
-func isIntrospectionQuery(query string) bool {
-	// Match common introspection patterns
-	return regexp.MustCompile(`\b__schema\b`).MatchString(query) ||
-		regexp.MustCompile(`\b__type\b`).MatchString(query) ||
-		regexp.MustCompile(`\bIntrospectionQuery\b`).MatchString(query)
+// executeStep sends one sub-operation to its service and decodes the response
+// Transport failures are reported as GraphQL errors on each of the step's fields
+func (r *Router) executeStep(originalReq *http.Request, step *planStep, idempotent bool) *serviceResponse {
+	subReq := GraphQLRequest{Query: step.Query, Variables: step.Variables}
+
+	resp, err := r.sendToService(originalReq, subReq, step.ServiceURL, idempotent)
+	if errors.Is(err, errCircuitOpen) {
+		log.Printf("Rejected sub-request to %s: %v", step.ServiceURL, err)
+		return failedStepResponse(step, "Service temporarily unavailable")
+	}
+	if err != nil {
+		log.Printf("Error forwarding to service %s: %v", step.ServiceURL, err)
+		return failedStepResponse(step, "Service unavailable")
+	}
+	defer resp.Body.Close()
+
+	var result serviceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		log.Printf("Invalid response from service %s (status %d): %v", step.ServiceURL, resp.StatusCode, err)
+		return failedStepResponse(step, "Invalid response from service")
+	}
+
+	log.Printf("Forwarded sub-request to %s, status: %d, fields: %v", step.ServiceURL, resp.StatusCode, step.Keys)
+	return &result
 }
 
-// extractRootField gets the main operation field from a GraphQL query
-func extractRootField(query string) string {
-	// Match the first field inside the operation
-	pattern := regexp.MustCompile(`(?i)^\s*(?:query|mutation|subscription)?\s*(?:\w+\s*)?(?:\([\s\S]*?\))?\s*\{?\s*(\w+)`)
-	matches := pattern.FindStringSubmatch(query)
+// resolveStepEntities runs the entity fetches of a step and re-encodes its root fields
+func (r *Router) resolveStepEntities(originalReq *http.Request, step *planStep, result *serviceResponse) {
+	root := make(map[string]interface{}, len(step.Keys))
+	for _, key := range step.Keys {
+		raw, ok := result.Data[key]
+		if !ok {
+			continue
+		}
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			log.Printf("Failed to decode field %s for entity resolution: %v", key, err)
+			continue
+		}
+		root[key] = value
+	}
+
+	result.Errors = append(result.Errors, r.resolveEntities(originalReq, []interface{}{root}, step.Fetches)...)
+	stripGatewayKeys(root)
 
-	if len(matches) < 2 {
-		return ""
+	for key, value := range root {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			log.Printf("Failed to encode field %s after entity resolution: %v", key, err)
+			continue
+		}
+		result.Data[key] = encoded
 	}
+}
 
-	return matches[1]
+// failedStepResponse builds a response carrying one error per field of a failed step
+func failedStepResponse(step *planStep, message string) *serviceResponse {
+	result := &serviceResponse{}
+	for _, key := range step.Keys {
+		errorJSON, _ := json.Marshal(map[string]interface{}{
+			"message": message,
+			"path":    []string{key},
+		})
+		result.Errors = append(result.Errors, errorJSON)
+	}
+	return result
+}
+
+// rootTypeName returns the conventional root type name for an operation type
+func rootTypeName(operation ast.Operation) string {
+	switch operation {
+	case ast.Mutation:
+		return "Mutation"
+	case ast.Subscription:
+		return "Subscription"
+	default:
+		return "Query"
+	}
+}
+
+// writeGraphQLError writes a GraphQL-shaped error response with the given status code
+func writeGraphQLError(w http.ResponseWriter, statusCode int, message string) {
+	errorJSON, _ := json.Marshal(map[string]interface{}{
+		"errors": []map[string]interface{}{
+			{"message": message},
+		},
+	})
+	w.WriteHeader(statusCode)
+	w.Write(errorJSON)
+}
+
+// copyHeaders copies HTTP headers except Host and body-specific headers
+func copyHeaders(src, dst http.Header) {
+	for key, values := range src {
+		if key == "Host" || key == "Content-Length" || key == "Accept-Encoding" {
+			continue
+		}
+		for _, value := range values {
+			dst.Add(key, value)
+		}
+	}
 }
 
 // ServePlayground serves the GraphQL Playground - an in-browser GraphQL IDE
@@ -286,135 +620,3 @@ func ServePlayground(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 	}
 }
-
-// HandleRESTRequest processes incoming REST API requests and forwards them to appropriate services
-func (r *Router) HandleRESTRequest(w http.ResponseWriter, req *http.Request) {
-	// Extract service name and path from the URL
-	// Expected format: /api/v1/{service_name}/{path}
-	path := req.URL.Path
-
-	// Parse the URL to get service name and remaining path
-	parts := strings.Split(path, "/")
-	if len(parts) < 4 {
-		http.Error(w, "Invalid API path format", http.StatusBadRequest)
-		log.Printf("Invalid API path: %s", path)
-		return
-	}
-
-	// Extract service name (parts[3])
-	serviceName := parts[3]
-
-	// Get base URL for this service from the schema manager
-	serviceBaseURL := r.getServiceBaseURL(serviceName)
-	if serviceBaseURL == "" {
-		http.Error(w, fmt.Sprintf("Unknown service: %s", serviceName), http.StatusNotFound)
-		log.Printf("Unknown service requested: %s", serviceName)
-		return
-	}
-
-	// Rebuild the path without the /api/v1/{service_name} prefix
-	remainingPath := strings.Join(parts[4:], "/")
-
-	// Extract the base URL without the GraphQL path
-	serviceURLParts := strings.Split(serviceBaseURL, "/graphql")
-	serviceHostURL := serviceURLParts[0]
-
-	// Build the target URL
-	targetURL := fmt.Sprintf("%s/%s", serviceHostURL, remainingPath)
-
-	// Log the forwarding information
-	log.Printf("Forwarding REST request to service %s: %s → %s", serviceName, path, targetURL)
-
-	// Forward the request
-	r.forwardRESTRequest(w, req, targetURL)
-}
-
-// getServiceBaseURL returns the base URL for a service by name
-func (r *Router) getServiceBaseURL(serviceName string) string {
-	// Simple example field to get a URL for each service
-	// This assumes the first operation for each service is mapped correctly
-	for field, url := range r.getRoutesMap() {
-		if strings.HasPrefix(field, serviceName) ||
-			(serviceName == "main" && !strings.HasPrefix(field, "auth") &&
-				!strings.HasPrefix(field, "getgrass")) {
-			return url
-		}
-	}
-
-	// Special case mapping if no routes are found
-	switch serviceName {
-	case "main":
-		return "http://localhost:8088/graphql"
-	case "auth":
-		return "http://localhost:8081/graphql"
-	case "getgrass":
-		return "http://localhost:8085/graphql"
-	default:
-		return ""
-	}
-}
-
-// getRoutesMap retrieves all available routes from the schema manager
-func (r *Router) getRoutesMap() map[string]string {
-	// This method is needed because SchemaManager interface doesn't expose the routes map directly
-	// We'll use reflection to access it - in a real-world scenario, this should be properly exposed
-
-	// Try to extract the routes map using type assertion if the underlying implementation is compatible
-	if adapter, ok := r.SchemaManager.(interface{ GetRoutes() map[string]string }); ok {
-		return adapter.GetRoutes()
-	}
-
-	// Fallback to an empty map if not available
-	return map[string]string{}
-}
-
-// forwardRESTRequest sends the REST request to the target service
-func (r *Router) forwardRESTRequest(w http.ResponseWriter, originalReq *http.Request, targetURL string) {
-	// Create a new request with the same method, URL, and body
-	proxyReq, err := http.NewRequest(originalReq.Method, targetURL, originalReq.Body)
-	if err != nil {
-		log.Printf("Error creating proxy request: %v", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return
-	}
-
-	// Copy all headers from the original request
-	for key, values := range originalReq.Header {
-		if key != "Host" { // Skip the Host header
-			for _, value := range values {
-				proxyReq.Header.Add(key, value)
-			}
-		}
-	}
-
-	// Copy query parameters
-	proxyReq.URL.RawQuery = originalReq.URL.RawQuery
-
-	// Send the request to the target service
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(proxyReq)
-	if err != nil {
-		log.Printf("Error forwarding request to %s: %v", targetURL, err)
-		http.Error(w, "Service Unavailable", http.StatusBadGateway)
-		return
-	}
-	defer resp.Body.Close()
-
-	// Copy all headers from the response
-	for key, values := range resp.Header {
-		for _, value := range values {
-			w.Header().Add(key, value)
-		}
-	}
-
-	// Set the status code
-	w.WriteHeader(resp.StatusCode)
-
-	// Copy the body
-	_, err = io.Copy(w, resp.Body)
-	if err != nil {
-		log.Printf("Error copying response body: %v", err)
-	}
-
-	log.Printf("REST request forwarded to %s, status: %d", targetURL, resp.StatusCode)
-}
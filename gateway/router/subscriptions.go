@@ -0,0 +1,451 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/saurabh/entgo-microservices/pkg/identity"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	connectionInitTimeout = 10 * time.Second
+	keepAliveInterval     = 10 * time.Second
+)
+
+// upgrader accepts both GraphQL WebSocket subprotocols from any origin, like the CORS setup
+var upgrader = websocket.Upgrader{
+	Subprotocols: []string{protocolTransportWS, protocolLegacyWS},
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// wsSession is one client WebSocket connection and the subscriptions it started
+type wsSession struct {
+	router   *Router
+	conn     *websocket.Conn
+	protocol string
+	request  *http.Request
+	writeMu  sync.Mutex
+
+	mu           sync.Mutex
+	acknowledged bool
+	initPayload  json.RawMessage
+	upstreamHdr  http.Header
+	subs         map[string]*clientSubscription
+}
+
+// clientSubscription links a client operation id to its upstream subscription
+type clientSubscription struct {
+	upstream   *upstreamConn
+	upstreamID string
+}
+
+// HandleWebSocket upgrades a request and serves graphql-transport-ws or graphql-ws clients
+func (r *Router) HandleWebSocket(w http.ResponseWriter, req *http.Request) {
+	if !websocket.IsWebSocketUpgrade(req) {
+		writeGraphQLError(w, http.StatusBadRequest, "Expected a WebSocket upgrade for GraphQL subscriptions")
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade failed: %v", err)
+		return
+	}
+
+	session := &wsSession{
+		router:   r,
+		conn:     conn,
+		protocol: conn.Subprotocol(),
+		request:  req,
+		subs:     make(map[string]*clientSubscription),
+	}
+
+	if session.protocol == "" {
+		session.closeWith(4406, "Subprotocol not acceptable")
+		return
+	}
+
+	log.Printf("WebSocket client connected using %s", session.protocol)
+	session.serve()
+}
+
+// serve reads client messages until the connection closes
+func (s *wsSession) serve() {
+	defer s.cleanup()
+
+	// Clients must initialise the connection before anything else
+	initTimer := time.AfterFunc(connectionInitTimeout, func() {
+		s.mu.Lock()
+		acknowledged := s.acknowledged
+		s.mu.Unlock()
+		if !acknowledged {
+			s.closeWith(4408, "Connection initialisation timeout")
+		}
+	})
+	defer initTimer.Stop()
+
+	done := make(chan struct{})
+	defer close(done)
+	go s.keepAlive(done)
+
+	for {
+		var msg wsMessage
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		switch msg.Type {
+		case "connection_init":
+			if !s.handleInit(msg.Payload) {
+				return
+			}
+		case "subscribe", "start":
+			if !s.isAcknowledged() {
+				s.closeWith(4401, "Unauthorized")
+				return
+			}
+			s.handleSubscribe(msg.ID, msg.Payload)
+		case "complete", "stop":
+			s.stop(msg.ID)
+		case "ping":
+			s.write(wsMessage{Type: "pong", Payload: msg.Payload})
+		case "pong":
+		case "connection_terminate":
+			return
+		default:
+			if s.protocol == protocolTransportWS {
+				s.closeWith(4400, fmt.Sprintf("Invalid message type %q", msg.Type))
+				return
+			}
+		}
+	}
+}
+
+// handleInit stores the connection_init payload that is forwarded to every upstream service
+func (s *wsSession) handleInit(payload json.RawMessage) bool {
+	s.mu.Lock()
+	if s.acknowledged {
+		s.mu.Unlock()
+		s.closeWith(4429, "Too many initialisation requests")
+		return false
+	}
+	s.acknowledged = true
+	s.initPayload = payload
+	s.upstreamHdr = upstreamHeaders(s.request.Header, payload)
+	s.mu.Unlock()
+
+	// Tokens are validated before the connection is acknowledged, like a service would
+	if _, _, err := s.forwardHeader(); err != nil {
+		log.Printf("Rejected WebSocket connection: %v", err)
+		s.closeWith(4403, "Forbidden")
+		return false
+	}
+
+	s.write(wsMessage{Type: "connection_ack"})
+	if s.protocol == protocolLegacyWS {
+		s.write(wsMessage{Type: "ka"})
+	}
+	return true
+}
+
+// handleSubscribe routes one client operation to the service that owns it
+func (s *wsSession) handleSubscribe(id string, payload json.RawMessage) {
+	s.mu.Lock()
+	_, exists := s.subs[id]
+	s.mu.Unlock()
+	if exists {
+		s.closeWith(4409, fmt.Sprintf("Subscriber for %s already exists", id))
+		return
+	}
+
+	var graphQLReq GraphQLRequest
+	if err := json.Unmarshal(payload, &graphQLReq); err != nil {
+		s.sendError(id, "Invalid GraphQL request: "+err.Error())
+		return
+	}
+
+	// Queries sent as a hash are forwarded with their text
+	_, hashed := graphQLReq.Extensions["persistedQuery"]
+	if err := s.router.resolvePersistedQuery(s.request.Context(), &graphQLReq); err != nil {
+		s.sendError(id, err.Error())
+		return
+	}
+	if hashed {
+		encoded, err := json.Marshal(graphQLReq)
+		if err != nil {
+			s.sendError(id, "Invalid GraphQL request: "+err.Error())
+			return
+		}
+		payload = encoded
+	}
+
+	doc, op, err := parseOperation(graphQLReq.Query, graphQLReq.OperationName)
+	if err != nil {
+		s.sendError(id, "Unable to parse GraphQL query: "+err.Error())
+		return
+	}
+
+	plan, err := s.router.planOperation(doc, op, graphQLReq.Variables)
+	if err != nil {
+		s.sendError(id, err.Error())
+		return
+	}
+
+	// The token is checked again for every operation, so revoking it stops new operations
+	header, caller, err := s.forwardHeader()
+	if err != nil {
+		log.Printf("Rejected WebSocket operation %s: %v", id, err)
+		s.sendError(id, "Invalid or expired token")
+		return
+	}
+
+	if _, err := s.router.analyzeOperation(doc, op, graphQLReq.Variables, caller); err != nil {
+		s.sendError(id, err.Error())
+		return
+	}
+
+	if decision := s.router.checkRateLimit(s.request, caller, plan.Fields); decision != nil && !decision.Allowed {
+		s.sendError(id, rateLimitMessage(decision))
+		return
+	}
+
+	// Queries and mutations sent over the socket are answered once through HTTP
+	if plan.Operation != ast.Subscription {
+		invalidates := s.router.invalidationTypes(doc, op, graphQLReq.Variables)
+		go func() {
+			s.executeOnce(id, plan, header)
+			s.router.invalidateCache(s.request.Context(), invalidates)
+		}()
+		return
+	}
+
+	if len(plan.Steps) != 1 || len(plan.Steps[0].Fetches) > 0 {
+		s.sendError(id, "Subscriptions must select fields from a single service")
+		return
+	}
+
+	if err := s.startUpstream(id, plan.Steps[0].ServiceURL, payload, header); err != nil {
+		log.Printf("Failed to start subscription %s on %s: %v", id, plan.Steps[0].ServiceURL, err)
+		s.sendError(id, "Subscription service unavailable")
+	}
+}
+
+// startUpstream attaches the client subscription to a pooled upstream connection
+func (s *wsSession) startUpstream(id, serviceURL string, payload json.RawMessage, header http.Header) error {
+	handler := func(msgType string, upstreamPayload json.RawMessage) {
+		if msgType != "next" {
+			s.mu.Lock()
+			delete(s.subs, id)
+			s.mu.Unlock()
+		}
+		s.relay(id, msgType, upstreamPayload)
+	}
+
+	s.mu.Lock()
+	initPayload := s.initPayload
+	s.mu.Unlock()
+
+	// A pooled connection may close between lookup and subscribe, so retry once
+	var lastErr error
+	for attempt := 0; attempt < 2; attempt++ {
+		upstream, err := s.router.upstreams.get(serviceURL, header, initPayload)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		sub := &clientSubscription{upstream: upstream}
+		s.subs[id] = sub
+		s.mu.Unlock()
+
+		upstreamID, err := upstream.subscribe(payload, handler)
+		if err == nil {
+			s.mu.Lock()
+			sub.upstreamID = upstreamID
+			s.mu.Unlock()
+			return nil
+		}
+
+		s.mu.Lock()
+		delete(s.subs, id)
+		s.mu.Unlock()
+		lastErr = err
+	}
+
+	return lastErr
+}
+
+// executeOnce answers a query or mutation received over the socket
+func (s *wsSession) executeOnce(id string, plan *queryPlan, header http.Header) {
+	req := s.request.Clone(s.request.Context())
+	identity.StripHeaders(req.Header)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	response := s.router.executePlan(req, plan)
+
+	payload, err := json.Marshal(response)
+	if err != nil {
+		s.sendError(id, "Internal server error: Failed to encode response")
+		return
+	}
+
+	s.relay(id, "next", payload)
+	s.relay(id, "complete", nil)
+}
+
+// stop ends a client subscription and releases its upstream subscription
+func (s *wsSession) stop(id string) {
+	s.mu.Lock()
+	sub, ok := s.subs[id]
+	delete(s.subs, id)
+	var upstreamID string
+	if ok {
+		upstreamID = sub.upstreamID
+	}
+	s.mu.Unlock()
+
+	if upstreamID != "" {
+		sub.upstream.unsubscribe(upstreamID)
+	}
+}
+
+// relay sends an upstream message to the client in the client's protocol
+func (s *wsSession) relay(id, msgType string, payload json.RawMessage) {
+	if s.protocol == protocolLegacyWS {
+		switch msgType {
+		case "next":
+			msgType = "data"
+		case "error":
+			// Legacy clients expect a single error object rather than a list
+			var errors []json.RawMessage
+			if json.Unmarshal(payload, &errors) == nil && len(errors) > 0 {
+				payload = errors[0]
+			}
+		}
+	}
+
+	s.write(wsMessage{ID: id, Type: msgType, Payload: payload})
+}
+
+// sendError reports an operation error to the client
+func (s *wsSession) sendError(id, message string) {
+	payload, _ := json.Marshal([]map[string]interface{}{
+		{"message": message},
+	})
+	s.relay(id, "error", payload)
+}
+
+// keepAlive pings graphql-transport-ws clients and sends ka to graphql-ws clients
+func (s *wsSession) keepAlive(done <-chan struct{}) {
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if !s.isAcknowledged() {
+				continue
+			}
+			if s.protocol == protocolLegacyWS {
+				s.write(wsMessage{Type: "ka"})
+			} else {
+				s.write(wsMessage{Type: "ping"})
+			}
+		}
+	}
+}
+
+// cleanup releases all upstream subscriptions of a closed client connection
+func (s *wsSession) cleanup() {
+	s.mu.Lock()
+	subs := s.subs
+	s.subs = make(map[string]*clientSubscription)
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		if sub.upstreamID != "" {
+			sub.upstream.unsubscribe(sub.upstreamID)
+		}
+	}
+
+	s.conn.Close()
+	log.Printf("WebSocket client disconnected, released %d subscriptions", len(subs))
+}
+
+// isAcknowledged reports whether connection_init has been accepted
+func (s *wsSession) isAcknowledged() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.acknowledged
+}
+
+// write sends a message to the client, serializing concurrent writers
+func (s *wsSession) write(msg wsMessage) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if err := s.conn.WriteJSON(msg); err != nil {
+		log.Printf("Failed to write WebSocket message: %v", err)
+	}
+}
+
+// closeWith closes the client connection with a protocol close code
+func (s *wsSession) closeWith(code int, reason string) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+	s.conn.Close()
+}
+
+// forwardHeader validates the session's token and returns the headers sent to services with its identity, and the caller
+func (s *wsSession) forwardHeader() (http.Header, *identity.Identity, error) {
+	s.mu.Lock()
+	header := s.upstreamHdr.Clone()
+	s.mu.Unlock()
+
+	caller, err := s.router.authenticate(s.request.Context(), header)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, caller, nil
+}
+
+// upstreamHeaders builds the dial headers for upstream sockets
+// The bearer token comes from the upgrade request or, failing that, the connection_init payload
+func upstreamHeaders(src http.Header, initPayload json.RawMessage) http.Header {
+	header := http.Header{}
+	if auth := src.Get("Authorization"); auth != "" {
+		header.Set("Authorization", auth)
+		return header
+	}
+
+	var params map[string]interface{}
+	if json.Unmarshal(initPayload, &params) != nil {
+		return header
+	}
+	for _, key := range []string{"Authorization", "authorization", "authToken", "token"} {
+		if value, ok := params[key].(string); ok && value != "" {
+			if key == "authToken" || key == "token" {
+				value = "Bearer " + value
+			}
+			header.Set("Authorization", value)
+			break
+		}
+	}
+
+	return header
+}
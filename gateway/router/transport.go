@@ -0,0 +1,295 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// errCircuitOpen is returned without contacting a service whose circuit breaker is open
+var errCircuitOpen = errors.New("circuit breaker open")
+
+// ServicePolicy controls timeouts, retries and circuit breaking towards one service
+type ServicePolicy struct {
+	Timeout          time.Duration // Timeout of a single attempt, including reading the response
+	MaxRetries       int           // Extra attempts for queries after a connection error or a 502, 503 or 504
+	RetryBackoff     time.Duration // Delay before the first retry, doubled for every further retry
+	FailureThreshold int           // Consecutive failures that open the circuit
+	OpenTimeout      time.Duration // Time the circuit stays open before a trial request is let through
+	MaxConnections   int           // Maximum connections to the service, 0 for no limit
+}
+
+// DefaultServicePolicy returns the policy used for services without explicit settings
+func DefaultServicePolicy() ServicePolicy {
+	return ServicePolicy{
+		Timeout:          30 * time.Second,
+		MaxRetries:       2,
+		RetryBackoff:     100 * time.Millisecond,
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+	}
+}
+
+// serviceClients holds one pooled HTTP client and circuit breaker per service URL
+type serviceClients struct {
+	mu       sync.Mutex
+	clients  map[string]*serviceClient
+	defaults ServicePolicy
+	policies map[string]ServicePolicy // Policies by service URL
+}
+
+// serviceClient sends GraphQL requests to one service
+type serviceClient struct {
+	url     string
+	policy  ServicePolicy
+	client  *http.Client
+	breaker *circuitBreaker
+}
+
+// newServiceClients creates an empty client set using the default policy
+func newServiceClients() *serviceClients {
+	return &serviceClients{
+		clients:  make(map[string]*serviceClient),
+		defaults: DefaultServicePolicy(),
+		policies: make(map[string]ServicePolicy),
+	}
+}
+
+// SetServicePolicies replaces the default policy and the per-service policies keyed by service URL
+// Clients created with earlier policies are closed and rebuilt on their next use
+func (r *Router) SetServicePolicies(defaults ServicePolicy, byURL map[string]ServicePolicy) {
+	r.clients.mu.Lock()
+	defer r.clients.mu.Unlock()
+
+	r.clients.defaults = defaults
+	r.clients.policies = byURL
+	for url, client := range r.clients.clients {
+		client.client.CloseIdleConnections()
+		delete(r.clients.clients, url)
+	}
+}
+
+// get returns the client of a service, creating it on first use
+func (c *serviceClients) get(url string) *serviceClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.clients[url]; ok {
+		return client
+	}
+
+	policy, ok := c.policies[url]
+	if !ok {
+		policy = c.defaults
+	}
+
+	client := &serviceClient{
+		url:    url,
+		policy: policy,
+		client: &http.Client{
+			Timeout: policy.Timeout,
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				DialContext: (&net.Dialer{
+					Timeout:   5 * time.Second,
+					KeepAlive: 30 * time.Second,
+				}).DialContext,
+				MaxIdleConns:           100,
+				MaxIdleConnsPerHost:    32,
+				MaxConnsPerHost:        policy.MaxConnections,
+				IdleConnTimeout:        90 * time.Second,
+				TLSHandshakeTimeout:    5 * time.Second,
+				ExpectContinueTimeout:  1 * time.Second,
+				ResponseHeaderTimeout:  policy.Timeout,
+				MaxResponseHeaderBytes: 10 * 1024 * 1024, // 10MB for headers
+			},
+		},
+		breaker: &circuitBreaker{
+			url:         url,
+			threshold:   policy.FailureThreshold,
+			openTimeout: policy.OpenTimeout,
+		},
+	}
+	c.clients[url] = client
+	return client
+}
+
+// closeAll releases idle connections of every service client
+func (c *serviceClients) closeAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, client := range c.clients {
+		client.client.CloseIdleConnections()
+	}
+}
+
+// do sends a request built by newRequest, retrying retryable failures when the request is idempotent
+// The request is rebuilt for every attempt so that its body can be sent again
+func (c *serviceClient) do(ctx context.Context, newRequest func() (*http.Request, error), idempotent bool) (*http.Response, error) {
+	attempts := 1
+	if idempotent {
+		attempts += c.policy.MaxRetries
+	}
+	backoff := c.policy.RetryBackoff
+
+	for attempt := 1; ; attempt++ {
+		if !c.breaker.allow() {
+			return nil, fmt.Errorf("%s: %w", c.url, errCircuitOpen)
+		}
+
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.client.Do(req)
+		if ctx.Err() != nil {
+			// A client that went away says nothing about the health of the service
+			c.breaker.release()
+		} else {
+			c.breaker.record(err == nil && resp.StatusCode < http.StatusInternalServerError)
+		}
+
+		if attempt >= attempts || !shouldRetry(resp, err) || ctx.Err() != nil {
+			return resp, err
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		log.Printf("Retrying request to %s (attempt %d of %d) after %s", c.url, attempt+1, attempts, backoff)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// RoundTrip sends a single request through the circuit breaker without retrying
+// It lets the REST reverse proxy stream request and response bodies over the pooled connections
+func (c *serviceClient) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !c.breaker.allow() {
+		return nil, fmt.Errorf("%s: %w", c.url, errCircuitOpen)
+	}
+
+	resp, err := c.client.Transport.RoundTrip(req)
+	if req.Context().Err() != nil {
+		c.breaker.release()
+	} else {
+		c.breaker.record(err == nil && resp.StatusCode < http.StatusInternalServerError)
+	}
+	return resp, err
+}
+
+// shouldRetry reports whether a failed attempt may succeed when sent again
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// circuitBreaker stops sending requests to a service after consecutive failures
+// After openTimeout a single trial request is let through; its outcome closes or re-opens the circuit
+type circuitBreaker struct {
+	url         string
+	threshold   int
+	openTimeout time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	trial    bool // A half-open trial request is in flight
+}
+
+// breakerState is the state of a circuit breaker
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// allow reports whether a request may be sent now
+func (b *circuitBreaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = breakerHalfOpen
+		b.trial = true
+		log.Printf("Circuit for %s half-open, sending a trial request", b.url)
+		return true
+
+	case breakerHalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	}
+	return true
+}
+
+// release gives up a half-open trial without recording an outcome
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+// record updates the breaker with the outcome of a request
+func (b *circuitBreaker) record(success bool) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		if b.state != breakerClosed {
+			log.Printf("Circuit for %s closed", b.url)
+		}
+		b.state = breakerClosed
+		b.failures = 0
+		b.trial = false
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		if b.state != breakerOpen {
+			log.Printf("Circuit for %s opened after %d consecutive failures", b.url, b.failures)
+		}
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+		b.trial = false
+	}
+}
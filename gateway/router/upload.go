@@ -0,0 +1,237 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/saurabh/entgo-microservices/pkg/identity"
+)
+
+// maxUploadFieldSize bounds the operations and map fields, which are read into memory
+const maxUploadFieldSize = 1 << 20
+
+// UploadLimits bounds GraphQL multipart requests
+type UploadLimits struct {
+	MaxRequestSize int64 // Bytes of a whole multipart request, 0 for no limit
+	MaxFileSize    int64 // Bytes of a single file, 0 for no limit
+	MaxFiles       int   // Files in one request, 0 for no limit
+}
+
+// uploadLimitError rejects an upload over one of the limits
+type uploadLimitError struct {
+	message string
+}
+
+func (e *uploadLimitError) Error() string {
+	return e.message
+}
+
+// SetUploadLimits sets the size limits of multipart file uploads
+func (r *Router) SetUploadLimits(limits UploadLimits) {
+	r.uploadLimits = limits
+}
+
+// isMultipartUpload reports whether a request follows the GraphQL multipart request spec
+func isMultipartUpload(req *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return err == nil && mediaType == "multipart/form-data" && req.Method == http.MethodPost
+}
+
+// serveUpload routes a multipart request by its operation and streams the file parts to the owning service
+// The operations and map fields are read first, as the spec requires; files are never held in memory
+func (r *Router) serveUpload(w http.ResponseWriter, req *http.Request, caller *identity.Identity) {
+	if r.uploadLimits.MaxRequestSize > 0 {
+		req.Body = http.MaxBytesReader(w, req.Body, r.uploadLimits.MaxRequestSize)
+	}
+	reader, err := req.MultipartReader()
+	if err != nil {
+		writeGraphQLError(w, http.StatusBadRequest, "Invalid multipart request: "+err.Error())
+		return
+	}
+
+	operations, err := readUploadField(reader, "operations")
+	if err == nil && bytes.HasPrefix(bytes.TrimSpace(operations), []byte("[")) {
+		err = errors.New("batched uploads are not supported, send one operation per request")
+	}
+	var fileMap []byte
+	if err == nil {
+		fileMap, err = readUploadField(reader, "map")
+	}
+	var graphQLReq GraphQLRequest
+	var files map[string][]string
+	if err == nil {
+		if err = json.Unmarshal(operations, &graphQLReq); err != nil {
+			err = fmt.Errorf("invalid operations field: %w", err)
+		} else if err = json.Unmarshal(fileMap, &files); err != nil {
+			err = fmt.Errorf("invalid map field: %w", err)
+		} else if r.uploadLimits.MaxFiles > 0 && len(files) > r.uploadLimits.MaxFiles {
+			err = &uploadLimitError{fmt.Sprintf("Request contains %d files, the maximum is %d", len(files), r.uploadLimits.MaxFiles)}
+		}
+	}
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+
+	prepared := r.prepareOperation(w, req, graphQLReq, caller)
+	if prepared == nil {
+		return
+	}
+	plan := prepared.plan
+	if len(plan.Steps) != 1 || len(plan.Steps[0].Fetches) > 0 || len(plan.Introspection) > 0 {
+		writeGraphQLError(w, http.StatusBadRequest, "Uploads must select fields from a single service")
+		return
+	}
+
+	// The operation is sent as resolved, e.g. with the query of a persisted query hash
+	operations, err = json.Marshal(prepared.request)
+	if err != nil {
+		writeGraphQLError(w, http.StatusBadRequest, "Invalid GraphQL request: "+err.Error())
+		return
+	}
+
+	invalidates := r.invalidationTypes(prepared.doc, prepared.op, prepared.request.Variables)
+	r.forwardUpload(w, req, plan.Steps[0].ServiceURL, operations, fileMap, reader, prepared.cost)
+	r.invalidateCache(req.Context(), invalidates)
+}
+
+// forwardUpload streams a multipart request to a service through a pipe, one part at a time
+func (r *Router) forwardUpload(w http.ResponseWriter, req *http.Request, serviceURL string,
+	operations, fileMap []byte, reader *multipart.Reader, cost *queryCost) {
+
+	// Cancelled when the client's upload fails, so the service's circuit breaker does not count it
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	pipeReader, pipeWriter := io.Pipe()
+	writer := multipart.NewWriter(pipeWriter)
+	copied := make(chan error, 1)
+	go func() {
+		err := r.copyUploadParts(writer, operations, fileMap, reader)
+		if err == nil {
+			err = writer.Close()
+		} else if clientUploadError(err) {
+			cancel()
+		}
+		pipeWriter.CloseWithError(err)
+		copied <- err
+	}()
+
+	resp, err := r.clients.get(serviceURL).do(ctx, func() (*http.Request, error) {
+		upstream, err := http.NewRequestWithContext(ctx, http.MethodPost, serviceURL, pipeReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create service request: %w", err)
+		}
+		copyHeaders(req.Header, upstream.Header)
+		upstream.Header.Set("Content-Type", writer.FormDataContentType())
+		return upstream, nil
+	}, false)
+
+	// A service that answered without reading every part must not block the copy
+	pipeReader.Close()
+	copyErr := <-copied
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	switch {
+	case clientUploadError(copyErr):
+		log.Printf("Rejected upload to %s: %v", serviceURL, copyErr)
+		writeUploadError(w, copyErr)
+	case errors.Is(err, errCircuitOpen):
+		log.Printf("Rejected upload to %s: %v", serviceURL, err)
+		writeGraphQLError(w, http.StatusServiceUnavailable, "Service temporarily unavailable")
+	case err != nil:
+		log.Printf("Error forwarding upload to %s: %v", serviceURL, err)
+		writeGraphQLError(w, http.StatusBadGateway, "Service unavailable")
+	default:
+		relayResponse(w, resp, serviceURL, cost)
+	}
+}
+
+// copyUploadParts writes the operations and map fields, then copies every file part within the limits
+func (r *Router) copyUploadParts(writer *multipart.Writer, operations, fileMap []byte, reader *multipart.Reader) error {
+	if err := writer.WriteField("operations", string(operations)); err != nil {
+		return err
+	}
+	if err := writer.WriteField("map", string(fileMap)); err != nil {
+		return err
+	}
+
+	for files := 1; ; files++ {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading upload: %w", err)
+		}
+		if r.uploadLimits.MaxFiles > 0 && files > r.uploadLimits.MaxFiles {
+			return &uploadLimitError{fmt.Sprintf("Request contains more than %d files", r.uploadLimits.MaxFiles)}
+		}
+
+		dst, err := writer.CreatePart(part.Header)
+		if err != nil {
+			return err
+		}
+		src := io.Reader(part)
+		if r.uploadLimits.MaxFileSize > 0 {
+			src = io.LimitReader(part, r.uploadLimits.MaxFileSize+1)
+		}
+		n, err := io.Copy(dst, src)
+		if err != nil {
+			return err
+		}
+		if r.uploadLimits.MaxFileSize > 0 && n > r.uploadLimits.MaxFileSize {
+			return &uploadLimitError{fmt.Sprintf("File %s exceeds the maximum size of %d bytes", part.FileName(), r.uploadLimits.MaxFileSize)}
+		}
+	}
+}
+
+// readUploadField reads the next part, which must be the named form field
+func readUploadField(reader *multipart.Reader, name string) ([]byte, error) {
+	part, err := reader.NextPart()
+	if err != nil {
+		return nil, fmt.Errorf("missing %s field: %w", name, err)
+	}
+	if part.FormName() != name {
+		return nil, fmt.Errorf("expected the %s field, got %q", name, part.FormName())
+	}
+
+	data, err := io.ReadAll(io.LimitReader(part, maxUploadFieldSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s field: %w", name, err)
+	}
+	if len(data) > maxUploadFieldSize {
+		return nil, &uploadLimitError{fmt.Sprintf("The %s field exceeds %d bytes", name, maxUploadFieldSize)}
+	}
+	return data, nil
+}
+
+// clientUploadError reports whether copying an upload failed on the client's side
+// Failures to write to the service show up as a closed pipe instead
+func clientUploadError(err error) bool {
+	return err != nil && !errors.Is(err, io.ErrClosedPipe)
+}
+
+// writeUploadError rejects an upload, with 413 for requests over a size limit
+func writeUploadError(w http.ResponseWriter, err error) {
+	var limitErr *uploadLimitError
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &limitErr):
+		writeGraphQLError(w, http.StatusRequestEntityTooLarge, limitErr.message)
+	case errors.As(err, &maxBytesErr):
+		writeGraphQLError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Upload exceeds the maximum size of %d bytes", maxBytesErr.Limit))
+	default:
+		writeGraphQLError(w, http.StatusBadRequest, "Invalid multipart request: "+err.Error())
+	}
+}
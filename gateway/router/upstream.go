@@ -0,0 +1,309 @@
+package router
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Subprotocols spoken by GraphQL WebSocket clients and services
+const (
+	protocolTransportWS = "graphql-transport-ws" // graphql-ws library
+	protocolLegacyWS    = "graphql-ws"           // subscriptions-transport-ws (Apollo legacy)
+
+	upstreamAckTimeout = 10 * time.Second
+)
+
+// wsMessage is a message of either GraphQL WebSocket protocol
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// upstreamHandler receives next, error and complete messages of one upstream subscription
+type upstreamHandler func(msgType string, payload json.RawMessage)
+
+// upstreamPool shares upstream WebSocket connections between client subscriptions
+// Connections are keyed by service and auth context so that identities are never mixed
+type upstreamPool struct {
+	mu    sync.Mutex
+	conns map[string]*upstreamConn
+	dials map[string]*upstreamDial // Connections being dialed, shared by the callers of their key
+}
+
+// upstreamDial is a connection being dialed; done is closed once conn or err is set
+type upstreamDial struct {
+	done chan struct{}
+	conn *upstreamConn
+	err  error
+}
+
+// upstreamConn is one graphql-transport-ws connection to a service multiplexing many subscriptions
+type upstreamConn struct {
+	key     string
+	pool    *upstreamPool
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+
+	mu     sync.Mutex
+	subs   map[string]upstreamHandler
+	nextID uint64
+	closed bool
+}
+
+// newUpstreamPool creates an empty upstream connection pool
+func newUpstreamPool() *upstreamPool {
+	return &upstreamPool{conns: make(map[string]*upstreamConn), dials: make(map[string]*upstreamDial)}
+}
+
+// get returns a live connection for the service and auth context, dialing one if needed
+// The dial runs without the pool lock, so a slow service only delays the callers waiting for it
+func (p *upstreamPool) get(serviceURL string, header http.Header, initPayload json.RawMessage) (*upstreamConn, error) {
+	key := upstreamKey(serviceURL, header, initPayload)
+
+	p.mu.Lock()
+	if conn, ok := p.conns[key]; ok && !conn.isClosed() {
+		p.mu.Unlock()
+		return conn, nil
+	}
+	if dial, ok := p.dials[key]; ok {
+		p.mu.Unlock()
+		<-dial.done
+		return dial.conn, dial.err
+	}
+	dial := &upstreamDial{done: make(chan struct{})}
+	p.dials[key] = dial
+	p.mu.Unlock()
+
+	conn, err := dialUpstream(serviceURL, header, initPayload)
+
+	p.mu.Lock()
+	delete(p.dials, key)
+	if err == nil {
+		conn.key = key
+		conn.pool = p
+		p.conns[key] = conn
+	}
+	pooled := len(p.conns)
+	p.mu.Unlock()
+
+	if err == nil {
+		go conn.readLoop()
+		log.Printf("Opened upstream subscription socket to %s (pooled: %d)", serviceURL, pooled)
+	}
+	dial.conn, dial.err = conn, err
+	close(dial.done)
+	return conn, err
+}
+
+// remove drops a connection from the pool if it is still the pooled one for its key
+func (p *upstreamPool) remove(conn *upstreamConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conns[conn.key] == conn {
+		delete(p.conns, conn.key)
+	}
+}
+
+// closeAll closes every pooled upstream connection
+func (p *upstreamPool) closeAll() {
+	p.mu.Lock()
+	conns := make([]*upstreamConn, 0, len(p.conns))
+	for _, conn := range p.conns {
+		conns = append(conns, conn)
+	}
+	p.conns = make(map[string]*upstreamConn)
+	p.mu.Unlock()
+
+	for _, conn := range conns {
+		conn.close()
+	}
+}
+
+// upstreamKey identifies the auth context of an upstream connection
+func upstreamKey(serviceURL string, header http.Header, initPayload json.RawMessage) string {
+	hash := sha256.New()
+	hash.Write([]byte(header.Get("Authorization")))
+	hash.Write([]byte{0})
+	hash.Write(initPayload)
+	return serviceURL + "|" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// dialUpstream opens a graphql-transport-ws connection and completes the connection_init handshake
+func dialUpstream(serviceURL string, header http.Header, initPayload json.RawMessage) (*upstreamConn, error) {
+	wsURL := toWebSocketURL(serviceURL)
+
+	dialer := websocket.Dialer{
+		HandshakeTimeout: upstreamAckTimeout,
+		Subprotocols:     []string{protocolTransportWS},
+	}
+	conn, _, err := dialer.Dial(wsURL, header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s: %w", wsURL, err)
+	}
+
+	init := wsMessage{Type: "connection_init", Payload: initPayload}
+	if err := conn.WriteJSON(init); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send connection_init to %s: %w", wsURL, err)
+	}
+
+	// Wait for the acknowledgement before any subscription is sent
+	conn.SetReadDeadline(time.Now().Add(upstreamAckTimeout))
+	for {
+		var msg wsMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("no connection_ack from %s: %w", wsURL, err)
+		}
+		if msg.Type == "connection_ack" {
+			break
+		}
+		if msg.Type == "ping" {
+			conn.WriteJSON(wsMessage{Type: "pong"})
+		}
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	return &upstreamConn{
+		conn: conn,
+		subs: make(map[string]upstreamHandler),
+	}, nil
+}
+
+// toWebSocketURL converts a service's HTTP GraphQL URL into its WebSocket URL
+func toWebSocketURL(serviceURL string) string {
+	switch {
+	case strings.HasPrefix(serviceURL, "https://"):
+		return "wss://" + strings.TrimPrefix(serviceURL, "https://")
+	case strings.HasPrefix(serviceURL, "http://"):
+		return "ws://" + strings.TrimPrefix(serviceURL, "http://")
+	default:
+		return serviceURL
+	}
+}
+
+// subscribe starts a subscription on the connection and returns its upstream id
+func (c *upstreamConn) subscribe(payload json.RawMessage, handler upstreamHandler) (string, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return "", fmt.Errorf("upstream connection closed")
+	}
+	c.nextID++
+	id := strconv.FormatUint(c.nextID, 10)
+	c.subs[id] = handler
+	c.mu.Unlock()
+
+	if err := c.write(wsMessage{ID: id, Type: "subscribe", Payload: payload}); err != nil {
+		c.mu.Lock()
+		delete(c.subs, id)
+		c.mu.Unlock()
+		return "", err
+	}
+
+	return id, nil
+}
+
+// unsubscribe stops a subscription and closes the connection once it carries none
+func (c *upstreamConn) unsubscribe(id string) {
+	c.mu.Lock()
+	_, active := c.subs[id]
+	delete(c.subs, id)
+	idle := len(c.subs) == 0
+	c.mu.Unlock()
+
+	if active {
+		c.write(wsMessage{ID: id, Type: "complete"})
+	}
+	if idle {
+		c.close()
+	}
+}
+
+// readLoop dispatches upstream messages to the subscriptions they belong to
+func (c *upstreamConn) readLoop() {
+	defer c.close()
+
+	for {
+		var msg wsMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			if !c.isClosed() {
+				log.Printf("Upstream subscription socket closed: %v", err)
+			}
+			return
+		}
+
+		switch msg.Type {
+		case "next", "error", "complete":
+			c.mu.Lock()
+			handler, ok := c.subs[msg.ID]
+			if ok && msg.Type != "next" {
+				delete(c.subs, msg.ID)
+			}
+			c.mu.Unlock()
+			if ok {
+				handler(msg.Type, msg.Payload)
+			}
+		case "ping":
+			c.write(wsMessage{Type: "pong"})
+		}
+	}
+}
+
+// write sends a message, serializing concurrent writers
+func (c *upstreamConn) write(msg wsMessage) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	return c.conn.WriteJSON(msg)
+}
+
+// isClosed reports whether the connection has been closed
+func (c *upstreamConn) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closed
+}
+
+// close shuts the connection down and fails every subscription still attached to it
+func (c *upstreamConn) close() {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	subs := c.subs
+	c.subs = make(map[string]upstreamHandler)
+	c.mu.Unlock()
+
+	if c.pool != nil {
+		c.pool.remove(c)
+	}
+
+	c.writeMu.Lock()
+	c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	c.writeMu.Unlock()
+	c.conn.Close()
+
+	// Subscriptions cut off by the service end with an error rather than silently
+	for _, handler := range subs {
+		payload, _ := json.Marshal([]map[string]interface{}{
+			{"message": "Subscription service connection lost"},
+		})
+		handler("error", payload)
+	}
+}
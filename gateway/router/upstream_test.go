@@ -0,0 +1,87 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeSubscriptionService acknowledges graphql-transport-ws connections after delay and counts them
+func fakeSubscriptionService(t *testing.T, delay time.Duration, dials *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{Subprotocols: []string{protocolTransportWS}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dials.Add(1)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var init wsMessage
+		if err := conn.ReadJSON(&init); err != nil {
+			return
+		}
+		time.Sleep(delay)
+		if err := conn.WriteJSON(wsMessage{Type: "connection_ack"}); err != nil {
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestUpstreamPoolDialsOutsideTheLock(t *testing.T) {
+	var slowDials, fastDials atomic.Int32
+	slow := fakeSubscriptionService(t, 500*time.Millisecond, &slowDials)
+	fast := fakeSubscriptionService(t, 0, &fastDials)
+
+	pool := newUpstreamPool()
+	defer pool.closeAll()
+
+	// Callers of the slow service share its single dial
+	var wg sync.WaitGroup
+	conns := make([]*upstreamConn, 5)
+	for i := range conns {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conn, err := pool.get(slow.URL, http.Header{}, nil)
+			if err != nil {
+				t.Error(err)
+			}
+			conns[i] = conn
+		}(i)
+	}
+
+	// Meanwhile another service is dialed without waiting for the slow one
+	time.Sleep(100 * time.Millisecond)
+	start := time.Now()
+	if _, err := pool.get(fast.URL, http.Header{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Fatalf("dialing the fast service waited %s for the slow one", elapsed)
+	}
+
+	wg.Wait()
+	if n := slowDials.Load(); n != 1 {
+		t.Fatalf("expected one dial of the slow service, got %d", n)
+	}
+	for _, conn := range conns {
+		if conn == nil || conn != conns[0] {
+			t.Fatal("expected every caller to get the same connection")
+		}
+	}
+}
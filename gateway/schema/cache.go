@@ -0,0 +1,77 @@
+package schema
+
+import (
+	"strconv"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// cacheControlDirective is the Apollo cache hint directive services can put on types and fields
+const cacheControlDirective = "cacheControl"
+
+// CacheHint is a @cacheControl(maxAge:, scope:) hint from a service schema
+type CacheHint struct {
+	MaxAge  int  // Seconds a response may be cached
+	Private bool // scope: PRIVATE, the response may only be cached per user
+}
+
+// buildCacheHints collects the @cacheControl hints of all federation SDLs, keyed by "Type" and "Type.field"
+// When services disagree, the shorter max age and the private scope win
+func buildCacheHints(sdls map[string]*serviceSDL) map[string]CacheHint {
+	hints := make(map[string]CacheHint)
+	add := func(key string, directives ast.DirectiveList) {
+		hint, ok := cacheHint(directives)
+		if !ok {
+			return
+		}
+		if existing, seen := hints[key]; seen {
+			if existing.MaxAge < hint.MaxAge {
+				hint.MaxAge = existing.MaxAge
+			}
+			hint.Private = hint.Private || existing.Private
+		}
+		hints[key] = hint
+	}
+
+	for _, sdl := range sdls {
+		for _, defs := range []ast.DefinitionList{sdl.Document.Definitions, sdl.Document.Extensions} {
+			for _, def := range defs {
+				add(def.Name, def.Directives)
+				for _, field := range def.Fields {
+					add(def.Name+"."+field.Name, field.Directives)
+				}
+			}
+		}
+	}
+	return hints
+}
+
+// cacheHint reads the @cacheControl directive of a type or field
+func cacheHint(directives ast.DirectiveList) (CacheHint, bool) {
+	directive := directives.ForName(cacheControlDirective)
+	if directive == nil {
+		return CacheHint{}, false
+	}
+
+	var hint CacheHint
+	if arg := directive.Arguments.ForName("maxAge"); arg != nil && arg.Value != nil {
+		hint.MaxAge, _ = strconv.Atoi(arg.Value.Raw)
+	}
+	if arg := directive.Arguments.ForName("scope"); arg != nil && arg.Value != nil {
+		hint.Private = arg.Value.Raw == "PRIVATE"
+	}
+	return hint, true
+}
+
+// GetCacheHint returns the hint of a field, or of a type when fieldName is empty
+func (m *Manager) GetCacheHint(typeName, fieldName string) (CacheHint, bool) {
+	m.RouteLock.RLock()
+	defer m.RouteLock.RUnlock()
+
+	key := typeName
+	if fieldName != "" {
+		key += "." + fieldName
+	}
+	hint, ok := m.CacheHints[key]
+	return hint, ok
+}
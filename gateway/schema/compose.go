@@ -0,0 +1,352 @@
+package schema
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// Composition modes
+const (
+	// CompositionStrict rejects a composition with conflicts: startup fails and refreshes keep the previous schema
+	CompositionStrict = "strict"
+
+	// CompositionPermissive resolves conflicts by service precedence and logs them
+	CompositionPermissive = "permissive"
+)
+
+// Conflict kinds
+const (
+	ConflictRootField = "root_field" // Root field exposed by more than one service
+	ConflictType      = "type"       // Type defined differently by more than one service
+)
+
+// CompositionConfig controls how conflicts between service schemas are handled
+type CompositionConfig struct {
+	Mode       string   // CompositionStrict or CompositionPermissive
+	Precedence []string // Service names in priority order; unlisted services follow in configuration order
+}
+
+// Conflict describes a root field or type that several services define
+type Conflict struct {
+	Kind     string   `json:"kind"`     // ConflictRootField or ConflictType
+	Name     string   `json:"name"`     // Root field as Type.field, or the type name
+	Services []string `json:"services"` // Services defining it, in precedence order
+	Detail   string   `json:"detail"`   // What differs between the definitions
+	Winner   string   `json:"winner"`   // Service whose definition is used in permissive mode
+}
+
+// String formats a conflict for logs and startup errors
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s %s defined by %s: %s (using %s)", c.Kind, c.Name, strings.Join(c.Services, ", "), c.Detail, c.Winner)
+}
+
+// CompositionError is returned when strict composition finds conflicts
+type CompositionError struct {
+	Conflicts []Conflict
+}
+
+// Error lists every conflict found during composition
+func (e *CompositionError) Error() string {
+	lines := make([]string, len(e.Conflicts))
+	for i, conflict := range e.Conflicts {
+		lines[i] = "  - " + conflict.String()
+	}
+	return fmt.Sprintf("schema composition found %d conflicts:\n%s", len(e.Conflicts), strings.Join(lines, "\n"))
+}
+
+// Strict reports whether conflicts must fail composition
+func (c CompositionConfig) Strict() bool {
+	return strings.EqualFold(c.Mode, CompositionStrict)
+}
+
+// ValidateComposition compares root field signatures and type shapes across the cached service schemas
+func (m *Manager) ValidateComposition() []Conflict {
+	services := m.orderedServices()
+	entities := buildEntities(m.federationSDL)
+
+	var conflicts []Conflict
+
+	// Root fields are routed by name, so a name shared by two services is ambiguous
+	rootOwners := make(map[string][]string)
+	rootSignatures := make(map[string]map[string]string)
+	rootTypes := make(map[string]string)
+	for _, name := range services {
+		for _, typeObj := range m.SchemaCache[name].Data.Schema.Types {
+			if !isRootType(typeObj.Name) {
+				continue
+			}
+			for _, fieldObj := range fieldObjects(typeObj.Fields) {
+				fieldName, _ := fieldObj["name"].(string)
+				if fieldName == "" || isFederationField(fieldName) {
+					continue
+				}
+				if rootSignatures[fieldName] == nil {
+					rootSignatures[fieldName] = make(map[string]string)
+					rootTypes[fieldName] = typeObj.Name
+				}
+				if _, seen := rootSignatures[fieldName][name]; !seen {
+					rootOwners[fieldName] = append(rootOwners[fieldName], name)
+				}
+				rootSignatures[fieldName][name] = typeObj.Name + "." + fieldSignature(fieldObj)
+			}
+		}
+	}
+
+	for fieldName, owners := range rootOwners {
+		if len(owners) < 2 {
+			continue
+		}
+		detail := "exposed by several services with identical signatures"
+		if differing := distinctSignatures(owners, rootSignatures[fieldName]); differing != "" {
+			detail = "signatures differ: " + differing
+		}
+		conflicts = append(conflicts, Conflict{
+			Kind:     ConflictRootField,
+			Name:     rootTypes[fieldName] + "." + fieldName,
+			Services: owners,
+			Detail:   detail,
+			Winner:   owners[0],
+		})
+	}
+
+	// Shared types must have the same shape unless federation merges them
+	typeOwners := make(map[string][]string)
+	typeShapes := make(map[string]map[string]map[string]string)
+	for _, name := range services {
+		for _, typeObj := range m.SchemaCache[name].Data.Schema.Types {
+			if isRootType(typeObj.Name) || internalTypes[typeObj.Name] || strings.HasPrefix(typeObj.Name, "__") {
+				continue
+			}
+			if _, ok := entities[typeObj.Name]; ok {
+				continue
+			}
+			if typeShapes[typeObj.Name] == nil {
+				typeShapes[typeObj.Name] = make(map[string]map[string]string)
+			}
+			typeOwners[typeObj.Name] = append(typeOwners[typeObj.Name], name)
+			typeShapes[typeObj.Name][name] = typeShape(typeObj)
+		}
+	}
+
+	for typeName, owners := range typeOwners {
+		if len(owners) < 2 {
+			continue
+		}
+		reference := typeShapes[typeName][owners[0]]
+		var details []string
+		for _, owner := range owners[1:] {
+			if members := diffShapes(reference, typeShapes[typeName][owner]); len(members) > 0 {
+				details = append(details, fmt.Sprintf("%s differs from %s on %s", owner, owners[0], strings.Join(members, ", ")))
+			}
+		}
+		if len(details) == 0 {
+			continue
+		}
+		conflicts = append(conflicts, Conflict{
+			Kind:     ConflictType,
+			Name:     typeName,
+			Services: owners,
+			Detail:   strings.Join(details, "; "),
+			Winner:   owners[0],
+		})
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Kind != conflicts[j].Kind {
+			return conflicts[i].Kind < conflicts[j].Kind
+		}
+		return conflicts[i].Name < conflicts[j].Name
+	})
+
+	return conflicts
+}
+
+// checkComposition validates the cached schemas, logging conflicts and failing in strict mode
+func (m *Manager) checkComposition() ([]Conflict, error) {
+	conflicts := m.ValidateComposition()
+	for _, conflict := range conflicts {
+		log.Printf("Composition conflict: %s", conflict)
+	}
+
+	if len(conflicts) > 0 && m.Composition.Strict() {
+		return conflicts, &CompositionError{Conflicts: conflicts}
+	}
+	return conflicts, nil
+}
+
+// orderedServices returns the names of cached services, highest precedence first
+func (m *Manager) orderedServices() []string {
+	names := make([]string, 0, len(m.SchemaCache))
+	seen := make(map[string]bool, len(m.SchemaCache))
+
+	add := func(name string) {
+		if _, ok := m.SchemaCache[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+
+	for _, name := range m.Composition.Precedence {
+		add(name)
+	}
+	for _, svc := range m.Services {
+		add(svc.Name)
+	}
+
+	// Services cached under a name that is no longer configured come last
+	var rest []string
+	for name := range m.SchemaCache {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+
+	return append(names, rest...)
+}
+
+// precedence returns the rank of a service URL, lower ranks win conflicts
+func (m *Manager) precedence(url string) int {
+	name := ""
+	for _, svc := range m.Services {
+		if svc.URL == url {
+			name = svc.Name
+			break
+		}
+	}
+
+	for i, preferred := range m.Composition.Precedence {
+		if preferred == name {
+			return i
+		}
+	}
+	for i, svc := range m.Services {
+		if svc.Name == name {
+			return len(m.Composition.Precedence) + i
+		}
+	}
+	return len(m.Composition.Precedence) + len(m.Services)
+}
+
+// isRootType reports whether a type is one of the root operation types
+func isRootType(typeName string) bool {
+	return typeName == "Query" || typeName == "Mutation" || typeName == "Subscription"
+}
+
+// fieldObjects returns the introspected field objects of a field list
+func fieldObjects(fields interface{}) []map[string]interface{} {
+	list, _ := fields.([]interface{})
+	objects := make([]map[string]interface{}, 0, len(list))
+	for _, field := range list {
+		if fieldObj, ok := field.(map[string]interface{}); ok {
+			objects = append(objects, fieldObj)
+		}
+	}
+	return objects
+}
+
+// fieldSignature renders a field with its arguments and type, e.g. user(id: ID!): User
+func fieldSignature(fieldObj map[string]interface{}) string {
+	name, _ := fieldObj["name"].(string)
+
+	var args []string
+	for _, arg := range fieldObjects(fieldObj["args"]) {
+		argName, _ := arg["name"].(string)
+		args = append(args, argName+": "+typeRefString(arg["type"]))
+	}
+	sort.Strings(args)
+
+	signature := name
+	if len(args) > 0 {
+		signature += "(" + strings.Join(args, ", ") + ")"
+	}
+	if typeRef, ok := fieldObj["type"]; ok {
+		signature += ": " + typeRefString(typeRef)
+	}
+	return signature
+}
+
+// typeRefString renders an introspected type reference in SDL notation, e.g. [User!]!
+func typeRefString(typeRef interface{}) string {
+	typeObj, ok := typeRef.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	switch typeObj["kind"] {
+	case "NON_NULL":
+		return typeRefString(typeObj["ofType"]) + "!"
+	case "LIST":
+		return "[" + typeRefString(typeObj["ofType"]) + "]"
+	default:
+		name, _ := typeObj["name"].(string)
+		return name
+	}
+}
+
+// typeShape indexes the members of a type by name, with their signature as value
+// Descriptions and deprecation are ignored, they do not affect compatibility
+func typeShape(typeObj SchemaType) map[string]string {
+	shape := map[string]string{"kind": typeObj.Kind}
+
+	for _, field := range fieldObjects(typeObj.Fields) {
+		name, _ := field["name"].(string)
+		shape["field "+name] = fieldSignature(field)
+	}
+	for _, field := range fieldObjects(typeObj.InputFields) {
+		name, _ := field["name"].(string)
+		shape["input "+name] = fieldSignature(field)
+	}
+	for _, value := range fieldObjects(typeObj.EnumValues) {
+		name, _ := value["name"].(string)
+		shape["value "+name] = name
+	}
+	for _, ref := range fieldObjects(typeObj.Interfaces) {
+		shape["implements "+typeRefString(ref)] = ""
+	}
+	for _, ref := range fieldObjects(typeObj.PossibleTypes) {
+		shape["member "+typeRefString(ref)] = ""
+	}
+
+	return shape
+}
+
+// diffShapes returns the sorted members that are missing from one shape or differ between them
+func diffShapes(a, b map[string]string) []string {
+	var members []string
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			members = append(members, key)
+		}
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			members = append(members, key)
+		}
+	}
+	sort.Strings(members)
+	return members
+}
+
+// distinctSignatures describes the signatures of services when they are not all identical
+func distinctSignatures(owners []string, signatures map[string]string) string {
+	first := signatures[owners[0]]
+	same := true
+	for _, owner := range owners[1:] {
+		if signatures[owner] != first {
+			same = false
+			break
+		}
+	}
+	if same {
+		return ""
+	}
+
+	parts := make([]string, len(owners))
+	for i, owner := range owners {
+		parts[i] = owner + " " + signatures[owner]
+	}
+	return strings.Join(parts, ", ")
+}
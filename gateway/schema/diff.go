@@ -0,0 +1,283 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// Change types reported by DiffSDL
+const (
+	TypeAdded             = "TYPE_ADDED"
+	TypeRemoved           = "TYPE_REMOVED"
+	TypeKindChanged       = "TYPE_KIND_CHANGED"
+	FieldAdded            = "FIELD_ADDED"
+	FieldRemoved          = "FIELD_REMOVED"
+	FieldTypeChanged      = "FIELD_TYPE_CHANGED"
+	ArgumentAdded         = "ARGUMENT_ADDED"
+	ArgumentRemoved       = "ARGUMENT_REMOVED"
+	ArgumentTypeChanged   = "ARGUMENT_TYPE_CHANGED"
+	EnumValueAdded        = "ENUM_VALUE_ADDED"
+	EnumValueRemoved      = "ENUM_VALUE_REMOVED"
+	UnionMemberAdded      = "UNION_MEMBER_ADDED"
+	UnionMemberRemoved    = "UNION_MEMBER_REMOVED"
+	InterfaceAdded        = "INTERFACE_ADDED"
+	InterfaceRemoved      = "INTERFACE_REMOVED"
+	FieldDeprecated       = "FIELD_DEPRECATED"
+	EnumValueDeprecated   = "ENUM_VALUE_DEPRECATED"
+	InputFieldAdded       = "INPUT_FIELD_ADDED"
+	InputFieldRemoved     = "INPUT_FIELD_REMOVED"
+	InputFieldTypeChanged = "INPUT_FIELD_TYPE_CHANGED"
+)
+
+// Change is a single difference between two schema versions
+type Change struct {
+	Type        string `json:"type"`        // One of the change type constants
+	Path        string `json:"path"`        // Type, Type.field or Type.field(arg)
+	Description string `json:"description"` // Human readable summary
+	Breaking    bool   `json:"breaking"`    // Existing clients may fail after this change
+}
+
+// SchemaDiff lists the changes between two schema versions
+type SchemaDiff struct {
+	Changes  []Change `json:"changes"`
+	Breaking bool     `json:"breaking"` // At least one change is breaking
+}
+
+// DiffSDL compares two SDL documents and classifies each change as breaking or safe
+func DiffSDL(oldSDL, newSDL string) (*SchemaDiff, error) {
+	oldTypes, err := sdlTypes(oldSDL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse old schema: %w", err)
+	}
+	newTypes, err := sdlTypes(newSDL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse new schema: %w", err)
+	}
+
+	d := &SchemaDiff{Changes: []Change{}}
+
+	for _, name := range sortedKeys(oldTypes) {
+		oldDef := oldTypes[name]
+		newDef, ok := newTypes[name]
+		if !ok {
+			d.add(TypeRemoved, name, fmt.Sprintf("Type %s was removed", name), true)
+			continue
+		}
+		if oldDef.Kind != newDef.Kind {
+			d.add(TypeKindChanged, name, fmt.Sprintf("Type %s changed from %s to %s", name, oldDef.Kind, newDef.Kind), true)
+			continue
+		}
+		d.diffDefinition(oldDef, newDef)
+	}
+	for _, name := range sortedKeys(newTypes) {
+		if _, ok := oldTypes[name]; !ok {
+			d.add(TypeAdded, name, fmt.Sprintf("Type %s was added", name), false)
+		}
+	}
+
+	return d, nil
+}
+
+// add appends a change and updates the breaking flag
+func (d *SchemaDiff) add(changeType, path, description string, breaking bool) {
+	d.Changes = append(d.Changes, Change{Type: changeType, Path: path, Description: description, Breaking: breaking})
+	if breaking {
+		d.Breaking = true
+	}
+}
+
+// diffDefinition compares two definitions of the same name and kind
+func (d *SchemaDiff) diffDefinition(oldDef, newDef *ast.Definition) {
+	switch oldDef.Kind {
+	case ast.Object, ast.Interface:
+		d.diffOutputFields(oldDef, newDef)
+		d.diffNames(oldDef.Name, oldDef.Interfaces, newDef.Interfaces, InterfaceRemoved, InterfaceAdded, "interface")
+
+	case ast.InputObject:
+		d.diffInputFields(oldDef, newDef)
+
+	case ast.Enum:
+		oldValues := make([]string, 0, len(oldDef.EnumValues))
+		for _, value := range oldDef.EnumValues {
+			oldValues = append(oldValues, value.Name)
+			if newValue := newDef.EnumValues.ForName(value.Name); newValue != nil &&
+				value.Directives.ForName("deprecated") == nil && newValue.Directives.ForName("deprecated") != nil {
+				d.add(EnumValueDeprecated, oldDef.Name+"."+value.Name, fmt.Sprintf("Enum value %s.%s was deprecated", oldDef.Name, value.Name), false)
+			}
+		}
+		newValues := make([]string, 0, len(newDef.EnumValues))
+		for _, value := range newDef.EnumValues {
+			newValues = append(newValues, value.Name)
+		}
+		d.diffNames(oldDef.Name, oldValues, newValues, EnumValueRemoved, EnumValueAdded, "enum value")
+
+	case ast.Union:
+		d.diffNames(oldDef.Name, oldDef.Types, newDef.Types, UnionMemberRemoved, UnionMemberAdded, "union member")
+	}
+}
+
+// diffOutputFields compares fields and arguments of object and interface types
+func (d *SchemaDiff) diffOutputFields(oldDef, newDef *ast.Definition) {
+	for _, oldField := range oldDef.Fields {
+		path := oldDef.Name + "." + oldField.Name
+		newField := newDef.Fields.ForName(oldField.Name)
+		if newField == nil {
+			d.add(FieldRemoved, path, fmt.Sprintf("Field %s was removed", path), true)
+			continue
+		}
+
+		if oldField.Type.String() != newField.Type.String() {
+			d.add(FieldTypeChanged, path, fmt.Sprintf("Field %s changed type from %s to %s", path, oldField.Type, newField.Type),
+				!safeOutputChange(oldField.Type, newField.Type))
+		}
+		if oldField.Directives.ForName("deprecated") == nil && newField.Directives.ForName("deprecated") != nil {
+			d.add(FieldDeprecated, path, fmt.Sprintf("Field %s was deprecated", path), false)
+		}
+
+		for _, oldArg := range oldField.Arguments {
+			argPath := fmt.Sprintf("%s(%s)", path, oldArg.Name)
+			newArg := newField.Arguments.ForName(oldArg.Name)
+			if newArg == nil {
+				d.add(ArgumentRemoved, argPath, fmt.Sprintf("Argument %s was removed", argPath), true)
+				continue
+			}
+			if oldArg.Type.String() != newArg.Type.String() {
+				d.add(ArgumentTypeChanged, argPath, fmt.Sprintf("Argument %s changed type from %s to %s", argPath, oldArg.Type, newArg.Type),
+					!safeInputChange(oldArg.Type, newArg.Type))
+			}
+		}
+		for _, newArg := range newField.Arguments {
+			if oldField.Arguments.ForName(newArg.Name) != nil {
+				continue
+			}
+			argPath := fmt.Sprintf("%s(%s)", path, newArg.Name)
+			required := newArg.Type.NonNull && newArg.DefaultValue == nil
+			description := fmt.Sprintf("Optional argument %s was added", argPath)
+			if required {
+				description = fmt.Sprintf("Required argument %s was added", argPath)
+			}
+			d.add(ArgumentAdded, argPath, description, required)
+		}
+	}
+
+	for _, newField := range newDef.Fields {
+		if oldDef.Fields.ForName(newField.Name) == nil {
+			path := newDef.Name + "." + newField.Name
+			d.add(FieldAdded, path, fmt.Sprintf("Field %s was added", path), false)
+		}
+	}
+}
+
+// diffInputFields compares fields of input object types
+func (d *SchemaDiff) diffInputFields(oldDef, newDef *ast.Definition) {
+	for _, oldField := range oldDef.Fields {
+		path := oldDef.Name + "." + oldField.Name
+		newField := newDef.Fields.ForName(oldField.Name)
+		if newField == nil {
+			d.add(InputFieldRemoved, path, fmt.Sprintf("Input field %s was removed", path), true)
+			continue
+		}
+		if oldField.Type.String() != newField.Type.String() {
+			d.add(InputFieldTypeChanged, path, fmt.Sprintf("Input field %s changed type from %s to %s", path, oldField.Type, newField.Type),
+				!safeInputChange(oldField.Type, newField.Type))
+		}
+	}
+
+	for _, newField := range newDef.Fields {
+		if oldDef.Fields.ForName(newField.Name) != nil {
+			continue
+		}
+		path := newDef.Name + "." + newField.Name
+		required := newField.Type.NonNull && newField.DefaultValue == nil
+		description := fmt.Sprintf("Optional input field %s was added", path)
+		if required {
+			description = fmt.Sprintf("Required input field %s was added", path)
+		}
+		d.add(InputFieldAdded, path, description, required)
+	}
+}
+
+// diffNames reports members removed from or added to a list of names
+func (d *SchemaDiff) diffNames(typeName string, oldNames, newNames []string, removed, added, label string) {
+	oldSet := make(map[string]bool, len(oldNames))
+	for _, name := range oldNames {
+		oldSet[name] = true
+	}
+	newSet := make(map[string]bool, len(newNames))
+	for _, name := range newNames {
+		newSet[name] = true
+	}
+
+	for _, name := range oldNames {
+		if !newSet[name] {
+			d.add(removed, typeName+"."+name, fmt.Sprintf("The %s %s was removed from %s", label, name, typeName), true)
+		}
+	}
+	for _, name := range newNames {
+		if !oldSet[name] {
+			// New enum values and union members can surprise exhaustive client code, but do not break queries
+			d.add(added, typeName+"."+name, fmt.Sprintf("The %s %s was added to %s", label, name, typeName), false)
+		}
+	}
+}
+
+// safeOutputChange reports whether clients reading a field of the old type can read the new type
+// Output types may only become stricter: nullable to non-null
+func safeOutputChange(oldType, newType *ast.Type) bool {
+	if newType.NonNull && !oldType.NonNull {
+		inner := *newType
+		inner.NonNull = false
+		return safeOutputChange(oldType, &inner)
+	}
+	if oldType.NonNull != newType.NonNull {
+		return false
+	}
+	if oldType.Elem != nil || newType.Elem != nil {
+		return oldType.Elem != nil && newType.Elem != nil && safeOutputChange(oldType.Elem, newType.Elem)
+	}
+	return oldType.NamedType == newType.NamedType
+}
+
+// safeInputChange reports whether values valid for the old input type are valid for the new type
+// Input types may only become looser: non-null to nullable
+func safeInputChange(oldType, newType *ast.Type) bool {
+	if oldType.NonNull && !newType.NonNull {
+		inner := *oldType
+		inner.NonNull = false
+		return safeInputChange(&inner, newType)
+	}
+	if oldType.NonNull != newType.NonNull {
+		return false
+	}
+	if oldType.Elem != nil || newType.Elem != nil {
+		return oldType.Elem != nil && newType.Elem != nil && safeInputChange(oldType.Elem, newType.Elem)
+	}
+	return oldType.NamedType == newType.NamedType
+}
+
+// sdlTypes parses an SDL document and indexes its type definitions by name
+func sdlTypes(sdl string) (map[string]*ast.Definition, error) {
+	doc, err := parser.ParseSchema(&ast.Source{Input: sdl})
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[string]*ast.Definition, len(doc.Definitions))
+	for _, def := range doc.Definitions {
+		types[def.Name] = def
+	}
+	return types, nil
+}
+
+// sortedKeys returns the keys of a definition map in alphabetical order
+func sortedKeys(types map[string]*ast.Definition) []string {
+	keys := make([]string, 0, len(types))
+	for name := range types {
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,224 @@
+package schema
+
+import (
+	"log"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// builtinScalars and builtinDirectives are part of every GraphQL schema and left out of the exported SDL
+var (
+	builtinScalars    = map[string]bool{"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true}
+	builtinDirectives = map[string]bool{"skip": true, "include": true, "deprecated": true, "specifiedBy": true, "oneOf": true, "defer": true}
+)
+
+// buildExecutableSchema converts the merged introspection result into an in-memory schema
+// The gateway executes introspection queries against it instead of returning the raw result
+func buildExecutableSchema(merged *SchemaResponse) *ast.Schema {
+	schema := &ast.Schema{
+		Types:         make(map[string]*ast.Definition),
+		Directives:    make(map[string]*ast.DirectiveDefinition),
+		PossibleTypes: make(map[string][]*ast.Definition),
+		Implements:    make(map[string][]*ast.Definition),
+	}
+
+	for _, typeObj := range merged.Data.Schema.Types {
+		def := &ast.Definition{
+			Kind:        ast.DefinitionKind(typeObj.Kind),
+			Name:        typeObj.Name,
+			Description: typeObj.Description,
+			BuiltIn:     strings.HasPrefix(typeObj.Name, "__") || builtinScalars[typeObj.Name],
+		}
+
+		if def.Kind == ast.InputObject {
+			for _, field := range fieldObjects(typeObj.InputFields) {
+				def.Fields = append(def.Fields, inputFieldDefinition(field))
+			}
+		} else {
+			for _, field := range fieldObjects(typeObj.Fields) {
+				def.Fields = append(def.Fields, fieldDefinition(field))
+			}
+		}
+
+		for _, ref := range fieldObjects(typeObj.Interfaces) {
+			if name, ok := ref["name"].(string); ok {
+				def.Interfaces = append(def.Interfaces, name)
+			}
+		}
+		for _, ref := range fieldObjects(typeObj.PossibleTypes) {
+			if name, ok := ref["name"].(string); ok && def.Kind == ast.Union {
+				def.Types = append(def.Types, name)
+			}
+		}
+		for _, value := range fieldObjects(typeObj.EnumValues) {
+			name, _ := value["name"].(string)
+			description, _ := value["description"].(string)
+			def.EnumValues = append(def.EnumValues, &ast.EnumValueDefinition{
+				Name:        name,
+				Description: description,
+				Directives:  deprecation(value),
+			})
+		}
+
+		schema.AddTypes(def)
+	}
+
+	// Index interface implementations and union members for possibleTypes
+	for _, def := range schema.Types {
+		for _, iface := range def.Interfaces {
+			if ifaceDef, ok := schema.Types[iface]; ok {
+				schema.AddImplements(def.Name, ifaceDef)
+				schema.AddPossibleType(iface, def)
+			}
+		}
+		for _, member := range def.Types {
+			if memberDef, ok := schema.Types[member]; ok {
+				schema.AddPossibleType(def.Name, memberDef)
+			}
+		}
+	}
+
+	schema.Query = schema.Types[merged.Data.Schema.QueryType["name"]]
+	schema.Mutation = schema.Types[merged.Data.Schema.MutationType["name"]]
+	schema.Subscription = schema.Types[merged.Data.Schema.SubscriptionType["name"]]
+
+	for _, directive := range fieldObjects(merged.Data.Schema.Directives) {
+		name, _ := directive["name"].(string)
+		description, _ := directive["description"].(string)
+		repeatable, _ := directive["isRepeatable"].(bool)
+		def := &ast.DirectiveDefinition{
+			Name:         name,
+			Description:  description,
+			IsRepeatable: repeatable,
+			Position:     &ast.Position{Src: &ast.Source{Name: "gateway", BuiltIn: builtinDirectives[name]}},
+		}
+		for _, arg := range fieldObjects(directive["args"]) {
+			def.Arguments = append(def.Arguments, argumentDefinition(arg))
+		}
+		if locations, ok := directive["locations"].([]interface{}); ok {
+			for _, location := range locations {
+				if loc, ok := location.(string); ok {
+					def.Locations = append(def.Locations, ast.DirectiveLocation(loc))
+				}
+			}
+		}
+		schema.Directives[name] = def
+	}
+
+	return schema
+}
+
+// fieldDefinition converts an introspected output field
+func fieldDefinition(field map[string]interface{}) *ast.FieldDefinition {
+	name, _ := field["name"].(string)
+	description, _ := field["description"].(string)
+
+	def := &ast.FieldDefinition{
+		Name:        name,
+		Description: description,
+		Type:        astType(field["type"]),
+		Directives:  deprecation(field),
+	}
+	for _, arg := range fieldObjects(field["args"]) {
+		def.Arguments = append(def.Arguments, argumentDefinition(arg))
+	}
+	return def
+}
+
+// inputFieldDefinition converts an introspected input object field
+func inputFieldDefinition(field map[string]interface{}) *ast.FieldDefinition {
+	arg := argumentDefinition(field)
+	return &ast.FieldDefinition{
+		Name:         arg.Name,
+		Description:  arg.Description,
+		Type:         arg.Type,
+		DefaultValue: arg.DefaultValue,
+		Directives:   arg.Directives,
+	}
+}
+
+// argumentDefinition converts an introspected __InputValue
+func argumentDefinition(arg map[string]interface{}) *ast.ArgumentDefinition {
+	name, _ := arg["name"].(string)
+	description, _ := arg["description"].(string)
+
+	def := &ast.ArgumentDefinition{
+		Name:        name,
+		Description: description,
+		Type:        astType(arg["type"]),
+		Directives:  deprecation(arg),
+	}
+	if literal, ok := arg["defaultValue"].(string); ok && literal != "" {
+		def.DefaultValue = parseLiteral(literal)
+	}
+	return def
+}
+
+// astType converts an introspected type reference into an AST type
+func astType(typeRef interface{}) *ast.Type {
+	typeObj, ok := typeRef.(map[string]interface{})
+	if !ok {
+		return ast.NamedType("String", nil)
+	}
+
+	switch typeObj["kind"] {
+	case "NON_NULL":
+		inner := astType(typeObj["ofType"])
+		inner.NonNull = true
+		return inner
+	case "LIST":
+		return ast.ListType(astType(typeObj["ofType"]), nil)
+	default:
+		name, _ := typeObj["name"].(string)
+		return ast.NamedType(name, nil)
+	}
+}
+
+// deprecation turns introspected deprecation flags back into a @deprecated directive
+func deprecation(member map[string]interface{}) ast.DirectiveList {
+	if deprecated, _ := member["isDeprecated"].(bool); !deprecated {
+		return nil
+	}
+
+	directive := &ast.Directive{Name: "deprecated"}
+	if reason, ok := member["deprecationReason"].(string); ok {
+		directive.Arguments = ast.ArgumentList{
+			{Name: "reason", Value: &ast.Value{Kind: ast.StringValue, Raw: reason}},
+		}
+	}
+	return ast.DirectiveList{directive}
+}
+
+// parseLiteral parses a GraphQL value literal such as a default value from introspection
+func parseLiteral(literal string) *ast.Value {
+	doc, err := parser.ParseQuery(&ast.Source{Input: "{ f(v: " + literal + ") }"})
+	if err != nil || len(doc.Operations) == 0 {
+		log.Printf("Failed to parse default value %q: %v", literal, err)
+		return nil
+	}
+
+	field, ok := doc.Operations[0].SelectionSet[0].(*ast.Field)
+	if !ok || len(field.Arguments) == 0 {
+		return nil
+	}
+	return field.Arguments[0].Value
+}
+
+// GetSchema returns the executable form of the merged schema
+// Before any service schema was merged it returns a schema with an empty Query type
+func (m *Manager) GetSchema() *ast.Schema {
+	m.RouteLock.RLock()
+	defer m.RouteLock.RUnlock()
+
+	if m.Executable == nil {
+		query := &ast.Definition{Kind: ast.Object, Name: "Query"}
+		return &ast.Schema{
+			Query:      query,
+			Types:      map[string]*ast.Definition{query.Name: query},
+			Directives: map[string]*ast.DirectiveDefinition{},
+		}
+	}
+	return m.Executable
+}
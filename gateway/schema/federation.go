@@ -0,0 +1,280 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// Federation root fields and types that are used between the gateway and services only
+const (
+	serviceField  = "_service"
+	entitiesField = "_entities"
+
+	// DefaultEntityResolver is the batch lookup field used on the owning service when no resolver is declared
+	DefaultEntityResolver = "nodes"
+)
+
+// internalTypes are federation types hidden from the merged schema
+var internalTypes = map[string]bool{
+	"_Service": true,
+	"_Any":     true,
+	"_Entity":  true,
+}
+
+// Entity describes a federated type whose fields can be resolved by several services
+type Entity struct {
+	Name         string            // Type name
+	KeyFields    []string          // Fields identifying an instance, from @key(fields:)
+	OwnerURL     string            // Service that defines the base type
+	Resolver     string            // Batch lookup field on the owner, from @key(resolver:)
+	FieldOwners  map[string]string // Field name → URL of the service that resolves it
+	EntitiesURLs map[string]bool   // Services exposing the _entities root field
+}
+
+// serviceSDL holds the federation SDL reported by one service
+type serviceSDL struct {
+	URL         string
+	Document    *ast.SchemaDocument
+	HasEntities bool // Service exposes _entities(representations:)
+}
+
+// serviceResponseSDL is the response of the { _service { sdl } } query
+type serviceResponseSDL struct {
+	Data struct {
+		Service struct {
+			SDL string `json:"sdl"`
+		} `json:"_service"`
+	} `json:"data"`
+}
+
+// collectFederation fetches and parses the SDL of a service exposing _service
+// Services without the _service root field do not take part in federation
+func (m *Manager) collectFederation(name, url string, schema *SchemaResponse) *serviceSDL {
+	rootFields := rootFieldNames(schema, "Query")
+	if !rootFields[serviceField] {
+		return nil
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewBufferString(`{"query":"{ _service { sdl } }"}`))
+	if err != nil {
+		log.Printf("Failed to fetch federation SDL from %s: %v", name, err)
+		return nil
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Printf("Failed to read federation SDL from %s: %v", name, err)
+		return nil
+	}
+
+	var sdlResp serviceResponseSDL
+	if err := json.Unmarshal(body, &sdlResp); err != nil || sdlResp.Data.Service.SDL == "" {
+		log.Printf("Service %s returned no federation SDL", name)
+		return nil
+	}
+
+	doc, err := parser.ParseSchema(&ast.Source{Name: name, Input: sdlResp.Data.Service.SDL})
+	if err != nil {
+		log.Printf("Failed to parse federation SDL from %s: %v", name, err)
+		return nil
+	}
+
+	log.Printf("Collected federation SDL from %s (%d bytes)", name, len(sdlResp.Data.Service.SDL))
+	return &serviceSDL{
+		URL:         url,
+		Document:    doc,
+		HasEntities: rootFields[entitiesField],
+	}
+}
+
+// buildEntities computes entity ownership from all collected federation SDLs
+func buildEntities(sdls map[string]*serviceSDL) map[string]*Entity {
+	entities := make(map[string]*Entity)
+
+	getEntity := func(typeName string) *Entity {
+		entity, ok := entities[typeName]
+		if !ok {
+			entity = &Entity{
+				Name:         typeName,
+				FieldOwners:  make(map[string]string),
+				EntitiesURLs: make(map[string]bool),
+			}
+			entities[typeName] = entity
+		}
+		return entity
+	}
+
+	for name, sdl := range sdls {
+		// Group base definitions and extensions of each type declared by this service
+		definitions := make(map[string][]*ast.Definition)
+		for _, def := range sdl.Document.Definitions {
+			definitions[def.Name] = append(definitions[def.Name], def)
+		}
+		for _, def := range sdl.Document.Extensions {
+			definitions[def.Name] = append(definitions[def.Name], def)
+		}
+
+		for typeName, defs := range definitions {
+			var keyFields []string
+			resolver := ""
+			owner := false
+
+			for _, def := range defs {
+				if key := def.Directives.ForName("key"); key != nil {
+					if fields := key.Arguments.ForName("fields"); fields != nil && fields.Value != nil {
+						keyFields = strings.Fields(fields.Value.Raw)
+					}
+					if res := key.Arguments.ForName("resolver"); res != nil && res.Value != nil {
+						resolver = res.Value.Raw
+					}
+				}
+				if isBaseDefinition(sdl.Document, def) {
+					owner = true
+				}
+			}
+
+			if len(keyFields) == 0 {
+				continue
+			}
+
+			entity := getEntity(typeName)
+			if sdl.HasEntities {
+				entity.EntitiesURLs[sdl.URL] = true
+			}
+			if owner {
+				entity.OwnerURL = sdl.URL
+				entity.KeyFields = keyFields
+				entity.Resolver = resolver
+			} else if len(entity.KeyFields) == 0 {
+				entity.KeyFields = keyFields
+			}
+
+			for _, def := range defs {
+				for _, field := range def.Fields {
+					if field.Directives.ForName("external") != nil {
+						continue
+					}
+					if existing, ok := entity.FieldOwners[field.Name]; ok && existing != sdl.URL && !owner {
+						log.Printf("Field %s.%s is resolved by several services, keeping %s", typeName, field.Name, existing)
+						continue
+					}
+					entity.FieldOwners[field.Name] = sdl.URL
+				}
+			}
+
+			log.Printf("Entity %s declared by %s (owner: %t, keys: %v)", typeName, name, owner, keyFields)
+		}
+	}
+
+	for typeName, entity := range entities {
+		if entity.OwnerURL == "" {
+			log.Printf("Warning: entity %s is extended but no service owns it", typeName)
+		}
+		if entity.Resolver == "" {
+			entity.Resolver = DefaultEntityResolver
+		}
+	}
+
+	return entities
+}
+
+// isBaseDefinition reports whether a definition declares a type rather than extending it
+func isBaseDefinition(doc *ast.SchemaDocument, def *ast.Definition) bool {
+	if def.Directives.ForName("extends") != nil {
+		return false
+	}
+	for _, ext := range doc.Extensions {
+		if ext == def {
+			return false
+		}
+	}
+	return true
+}
+
+// rootFieldNames returns the field names of a root operation type in an introspection result
+func rootFieldNames(schema *SchemaResponse, typeName string) map[string]bool {
+	names := make(map[string]bool)
+	for _, typeObj := range schema.Data.Schema.Types {
+		if typeObj.Name != typeName {
+			continue
+		}
+		if fields, ok := typeObj.Fields.([]interface{}); ok {
+			for _, field := range fields {
+				if fieldObj, ok := field.(map[string]interface{}); ok {
+					if fieldName, ok := fieldObj["name"].(string); ok {
+						names[fieldName] = true
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// isFederationField reports whether a root field is used only between the gateway and services
+func isFederationField(fieldName string) bool {
+	return fieldName == serviceField || fieldName == entitiesField
+}
+
+// filterFederationFields removes federation root fields from an introspected field list
+func filterFederationFields(fields []interface{}) []interface{} {
+	filtered := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		if fieldObj, ok := field.(map[string]interface{}); ok {
+			if fieldName, ok := fieldObj["name"].(string); ok && isFederationField(fieldName) {
+				continue
+			}
+		}
+		filtered = append(filtered, field)
+	}
+	return filtered
+}
+
+// namedType unwraps NON_NULL and LIST wrappers of an introspected type reference
+func namedType(typeRef interface{}) string {
+	for typeRef != nil {
+		typeObj, ok := typeRef.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		if name, ok := typeObj["name"].(string); ok && name != "" {
+			return name
+		}
+		typeRef = typeObj["ofType"]
+	}
+	return ""
+}
+
+// GetEntity returns the federated entity with the given type name, or nil
+func (m *Manager) GetEntity(typeName string) *Entity {
+	m.RouteLock.RLock()
+	defer m.RouteLock.RUnlock()
+
+	return m.Entities[typeName]
+}
+
+// HasEntities reports whether any service declared a federated entity
+func (m *Manager) HasEntities() bool {
+	m.RouteLock.RLock()
+	defer m.RouteLock.RUnlock()
+
+	return len(m.Entities) > 0
+}
+
+// GetFieldType returns the named type of a field in the merged schema, or "" if unknown
+func (m *Manager) GetFieldType(typeName, fieldName string) string {
+	m.RouteLock.RLock()
+	defer m.RouteLock.RUnlock()
+
+	return m.FieldTypes[typeName][fieldName]
+}
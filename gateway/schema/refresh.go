@@ -0,0 +1,174 @@
+package schema
+
+import (
+	"log"
+	"sort"
+	"time"
+)
+
+// RefreshResult describes how the routing table changed during a schema refresh
+type RefreshResult struct {
+	Services    int        `json:"services"`    // Services whose schema was collected in this refresh
+	Failed      []string   `json:"failed"`      // Services that could not be reached, kept at their last known schema
+	Operations  int        `json:"operations"`  // Operations routed after the refresh
+	Added       []string   `json:"added"`       // Operations routed for the first time
+	Removed     []string   `json:"removed"`     // Operations no longer exposed by any service
+	Changed     []string   `json:"changed"`     // Operations that moved to another service
+	Conflicts   []Conflict `json:"conflicts"`   // Composition conflicts of the collected schemas
+	Rejected    bool       `json:"rejected"`    // Strict composition refused the collected schemas
+	RefreshedAt time.Time  `json:"refreshedAt"` // Time the new routing table was published
+}
+
+// Refresh re-collects every service schema and atomically publishes the new routing table
+// Collection happens on a staging manager so readers never observe a half-built state
+func (m *Manager) Refresh() *RefreshResult {
+	m.refreshMu.Lock()
+	defer m.refreshMu.Unlock()
+
+	log.Println("Refreshing schemas from all services")
+
+	staging := NewManager(m.Services)
+	staging.Composition = m.Composition
+	result := &RefreshResult{}
+
+	m.RouteLock.RLock()
+	previous := m.SchemaCache
+	previousSDL := m.federationSDL
+	m.RouteLock.RUnlock()
+
+	for _, service := range m.Services {
+		if staging.CollectSchema(service.Name, service.URL) {
+			result.Services++
+			continue
+		}
+
+		// Keep serving the last known schema of a service that is temporarily unreachable
+		result.Failed = append(result.Failed, service.Name)
+		if schema, ok := previous[service.Name]; ok {
+			log.Printf("Keeping last known schema of %s", service.Name)
+			staging.SchemaCache[service.Name] = schema
+			if sdl, ok := previousSDL[service.Name]; ok {
+				staging.federationSDL[service.Name] = sdl
+			}
+			staging.UpdateRoutes(service.URL, schema)
+		}
+	}
+
+	if len(staging.SchemaCache) == 0 {
+		log.Println("Warning: schema refresh collected no service, keeping the current routing table")
+		result.RefreshedAt = time.Now()
+		result.Operations = len(m.GetRoutesMap())
+		return result
+	}
+
+	conflicts, err := staging.checkComposition()
+	result.Conflicts = conflicts
+	if err != nil {
+		log.Printf("Schema refresh rejected, keeping the current routing table: %v", err)
+		result.Rejected = true
+		result.RefreshedAt = time.Now()
+		result.Operations = len(m.GetRoutesMap())
+		return result
+	}
+
+	staging.MergeSchemas()
+
+	m.RouteLock.Lock()
+	result.Added, result.Removed, result.Changed = diffRoutes(m.Routes, staging.Routes)
+	m.SchemaCache = staging.SchemaCache
+	m.federationSDL = staging.federationSDL
+	m.Routes = staging.Routes
+	m.MergedSchema = staging.MergedSchema
+	m.Executable = staging.Executable
+	m.Entities = staging.Entities
+	m.FieldTypes = staging.FieldTypes
+	m.CacheHints = staging.CacheHints
+	m.Conflicts = conflicts
+	result.Operations = len(m.Routes)
+	result.RefreshedAt = time.Now()
+	m.RouteLock.Unlock()
+
+	m.recordVersion()
+
+	for _, op := range result.Added {
+		log.Printf("Schema refresh: operation %s added (%s)", op, staging.Routes[op])
+	}
+	for _, op := range result.Removed {
+		log.Printf("Schema refresh: operation %s removed", op)
+	}
+	for _, op := range result.Changed {
+		log.Printf("Schema refresh: operation %s now routed to %s", op, staging.Routes[op])
+	}
+	log.Printf("Schema refresh complete: %d services, %d operations (%d added, %d removed, %d changed)",
+		result.Services, result.Operations, len(result.Added), len(result.Removed), len(result.Changed))
+
+	return result
+}
+
+// StartRefresher re-collects schemas in the background every interval until StopRefresher is called
+func (m *Manager) StartRefresher(interval time.Duration) {
+	if interval <= 0 {
+		log.Println("Schema refresher disabled")
+		return
+	}
+
+	m.refreshMu.Lock()
+	if m.stopRefresh != nil {
+		m.refreshMu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	m.stopRefresh = stop
+	m.refreshMu.Unlock()
+
+	log.Printf("Schema refresher started (interval: %s)", interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.Refresh()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopRefresher stops the background refresher started by StartRefresher
+func (m *Manager) StopRefresher() {
+	m.refreshMu.Lock()
+	defer m.refreshMu.Unlock()
+
+	if m.stopRefresh != nil {
+		close(m.stopRefresh)
+		m.stopRefresh = nil
+		log.Println("Schema refresher stopped")
+	}
+}
+
+// diffRoutes compares two routing tables and returns sorted added, removed and re-owned operations
+func diffRoutes(before, after map[string]string) (added, removed, changed []string) {
+	for op, url := range after {
+		previous, ok := before[op]
+		switch {
+		case !ok:
+			added = append(added, op)
+		case previous != url:
+			changed = append(changed, op)
+		}
+	}
+	for op := range before {
+		if _, ok := after[op]; !ok {
+			removed = append(removed, op)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	return added, removed, changed
+}
@@ -0,0 +1,61 @@
+package schema
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeService serves a one-field schema whose @cacheControl max age can be changed between refreshes
+func fakeService(t *testing.T, maxAge *atomic.Int64) *httptest.Server {
+	t.Helper()
+
+	introspection := `{"data":{"__schema":{"queryType":{"name":"Query"},"types":[
+		{"kind":"OBJECT","name":"Query","fields":[
+			{"name":"posts","args":[],"type":{"kind":"SCALAR","name":"String"}},
+			{"name":"_service","args":[],"type":{"kind":"OBJECT","name":"_Service"}}]},
+		{"kind":"OBJECT","name":"_Service","fields":[
+			{"name":"sdl","args":[],"type":{"kind":"SCALAR","name":"String"}}]},
+		{"kind":"SCALAR","name":"String"}]}}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.Contains(string(body), "_service") {
+			io.WriteString(w, introspection)
+			return
+		}
+
+		sdl := "type Query { posts: String @cacheControl(maxAge: " + strconv.FormatInt(maxAge.Load(), 10) + ") }"
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"_service": map[string]string{"sdl": sdl}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRefreshUpdatesCacheHints(t *testing.T) {
+	var maxAge atomic.Int64
+	maxAge.Store(30)
+	server := fakeService(t, &maxAge)
+
+	m := NewManager([]Service{{Name: "posts", URL: server.URL}})
+	if result := m.Refresh(); result.Services != 1 {
+		t.Fatalf("expected 1 collected service, got %d", result.Services)
+	}
+	if hint, ok := m.GetCacheHint("Query", "posts"); !ok || hint.MaxAge != 30 {
+		t.Fatalf("expected max age 30 after the first refresh, got %+v (found %v)", hint, ok)
+	}
+
+	maxAge.Store(120)
+	m.Refresh()
+	if hint, ok := m.GetCacheHint("Query", "posts"); !ok || hint.MaxAge != 120 {
+		t.Fatalf("expected max age 120 after the hint changed, got %+v (found %v)", hint, ok)
+	}
+}
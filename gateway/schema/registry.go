@@ -0,0 +1,286 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+// ErrVersionNotFound is returned when a schema version does not exist in the registry
+var ErrVersionNotFound = errors.New("schema version not found")
+
+// SchemaVersion is a snapshot of the composed schema
+type SchemaVersion struct {
+	Version   int       `json:"version"`       // Sequential version number, starting at 1
+	Hash      string    `json:"hash"`          // SHA-256 of the SDL
+	CreatedAt time.Time `json:"createdAt"`     // Time the version was composed
+	Services  []string  `json:"services"`      // Services that contributed to the version
+	SDL       string    `json:"sdl,omitempty"` // Composed schema in SDL
+}
+
+// RegistryStore persists schema versions
+type RegistryStore interface {
+	// Save stores a new version
+	Save(version *SchemaVersion) error
+
+	// Get returns a version by number, or ErrVersionNotFound
+	Get(number int) (*SchemaVersion, error)
+
+	// List returns every version in ascending order, without SDL
+	List() ([]*SchemaVersion, error)
+}
+
+// Registry records a new version each time the composed schema changes
+type Registry struct {
+	store RegistryStore
+	mu    sync.Mutex
+}
+
+// NewRegistry creates a registry backed by the given store
+func NewRegistry(store RegistryStore) *Registry {
+	return &Registry{store: store}
+}
+
+// Record stores the SDL as a new version unless it is identical to the latest one
+func (r *Registry) Record(sdl string, services []string) (*SchemaVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(sdl))
+	hash := hex.EncodeToString(sum[:])
+
+	versions, err := r.store.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schema versions: %w", err)
+	}
+
+	next := 1
+	if len(versions) > 0 {
+		latest := versions[len(versions)-1]
+		if latest.Hash == hash {
+			return latest, nil
+		}
+		next = latest.Version + 1
+	}
+
+	version := &SchemaVersion{
+		Version:   next,
+		Hash:      hash,
+		CreatedAt: time.Now().UTC(),
+		Services:  services,
+		SDL:       sdl,
+	}
+	if err := r.store.Save(version); err != nil {
+		return nil, fmt.Errorf("failed to save schema version %d: %w", next, err)
+	}
+
+	log.Printf("Recorded schema version %d (%s)", version.Version, hash[:12])
+	return version, nil
+}
+
+// Versions returns every recorded version without SDL
+func (r *Registry) Versions() ([]*SchemaVersion, error) {
+	return r.store.List()
+}
+
+// Version returns a recorded version; 0 selects the latest one
+func (r *Registry) Version(number int) (*SchemaVersion, error) {
+	if number > 0 {
+		return r.store.Get(number)
+	}
+
+	versions, err := r.store.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, ErrVersionNotFound
+	}
+	return r.store.Get(versions[len(versions)-1].Version)
+}
+
+// recordVersion stores the current composition in the registry, if one is configured
+func (m *Manager) recordVersion() {
+	if m.Registry == nil {
+		return
+	}
+
+	m.RouteLock.RLock()
+	services := make([]string, 0, len(m.SchemaCache))
+	for name := range m.SchemaCache {
+		services = append(services, name)
+	}
+	m.RouteLock.RUnlock()
+	sort.Strings(services)
+
+	if _, err := m.Registry.Record(m.GetSDL(), services); err != nil {
+		log.Printf("Failed to record schema version: %v", err)
+	}
+}
+
+// GetSDL prints the merged schema in SDL, without built-in types and directives
+func (m *Manager) GetSDL() string {
+	return printSDL(m.GetSchema())
+}
+
+// printSDL formats an executable schema as SDL
+func printSDL(schema *ast.Schema) string {
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf, formatter.WithIndent("  ")).FormatSchema(schema)
+	return buf.String()
+}
+
+// redisStore keeps schema versions in Redis
+// Each version is a JSON value under keyPrefix:version:{n}, listed in keyPrefix:versions
+type redisStore struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+// NewRedisStore creates a registry store in Redis
+func NewRedisStore(client *redis.Client, keyPrefix string) RegistryStore {
+	return &redisStore{client: client, keyPrefix: keyPrefix}
+}
+
+func (s *redisStore) Save(version *SchemaVersion) error {
+	encoded, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, s.versionKey(version.Version), encoded, 0)
+	pipe.RPush(ctx, s.keyPrefix+":versions", version.Version)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (s *redisStore) Get(number int) (*SchemaVersion, error) {
+	encoded, err := s.client.Get(context.Background(), s.versionKey(number)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrVersionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var version SchemaVersion
+	if err := json.Unmarshal(encoded, &version); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+func (s *redisStore) List() ([]*SchemaVersion, error) {
+	numbers, err := s.client.LRange(context.Background(), s.keyPrefix+":versions", 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*SchemaVersion, 0, len(numbers))
+	for _, n := range numbers {
+		number, err := strconv.Atoi(n)
+		if err != nil {
+			continue
+		}
+		version, err := s.Get(number)
+		if err != nil {
+			return nil, err
+		}
+		version.SDL = ""
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+func (s *redisStore) versionKey(number int) string {
+	return fmt.Sprintf("%s:version:%d", s.keyPrefix, number)
+}
+
+// fileStore keeps schema versions as v{n}.json files in a directory
+type fileStore struct {
+	dir string
+}
+
+// NewFileStore creates a registry store in a local directory
+func NewFileStore(dir string) (RegistryStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+func (s *fileStore) Save(version *SchemaVersion) error {
+	encoded, err := json.MarshalIndent(version, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.versionPath(version.Version), encoded, 0o644)
+}
+
+func (s *fileStore) Get(number int) (*SchemaVersion, error) {
+	encoded, err := os.ReadFile(s.versionPath(number))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrVersionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var version SchemaVersion
+	if err := json.Unmarshal(encoded, &version); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+func (s *fileStore) List() ([]*SchemaVersion, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var numbers []int
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, "v") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		if number, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "v"), ".json")); err == nil {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Ints(numbers)
+
+	versions := make([]*SchemaVersion, 0, len(numbers))
+	for _, number := range numbers {
+		version, err := s.Get(number)
+		if err != nil {
+			return nil, err
+		}
+		version.SDL = ""
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+func (s *fileStore) versionPath(number int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("v%d.json", number))
+}
@@ -10,6 +10,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
 // Service represents a GraphQL service configuration
@@ -49,8 +51,21 @@ type Manager struct {
 	Services     []Service                  // Configured services
 	SchemaCache  map[string]*SchemaResponse // Cache of service schemas
 	MergedSchema *SchemaResponse            // Combined schema for introspection
+	Executable   *ast.Schema                // Merged schema the gateway executes introspection against
 	Routes       map[string]string          // Map of operations to service URLs
 	RouteLock    sync.RWMutex               // Lock for thread safety
+
+	Entities      map[string]*Entity           // Federated entity types keyed by type name
+	FieldTypes    map[string]map[string]string // Type name → field name → named field type
+	CacheHints    map[string]CacheHint         // @cacheControl hints keyed by "Type" and "Type.field"
+	federationSDL map[string]*serviceSDL       // Federation SDL of each service exposing _service
+
+	Composition CompositionConfig // How conflicts between service schemas are handled
+	Conflicts   []Conflict        // Conflicts found in the current composition
+	Registry    *Registry         // Optional history of composed schema versions
+
+	refreshMu   sync.Mutex    // Serializes refreshes from the background refresher and the admin endpoint
+	stopRefresh chan struct{} // Closed to stop the background refresher
 }
 
 // NewManager creates a new schema manager
@@ -60,11 +75,17 @@ func NewManager(services []Service) *Manager {
 		SchemaCache:  make(map[string]*SchemaResponse),
 		Routes:       make(map[string]string),
 		MergedSchema: &SchemaResponse{},
+
+		Entities:      make(map[string]*Entity),
+		FieldTypes:    make(map[string]map[string]string),
+		CacheHints:    make(map[string]CacheHint),
+		federationSDL: make(map[string]*serviceSDL),
 	}
 }
 
 // Initialize collects schemas from all services and merges them
-func (m *Manager) Initialize() {
+// In strict composition mode an error is returned when services define conflicting fields or types
+func (m *Manager) Initialize() error {
 	log.Println("Initializing schema manager")
 
 	// Initialize empty schema
@@ -82,11 +103,19 @@ func (m *Manager) Initialize() {
 
 	// Merge schemas if we have any successful collections
 	if successCount > 0 {
+		conflicts, err := m.checkComposition()
+		m.Conflicts = conflicts
+		if err != nil {
+			return err
+		}
 		m.MergeSchemas()
+		m.recordVersion()
 		log.Printf("Successfully initialized schema manager with %d services", successCount)
 	} else {
 		log.Println("Warning: Could not collect schema from any service")
 	}
+
+	return nil
 }
 
 // CollectSchema fetches schema from a service and updates routing map
@@ -144,9 +173,18 @@ func (m *Manager) CollectSchema(name, url string) bool {
 
 	// Cache schema and extract routes
 	log.Printf("Successfully received schema from %s with %d types", name, len(schemaResp.Data.Schema.Types))
+	m.RouteLock.Lock()
 	m.SchemaCache[name] = &schemaResp
+	m.RouteLock.Unlock()
 	m.UpdateRoutes(url, &schemaResp)
 
+	// Services exposing _service take part in cross-service entity resolution
+	if sdl := m.collectFederation(name, url, &schemaResp); sdl != nil {
+		m.RouteLock.Lock()
+		m.federationSDL[name] = sdl
+		m.RouteLock.Unlock()
+	}
+
 	return true
 }
 
@@ -165,6 +203,15 @@ func (m *Manager) UpdateRoutes(url string, schema *SchemaResponse) {
 				for _, field := range fields {
 					if fieldObj, ok := field.(map[string]interface{}); ok {
 						if fieldName, ok := fieldObj["name"].(string); ok {
+							// Federation fields are only used by the gateway itself
+							if isFederationField(fieldName) {
+								continue
+							}
+							// A field exposed by several services goes to the one with the highest precedence
+							if existing, ok := m.Routes[fieldName]; ok && existing != url && m.precedence(existing) < m.precedence(url) {
+								log.Printf("Operation %s is also exposed by %s, keeping %s", fieldName, url, existing)
+								continue
+							}
 							m.Routes[fieldName] = url
 							operationsAdded++
 						}
@@ -186,28 +233,40 @@ func (m *Manager) MergeSchemas() {
 	m.MergedSchema.Data.Schema.Types = []SchemaType{}
 	m.MergedSchema.Data.Schema.Directives = []interface{}{}
 
-	// Track Query and Mutation fields
+	// Track Query, Mutation and Subscription fields
 	queryFields := []interface{}{}
 	mutationFields := []interface{}{}
+	subscriptionFields := []interface{}{}
+
+	// Resolve entity ownership before merging so owners' type definitions win
+	entities := buildEntities(m.federationSDL)
 
-	// Track unique types and added directives
+	// Track unique types, root fields and added directives
 	typeMap := make(map[string]bool)
+	seenRootFields := map[string]map[string]bool{
+		"Query":        make(map[string]bool),
+		"Mutation":     make(map[string]bool),
+		"Subscription": make(map[string]bool),
+	}
 	directiveNames := make(map[string]bool) // To track directive names
 
 	// Track query and mutation counts per service
 	serviceStats := make(map[string]struct {
-		QueryCount    int
-		MutationCount int
+		QueryCount        int
+		MutationCount     int
+		SubscriptionCount int
 	})
 
-	// Process each service's schema
-	for name, schema := range m.SchemaCache {
+	// Process each service's schema, highest precedence first so its definitions win conflicts
+	for _, name := range m.orderedServices() {
+		schema := m.SchemaCache[name]
 		log.Printf("Processing schema from %s", name)
 
 		// Initialize stats for this service
 		serviceStats[name] = struct {
-			QueryCount    int
-			MutationCount int
+			QueryCount        int
+			MutationCount     int
+			SubscriptionCount int
 		}{}
 
 		// Set root operation types if not already set
@@ -228,6 +287,8 @@ func (m *Manager) MergeSchemas() {
 			// Collect operation fields separately
 			if typeObj.Name == "Query" {
 				if fields, ok := typeObj.Fields.([]interface{}); ok {
+					fields = uniqueFields(filterFederationFields(fields), seenRootFields[typeObj.Name])
+
 					// Update service stats
 					stats := serviceStats[name]
 					stats.QueryCount = len(fields)
@@ -245,6 +306,7 @@ func (m *Manager) MergeSchemas() {
 
 			if typeObj.Name == "Mutation" {
 				if fields, ok := typeObj.Fields.([]interface{}); ok {
+					fields = uniqueFields(fields, seenRootFields[typeObj.Name])
 					// Update service stats
 					stats := serviceStats[name]
 					stats.MutationCount = len(fields)
@@ -260,8 +322,28 @@ func (m *Manager) MergeSchemas() {
 				continue
 			}
 
-			// Skip other root operation types
 			if typeObj.Name == "Subscription" {
+				if fields, ok := typeObj.Fields.([]interface{}); ok {
+					fields = uniqueFields(fields, seenRootFields[typeObj.Name])
+					// Update service stats
+					stats := serviceStats[name]
+					stats.SubscriptionCount = len(fields)
+					serviceStats[name] = stats
+
+					subscriptionFields = append(subscriptionFields, fields...)
+					log.Printf("Service %s provides %d subscriptions (total subscriptions now: %d)",
+						name, len(fields), len(subscriptionFields))
+				}
+				continue
+			}
+
+			// Skip federation types used only between the gateway and services
+			if internalTypes[typeObj.Name] {
+				continue
+			}
+
+			// Entity types are taken from their owner, extension fields are added below
+			if entity, ok := entities[typeObj.Name]; ok && entity.OwnerURL != "" && entity.OwnerURL != m.serviceURL(name) {
 				continue
 			}
 
@@ -286,12 +368,15 @@ func (m *Manager) MergeSchemas() {
 		}
 	}
 
+	// Add extension fields declared by non-owning services to entity types
+	m.mergeEntityFields(entities)
+
 	// Print summary of operations per service
 	fmt.Println("\n📊 Operations Per Service:")
 	for name, stats := range serviceStats {
-		fmt.Printf("  • %s: %d queries, %d mutations\n", name, stats.QueryCount, stats.MutationCount)
+		fmt.Printf("  • %s: %d queries, %d mutations, %d subscriptions\n", name, stats.QueryCount, stats.MutationCount, stats.SubscriptionCount)
 	}
-	fmt.Printf("  • Total collected: %d queries, %d mutations\n", len(queryFields), len(mutationFields))
+	fmt.Printf("  • Total collected: %d queries, %d mutations, %d subscriptions\n", len(queryFields), len(mutationFields), len(subscriptionFields))
 
 	// Add Query type with all fields
 	if len(queryFields) > 0 || m.MergedSchema.Data.Schema.QueryType != nil {
@@ -315,6 +400,21 @@ func (m *Manager) MergeSchemas() {
 		})
 	}
 
+	// Add Subscription type with all fields
+	if len(subscriptionFields) > 0 {
+		log.Printf("Creating merged Subscription type with %d fields", len(subscriptionFields))
+		m.MergedSchema.Data.Schema.Types = append(m.MergedSchema.Data.Schema.Types, SchemaType{
+			Kind:       "OBJECT",
+			Name:       "Subscription",
+			Fields:     subscriptionFields,
+			Interfaces: []interface{}{}, // Required for GraphQL introspection
+		})
+		m.MergedSchema.Data.Schema.SubscriptionType = map[string]string{"name": "Subscription"}
+	} else {
+		// Services may advertise a subscription type without any fields
+		m.MergedSchema.Data.Schema.SubscriptionType = nil
+	}
+
 	// Ensure we have at least an empty Query type
 	if len(m.MergedSchema.Data.Schema.Types) == 0 {
 		m.MergedSchema.Data.Schema.Types = append(m.MergedSchema.Data.Schema.Types, SchemaType{
@@ -325,7 +425,110 @@ func (m *Manager) MergeSchemas() {
 		m.MergedSchema.Data.Schema.QueryType = map[string]string{"name": "Query"}
 	}
 
-	log.Printf("Merged schema created with %d types", len(m.MergedSchema.Data.Schema.Types))
+	// Index field types for the router and publish the entities
+	fieldTypes := make(map[string]map[string]string)
+	for _, typeObj := range m.MergedSchema.Data.Schema.Types {
+		fields, ok := typeObj.Fields.([]interface{})
+		if !ok {
+			continue
+		}
+		fieldTypes[typeObj.Name] = make(map[string]string, len(fields))
+		for _, field := range fields {
+			if fieldObj, ok := field.(map[string]interface{}); ok {
+				if fieldName, ok := fieldObj["name"].(string); ok {
+					fieldTypes[typeObj.Name][fieldName] = namedType(fieldObj["type"])
+				}
+			}
+		}
+	}
+
+	executable := buildExecutableSchema(m.MergedSchema)
+	cacheHints := buildCacheHints(m.federationSDL)
+
+	m.RouteLock.Lock()
+	m.Entities = entities
+	m.FieldTypes = fieldTypes
+	m.CacheHints = cacheHints
+	m.Executable = executable
+	m.RouteLock.Unlock()
+
+	log.Printf("Merged schema created with %d types and %d federated entities", len(m.MergedSchema.Data.Schema.Types), len(entities))
+}
+
+// uniqueFields drops fields whose name is already in seen and records the remaining ones
+func uniqueFields(fields []interface{}, seen map[string]bool) []interface{} {
+	unique := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		if fieldObj, ok := field.(map[string]interface{}); ok {
+			if fieldName, ok := fieldObj["name"].(string); ok {
+				if seen[fieldName] {
+					continue
+				}
+				seen[fieldName] = true
+			}
+		}
+		unique = append(unique, field)
+	}
+	return unique
+}
+
+// mergeEntityFields appends fields contributed by extending services to merged entity types
+func (m *Manager) mergeEntityFields(entities map[string]*Entity) {
+	for i, typeObj := range m.MergedSchema.Data.Schema.Types {
+		entity, ok := entities[typeObj.Name]
+		if !ok {
+			continue
+		}
+
+		existing, _ := typeObj.Fields.([]interface{})
+		fields := append([]interface{}{}, existing...)
+		present := make(map[string]bool, len(fields))
+		for _, field := range fields {
+			if fieldObj, ok := field.(map[string]interface{}); ok {
+				if fieldName, ok := fieldObj["name"].(string); ok {
+					present[fieldName] = true
+				}
+			}
+		}
+
+		for name, schema := range m.SchemaCache {
+			url := m.serviceURL(name)
+			if url == entity.OwnerURL {
+				continue
+			}
+			for _, serviceType := range schema.Data.Schema.Types {
+				if serviceType.Name != typeObj.Name {
+					continue
+				}
+				serviceFields, _ := serviceType.Fields.([]interface{})
+				for _, field := range serviceFields {
+					fieldObj, ok := field.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					fieldName, _ := fieldObj["name"].(string)
+					if present[fieldName] || entity.FieldOwners[fieldName] != url {
+						continue
+					}
+					fields = append(fields, field)
+					present[fieldName] = true
+					log.Printf("Added extension field %s.%s from %s", typeObj.Name, fieldName, name)
+				}
+			}
+		}
+
+		m.MergedSchema.Data.Schema.Types[i].Fields = fields
+	}
+}
+
+// serviceURL returns the configured URL of a service by name
+func (m *Manager) serviceURL(name string) string {
+	for _, svc := range m.Services {
+		if svc.Name == name {
+			return svc.URL
+		}
+	}
+	return ""
 }
 
 // GetRouteForOperation finds the service URL for a GraphQL operation
@@ -338,6 +541,10 @@ func (m *Manager) GetRouteForOperation(rootField string) string {
 
 // GetMergedSchema returns the merged schema for introspection queries
 func (m *Manager) GetMergedSchema() interface{} {
+	// Normalization below updates the merged schema in place
+	m.RouteLock.Lock()
+	defer m.RouteLock.Unlock()
+
 	// Return a valid response even if schema is empty
 	if m.MergedSchema == nil || len(m.MergedSchema.Data.Schema.Types) == 0 {
 		return map[string]interface{}{
@@ -359,8 +566,8 @@ func (m *Manager) GetMergedSchema() interface{} {
 
 	// Process all types to ensure proper structure for GraphQL clients
 	for i, typeObj := range m.MergedSchema.Data.Schema.Types {
-		// Fix Query and Mutation operation types
-		if typeObj.Name == "Query" || typeObj.Name == "Mutation" {
+		// Fix Query, Mutation and Subscription operation types
+		if typeObj.Name == "Query" || typeObj.Name == "Mutation" || typeObj.Name == "Subscription" {
 			// Validate and enhance field structure
 			if fields, ok := typeObj.Fields.([]interface{}); ok {
 				log.Printf("Processing %s type with %d fields", typeObj.Name, len(fields))
@@ -499,11 +706,15 @@ func (m *Manager) GetMergedSchema() interface{} {
 
 // Debug returns a string with information about the schema manager state
 func (m *Manager) Debug() string {
+	m.RouteLock.RLock()
+	defer m.RouteLock.RUnlock()
+
 	var result strings.Builder
 
 	result.WriteString(fmt.Sprintf("Services Configured: %d\n", len(m.Services)))
 	result.WriteString(fmt.Sprintf("Services Connected: %d\n", len(m.SchemaCache)))
 	result.WriteString(fmt.Sprintf("Operations Mapped: %d\n", len(m.Routes)))
+	result.WriteString(fmt.Sprintf("Composition Conflicts: %d\n", len(m.Conflicts)))
 
 	// Show service connection status
 	result.WriteString("\nService Status:\n")
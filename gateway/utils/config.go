@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds all configuration for the gateway service
@@ -16,6 +17,72 @@ type Config struct {
 	RedisPassword  string
 	RedisDB        int
 	AuthServiceURL string
+
+	SchemaRefreshInterval time.Duration // How often service schemas are re-collected, 0 disables
+	AdminToken            string        // Token required by the admin endpoints, empty disables them
+
+	CompositionMode       string   // "strict" fails on conflicting service schemas, "permissive" resolves them by precedence
+	CompositionPrecedence []string // Service names in priority order for permissive conflict resolution
+
+	SchemaRegistry    string // Where composed schema versions are kept: "redis", "file" or "off"
+	SchemaRegistryDir string // Directory of the file registry
+
+	ForwardTimeout          time.Duration // Default timeout of a request to a service
+	ForwardMaxRetries       int           // Extra attempts for queries that failed with a connection or gateway error
+	ForwardRetryBackoff     time.Duration // Delay before the first retry
+	ForwardMaxConns         int           // Maximum connections per service, 0 for no limit
+	BreakerFailureThreshold int           // Consecutive failures that open a service's circuit, 0 disables the breaker
+	BreakerOpenTimeout      time.Duration // Time a circuit stays open before a trial request
+
+	JWTSecret      string        // Secret the auth service signs access tokens with
+	JWKSURL        string        // Public keys of the auth service, used instead of JWTSecret when set
+	JWKSCacheTTL   time.Duration // How long fetched public keys are used before fetching them again
+	IdentitySecret string        // Signs the identity forwarded to services, empty leaves token validation to the services
+	IdentityTTL    time.Duration // Lifetime of a forwarded identity
+
+	RateLimitEnabled           bool          // Enforce rate limits in Redis
+	RateLimitWindow            time.Duration // Sliding window of the rate limits
+	RateLimitUser              int           // Requests per window and authenticated user, 0 for no limit
+	RateLimitTenant            int           // Requests per window and tenant, 0 for no limit
+	RateLimitIP                int           // Requests per window and client IP for anonymous requests, 0 for no limit
+	RateLimitOperations        string        // Per root field limits, e.g. "login:5,register:5/10m"
+	RateLimitTrustForwardedFor bool          // Use X-Forwarded-For as the client IP
+	RateLimitTrustedProxies    string        // Proxies whose X-Forwarded-For hops are skipped, e.g. "10.0.0.0/8"
+
+	QueryMaxDepth              int            // Deepest field nesting of an operation, 0 for no limit
+	QueryMaxIntrospectionDepth int            // Deepest nesting of __schema and __type, 0 for no limit
+	QueryMaxAliases            int            // Aliased fields per operation, 0 for no limit
+	QueryMaxCost               int            // Default cost budget of an operation, 0 for no limit
+	QueryCostByRole            map[string]int // Cost budgets by role name
+	QueryCostByTenant          map[string]int // Cost budgets by tenant id
+	QueryDefaultListSize       int            // Assumed length of lists requested without first or last
+
+	PersistedQueries  string        // "apq" accepts and caches hashed queries, "allowlist" only runs registered operations, "off" disables both
+	PersistedQueryTTL time.Duration // Lifetime of a query registered by an APQ client, 0 keeps it forever
+
+	ResponseCacheEnabled bool   // Cache responses of queries with cache hints in Redis
+	CacheHints           string // Cache hints besides the schemas' @cacheControl, e.g. "Query.Roles:60,Role:5m"
+	CacheInvalidate      string // Types changed by mutations besides the types they return, e.g. "deleteRole:Role"
+
+	MaxBatchSize int // Operations accepted in one batched request, 0 disables batching
+
+	UploadMaxRequestMB int // Size of a whole multipart upload request in MB, 0 for no limit
+	UploadMaxFileMB    int // Size of a single uploaded file in MB, 0 for no limit
+	UploadMaxFiles     int // Files in one upload request, 0 for no limit
+
+	RESTRoutesFile string // Declarative REST routes, see LoadRoutesFromFile
+
+	GRPCDiscoveryInterval time.Duration // How often gRPC backends are asked for their services, 0 disables the refresh
+	GRPCLoadBalancing     string        // round_robin or least_request across the replicas of a service
+	GRPCHealthChecks      bool          // Eject replicas failing grpc.health.v1 checks until they recover
+	GRPCPolicyFile        string        // Who may call which gRPC methods, see LoadGRPCPoliciesFromFile
+	GRPCMetadataAllow     []string      // Client metadata keys forwarded to gRPC backends, empty forwards every key not denied
+	GRPCMetadataDeny      []string      // Client metadata keys never forwarded to gRPC backends
+	GRPCDeadlineFile      string        // Default deadlines of gRPC methods, see LoadGRPCDeadlinesFromFile
+	GRPCDefaultDeadline   time.Duration // Deadline of gRPC calls without one whose method has no default, 0 for none
+	GRPCMaxDeadline       time.Duration // Upper bound of every gRPC call's deadline, 0 for none
+	GRPCAuditLog          string        // File receiving denied gRPC calls as JSON lines
+	ServiceTokenSecret    string        // Shared secret of service credentials, empty rejects them
 }
 
 // LoadConfig loads configuration from environment variables
@@ -30,6 +97,72 @@ func LoadConfig() *Config {
 		RedisPassword:  GetEnv("REDIS_PASSWORD", ""),
 		RedisDB:        GetEnvInt("REDIS_DB", 0),
 		AuthServiceURL: GetEnv("AUTH_SERVICE_URL", "http://localhost:8081/graphql"),
+
+		SchemaRefreshInterval: GetEnvDuration("SCHEMA_REFRESH_INTERVAL", 30*time.Second),
+		AdminToken:            GetEnv("GATEWAY_ADMIN_TOKEN", ""),
+
+		CompositionMode:       GetEnv("COMPOSITION_MODE", "permissive"),
+		CompositionPrecedence: parseServiceNames(GetEnv("COMPOSITION_PRECEDENCE", "")),
+
+		SchemaRegistry:    GetEnv("SCHEMA_REGISTRY", "redis"),
+		SchemaRegistryDir: GetEnv("SCHEMA_REGISTRY_DIR", "schemas"),
+
+		ForwardTimeout:          GetEnvDuration("FORWARD_TIMEOUT", 30*time.Second),
+		ForwardMaxRetries:       GetEnvInt("FORWARD_MAX_RETRIES", 2),
+		ForwardRetryBackoff:     GetEnvDuration("FORWARD_RETRY_BACKOFF", 100*time.Millisecond),
+		ForwardMaxConns:         GetEnvInt("FORWARD_MAX_CONNS_PER_SERVICE", 0),
+		BreakerFailureThreshold: GetEnvInt("BREAKER_FAILURE_THRESHOLD", 5),
+		BreakerOpenTimeout:      GetEnvDuration("BREAKER_OPEN_TIMEOUT", 30*time.Second),
+
+		JWTSecret:      GetEnv("JWT_SECRET", ""),
+		JWKSURL:        GetEnv("JWT_JWKS_URL", ""),
+		JWKSCacheTTL:   GetEnvDuration("JWT_JWKS_CACHE_TTL", 5*time.Minute),
+		IdentitySecret: GetEnv("GATEWAY_IDENTITY_SECRET", ""),
+		IdentityTTL:    GetEnvDuration("GATEWAY_IDENTITY_TTL", time.Minute),
+
+		RateLimitEnabled:           GetEnvBool("RATE_LIMIT_ENABLED", true),
+		RateLimitWindow:            GetEnvDuration("RATE_LIMIT_WINDOW", time.Minute),
+		RateLimitUser:              GetEnvInt("RATE_LIMIT_USER", 300),
+		RateLimitTenant:            GetEnvInt("RATE_LIMIT_TENANT", 3000),
+		RateLimitIP:                GetEnvInt("RATE_LIMIT_IP", 60),
+		RateLimitOperations:        GetEnv("RATE_LIMIT_OPERATIONS", "login:5,register:5,requestPasswordReset:3/10m,sendVerificationEmail:3/10m,verifyMfa:10"),
+		RateLimitTrustForwardedFor: GetEnvBool("RATE_LIMIT_TRUST_FORWARDED_FOR", false),
+		RateLimitTrustedProxies:    GetEnv("RATE_LIMIT_TRUSTED_PROXIES", ""),
+
+		QueryMaxDepth:              GetEnvInt("QUERY_MAX_DEPTH", 10),
+		QueryMaxIntrospectionDepth: GetEnvInt("QUERY_MAX_INTROSPECTION_DEPTH", 15),
+		QueryMaxAliases:            GetEnvInt("QUERY_MAX_ALIASES", 30),
+		QueryMaxCost:               GetEnvInt("QUERY_MAX_COST", 5000),
+		QueryCostByRole:            parseLimits(GetEnv("QUERY_COST_BY_ROLE", "")),
+		QueryCostByTenant:          parseLimits(GetEnv("QUERY_COST_BY_TENANT", "")),
+		QueryDefaultListSize:       GetEnvInt("QUERY_DEFAULT_LIST_SIZE", 10),
+
+		PersistedQueries:  GetEnv("PERSISTED_QUERIES", "apq"),
+		PersistedQueryTTL: GetEnvDuration("PERSISTED_QUERY_TTL", 24*time.Hour),
+
+		ResponseCacheEnabled: GetEnvBool("RESPONSE_CACHE_ENABLED", true),
+		CacheHints:           GetEnv("CACHE_HINTS", ""),
+		CacheInvalidate:      GetEnv("CACHE_INVALIDATE", ""),
+
+		MaxBatchSize: GetEnvInt("GRAPHQL_MAX_BATCH_SIZE", 10),
+
+		UploadMaxRequestMB: GetEnvInt("UPLOAD_MAX_REQUEST_MB", 32),
+		UploadMaxFileMB:    GetEnvInt("UPLOAD_MAX_FILE_MB", 10),
+		UploadMaxFiles:     GetEnvInt("UPLOAD_MAX_FILES", 10),
+
+		RESTRoutesFile: GetEnv("REST_ROUTES_FILE", "routes.conf"),
+
+		GRPCDiscoveryInterval: GetEnvDuration("GRPC_DISCOVERY_INTERVAL", 30*time.Second),
+		GRPCLoadBalancing:     GetEnv("GRPC_LOAD_BALANCING", "round_robin"),
+		GRPCHealthChecks:      GetEnvBool("GRPC_HEALTH_CHECKS", true),
+		GRPCPolicyFile:        GetEnv("GRPC_POLICY_FILE", "grpc_policies.conf"),
+		GRPCMetadataAllow:     parseServiceNames(GetEnv("GRPC_METADATA_ALLOW", "")),
+		GRPCMetadataDeny:      parseServiceNames(GetEnv("GRPC_METADATA_DENY", "")),
+		GRPCDeadlineFile:      GetEnv("GRPC_DEADLINE_FILE", "grpc_deadlines.conf"),
+		GRPCDefaultDeadline:   GetEnvDuration("GRPC_DEFAULT_DEADLINE", 30*time.Second),
+		GRPCMaxDeadline:       GetEnvDuration("GRPC_MAX_DEADLINE", 0),
+		GRPCAuditLog:          GetEnv("GRPC_AUDIT_LOG", "logs/grpc-audit.log"),
+		ServiceTokenSecret:    GetEnv("SERVICE_TOKEN_SECRET", ""),
 	}
 }
 
@@ -59,8 +192,10 @@ func LoadServicesFromEnv() []ServiceConfig {
 
 		if serviceURL != "" {
 			services = append(services, ServiceConfig{
-				Name: name,
-				URL:  serviceURL,
+				Name:    name,
+				URL:     serviceURL,
+				GRPCURL: ServiceGRPCURL(name),
+				Timeout: ServiceTimeout(name),
 			})
 		}
 	}
@@ -69,7 +204,8 @@ func LoadServicesFromEnv() []ServiceConfig {
 }
 
 // LoadServicesFromFile loads service configurations from a file
-// File format: service-name|service-url (one per line)
+// File format: service-name|service-url[|grpc-address] (one per line)
+// Without a gRPC address, {SERVICE_NAME}_GRPC_URL is used
 // Lines starting with # are comments
 func LoadServicesFromFile(filename string) ([]ServiceConfig, error) {
 	file, err := os.Open(filename)
@@ -89,19 +225,25 @@ func LoadServicesFromFile(filename string) ([]ServiceConfig, error) {
 			continue
 		}
 
-		// Parse line: service-name|service-url
+		// Parse line: service-name|service-url[|grpc-address]
 		parts := strings.Split(line, "|")
-		if len(parts) != 2 {
+		if len(parts) != 2 && len(parts) != 3 {
 			continue
 		}
 
 		name := strings.TrimSpace(parts[0])
 		url := strings.TrimSpace(parts[1])
+		grpcURL := ServiceGRPCURL(name)
+		if len(parts) == 3 && strings.TrimSpace(parts[2]) != "" {
+			grpcURL = strings.TrimSpace(parts[2])
+		}
 
 		if name != "" && url != "" {
 			services = append(services, ServiceConfig{
-				Name: name,
-				URL:  url,
+				Name:    name,
+				URL:     url,
+				GRPCURL: grpcURL,
+				Timeout: ServiceTimeout(name),
 			})
 		}
 	}
@@ -113,10 +255,226 @@ func LoadServicesFromFile(filename string) ([]ServiceConfig, error) {
 	return services, nil
 }
 
+// RouteConfig is a declarative REST route from the route file
+type RouteConfig struct {
+	Prefix        string   // Path prefix under /api/, matched on segment boundaries
+	Service       string   // Name of a configured service
+	Methods       []string // Allowed methods, empty allows any
+	Rewrite       string   // Replaces the prefix in the forwarded path, empty forwards the path unchanged
+	Authenticated bool     // Requests need a valid token
+	Roles         []string // Requests need one of these roles
+}
+
+// LoadRoutesFromFile loads REST routes from a file
+// File format: path-prefix|service|methods|rewrite|auth (one per line)
+// methods is a comma-separated list or *, auth is public, authenticated or role:name[,name]
+// Lines starting with # are comments
+func LoadRoutesFromFile(filename string) ([]RouteConfig, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	routes := make([]RouteConfig, 0)
+	scanner := bufio.NewScanner(file)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.Split(line, "|")
+		for len(parts) < 5 {
+			parts = append(parts, "")
+		}
+		if len(parts) > 5 {
+			return nil, fmt.Errorf("%s:%d: expected path-prefix|service|methods|rewrite|auth", filename, lineNumber)
+		}
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+
+		route := RouteConfig{Prefix: parts[0], Service: parts[1], Rewrite: parts[3]}
+		if !strings.HasPrefix(route.Prefix, "/api/") || route.Service == "" {
+			return nil, fmt.Errorf("%s:%d: a route needs a path prefix under /api/ and a service", filename, lineNumber)
+		}
+		if parts[2] != "" && parts[2] != "*" {
+			for _, method := range strings.Split(parts[2], ",") {
+				route.Methods = append(route.Methods, strings.ToUpper(strings.TrimSpace(method)))
+			}
+		}
+
+		switch auth := parts[4]; {
+		case auth == "" || auth == "public":
+		case auth == "authenticated":
+			route.Authenticated = true
+		case strings.HasPrefix(auth, "role:"):
+			route.Authenticated = true
+			route.Roles = parseServiceNames(strings.TrimPrefix(auth, "role:"))
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown auth requirement %q", filename, lineNumber, auth)
+		}
+
+		routes = append(routes, route)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return routes, nil
+}
+
+// GRPCPolicyConfig lists who may call the gRPC methods matching a pattern
+type GRPCPolicyConfig struct {
+	Pattern       string   // Full method, /package.Service/* or *
+	Public        bool     // Anyone, without credentials
+	Authenticated bool     // Any end user with a valid token
+	Roles         []string // End users with one of these roles
+	Services      []string // Microservices presenting a service credential, * for any
+}
+
+// LoadGRPCPoliciesFromFile loads gRPC method policies from a file
+// File format: method-pattern|callers (one per line)
+// callers is a comma-separated list of public, authenticated, role:name and service:name (service:* for any)
+// Lines starting with # are comments
+func LoadGRPCPoliciesFromFile(filename string) ([]GRPCPolicyConfig, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	policies := make([]GRPCPolicyConfig, 0)
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		pattern, callers, ok := strings.Cut(line, "|")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || !validMethodPattern(pattern) {
+			return nil, fmt.Errorf("%s:%d: expected /package.Service/Method, /package.Service/* or * followed by |callers", filename, lineNumber)
+		}
+		if seen[pattern] {
+			return nil, fmt.Errorf("%s:%d: %s has more than one policy", filename, lineNumber, pattern)
+		}
+		seen[pattern] = true
+
+		policy := GRPCPolicyConfig{Pattern: pattern}
+		for _, caller := range parseServiceNames(callers) {
+			switch {
+			case caller == "public":
+				policy.Public = true
+			case caller == "authenticated":
+				policy.Authenticated = true
+			case strings.HasPrefix(caller, "role:") && len(caller) > len("role:"):
+				policy.Roles = append(policy.Roles, strings.TrimPrefix(caller, "role:"))
+			case strings.HasPrefix(caller, "service:") && len(caller) > len("service:"):
+				policy.Services = append(policy.Services, strings.TrimPrefix(caller, "service:"))
+			default:
+				return nil, fmt.Errorf("%s:%d: unknown caller %q", filename, lineNumber, caller)
+			}
+		}
+
+		policies = append(policies, policy)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// GRPCDeadlineConfig is the default deadline of the gRPC methods matching a pattern
+type GRPCDeadlineConfig struct {
+	Pattern string        // Full method, /package.Service/* or *
+	Timeout time.Duration // 0 for no deadline
+}
+
+// LoadGRPCDeadlinesFromFile loads default gRPC deadlines from a file
+// File format: method-pattern|timeout (one per line), timeout as a duration like 5s or 0 for none
+// Lines starting with # are comments
+func LoadGRPCDeadlinesFromFile(filename string) ([]GRPCDeadlineConfig, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	deadlines := make([]GRPCDeadlineConfig, 0)
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		pattern, value, ok := strings.Cut(line, "|")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || !validMethodPattern(pattern) {
+			return nil, fmt.Errorf("%s:%d: expected /package.Service/Method, /package.Service/* or * followed by |timeout", filename, lineNumber)
+		}
+		if seen[pattern] {
+			return nil, fmt.Errorf("%s:%d: %s has more than one deadline", filename, lineNumber, pattern)
+		}
+		seen[pattern] = true
+
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || timeout < 0 {
+			return nil, fmt.Errorf("%s:%d: invalid timeout %q", filename, lineNumber, strings.TrimSpace(value))
+		}
+
+		deadlines = append(deadlines, GRPCDeadlineConfig{Pattern: pattern, Timeout: timeout})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return deadlines, nil
+}
+
+// validMethodPattern reports whether a pattern is *, /package.Service/* or a full method name
+func validMethodPattern(pattern string) bool {
+	if pattern == "*" {
+		return true
+	}
+	service, method, ok := strings.Cut(strings.TrimPrefix(pattern, "/"), "/")
+	return strings.HasPrefix(pattern, "/") && ok && service != "" && method != "" && !strings.Contains(method, "/")
+}
+
 // ServiceConfig holds configuration for a microservice
 type ServiceConfig struct {
-	Name string
-	URL  string
+	Name    string
+	URL     string
+	GRPCURL string        // gRPC address for the gRPC proxy, comma-separated for replicas, empty when the service has no gRPC server
+	Timeout time.Duration // Request timeout from {SERVICE_NAME}_SERVICE_TIMEOUT, 0 uses FORWARD_TIMEOUT
+}
+
+// ServiceTimeout reads the request timeout of a service from {SERVICE_NAME}_SERVICE_TIMEOUT
+func ServiceTimeout(name string) time.Duration {
+	return GetEnvDuration(toEnvVarName(name)+"_SERVICE_TIMEOUT", 0)
+}
+
+// ServiceGRPCURL reads the gRPC address of a service from {SERVICE_NAME}_GRPC_URL
+func ServiceGRPCURL(name string) string {
+	return GetEnv(toEnvVarName(name)+"_GRPC_URL", "")
 }
 
 // parseServiceNames splits comma-separated service names and trims whitespace
@@ -138,6 +496,23 @@ func parseServiceNames(servicesStr string) []string {
 	return names
 }
 
+// parseLimits parses comma-separated name:limit pairs, e.g. "admin:20000,viewer:1000"
+func parseLimits(limitsStr string) map[string]int {
+	limits := make(map[string]int)
+	for _, entry := range parseServiceNames(limitsStr) {
+		name, value, ok := strings.Cut(entry, ":")
+		if !ok {
+			continue
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		limits[strings.TrimSpace(name)] = limit
+	}
+	return limits
+}
+
 // toEnvVarName converts service name to environment variable format
 // Examples: "auth" -> "AUTH", "microservice-1" -> "MICROSERVICE_1"
 func toEnvVarName(serviceName string) string {
@@ -167,3 +542,23 @@ func GetEnvInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func GetEnvDuration(key string, fallback time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		duration, err := time.ParseDuration(value)
+		if err == nil {
+			return duration
+		}
+	}
+	return fallback
+}
+
+func GetEnvBool(key string, fallback bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		boolValue, err := strconv.ParseBool(value)
+		if err == nil {
+			return boolValue
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,23 @@
+package context
+
+import "context"
+
+// ClientCtxKey is the key for storing the client a request came from in context
+const ClientCtxKey contextKey = "client"
+
+// Client describes the device a request came from
+type Client struct {
+	UserAgent string `json:"user_agent"`
+	IP        string `json:"ip"`
+}
+
+// SetClient sets the requesting client in the context
+func SetClient(ctx context.Context, client *Client) context.Context {
+	return context.WithValue(ctx, ClientCtxKey, client)
+}
+
+// GetClient retrieves the requesting client from the context
+func GetClient(ctx context.Context) (*Client, bool) {
+	client, ok := ctx.Value(ClientCtxKey).(*Client)
+	return client, ok && client != nil
+}
@@ -59,22 +59,7 @@ func NewGatewayClient() (*GatewayClient, error) {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		conn, err := grpc.NewClient(gatewayAddr,
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
-			grpc.WithKeepaliveParams(keepalive.ClientParameters{
-				Time:                30 * time.Second,
-				Timeout:             10 * time.Second,
-				PermitWithoutStream: true,
-			}),
-			grpc.WithDefaultCallOptions(
-				grpc.MaxCallRecvMsgSize(10*1024*1024), // 10MB
-				grpc.MaxCallSendMsgSize(10*1024*1024), // 10MB
-			),
-			grpc.WithChainUnaryInterceptor(
-				ClientRetryInterceptor(3, 100*time.Millisecond),
-				ClientLoggingInterceptor(),
-			),
-		)
+		conn, err := dialGateway(gatewayAddr)
 		if err != nil {
 			return nil, fmt.Errorf("failed to connect to gateway proxy at %s: %w", gatewayAddr, err)
 		}
@@ -120,22 +105,7 @@ func (c *GatewayClient) ensureConnection() error {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		conn, err := grpc.NewClient(c.gatewayAddr,
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
-			grpc.WithKeepaliveParams(keepalive.ClientParameters{
-				Time:                30 * time.Second,
-				Timeout:             10 * time.Second,
-				PermitWithoutStream: true,
-			}),
-			grpc.WithDefaultCallOptions(
-				grpc.MaxCallRecvMsgSize(10*1024*1024), // 10MB
-				grpc.MaxCallSendMsgSize(10*1024*1024), // 10MB
-			),
-			grpc.WithChainUnaryInterceptor(
-				ClientRetryInterceptor(3, 100*time.Millisecond),
-				ClientLoggingInterceptor(),
-			),
-		)
+		conn, err := dialGateway(c.gatewayAddr)
 		if err != nil {
 			c.connErr = fmt.Errorf("failed to connect to gateway proxy at %s: %w", c.gatewayAddr, err)
 			return
@@ -210,6 +180,34 @@ func (c *GatewayClient) Close() error {
 	return nil
 }
 
+// dialGateway creates the connection to the gateway's gRPC proxy
+// With SERVICE_NAME and SERVICE_TOKEN_SECRET set, every call carries a service credential
+func dialGateway(gatewayAddr string) (*grpc.ClientConn, error) {
+	options := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                30 * time.Second,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(10*1024*1024), // 10MB
+			grpc.MaxCallSendMsgSize(10*1024*1024), // 10MB
+		),
+		grpc.WithChainUnaryInterceptor(
+			ClientRetryInterceptor(3, 100*time.Millisecond),
+			ClientLoggingInterceptor(),
+		),
+	}
+	if creds := ServiceCredentialsFromEnv(); creds != nil {
+		options = append(options, grpc.WithPerRPCCredentials(creds))
+	} else {
+		fmt.Println("⚠️  SERVICE_NAME or SERVICE_TOKEN_SECRET not set, calls through the gateway proxy carry no service credential")
+	}
+
+	return grpc.NewClient(gatewayAddr, options...)
+}
+
 // Global gateway client instance (optional singleton pattern)
 var (
 	globalGatewayClient     *GatewayClient
@@ -0,0 +1,198 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/saurabh/entgo-microservices/pkg/logger"
+	"golang.org/x/sync/singleflight"
+)
+
+// JWKSPath is where the auth service publishes its public keys
+const JWKSPath = "/.well-known/jwks.json"
+
+// jwksRefetchInterval bounds how often an unknown kid triggers fetching the key set again
+const jwksRefetchInterval = 10 * time.Second
+
+// JWKS is a JSON Web Key Set (RFC 7517)
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is a public RSA or Ed25519 key of a JSON Web Key Set
+type JWK struct {
+	KeyType   string `json:"kty"`           // RSA or OKP
+	KeyID     string `json:"kid"`           // Matches the kid header of the tokens it signed
+	Use       string `json:"use,omitempty"` // Always sig
+	Algorithm string `json:"alg"`           // RS256 or EdDSA
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA exponent
+	Curve     string `json:"crv,omitempty"` // Ed25519
+	X         string `json:"x,omitempty"`   // Ed25519 public key
+}
+
+// publicKey decodes the key for verifying signatures
+func (k JWK) publicKey() (interface{}, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || k.Curve != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+	}
+}
+
+// JWKSClient verifies tokens with the public keys the auth service publishes, without holding any secret
+// Keys are cached for the TTL; a token signed by an unknown key fetches the set again, e.g. after a rotation
+type JWKSClient struct {
+	url        string
+	ttl        time.Duration
+	httpClient *http.Client
+
+	fetches     singleflight.Group // One fetch at a time, shared by the token checks waiting for it
+	mu          sync.Mutex
+	keys        map[string]jwksKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+// jwksKey is a decoded public key and the algorithm it signs with
+type jwksKey struct {
+	algorithm string
+	public    interface{}
+}
+
+// NewJWKSClient creates keys verifying tokens against the key set at url, cached for ttl
+func NewJWKSClient(url string, ttl time.Duration) *JWKSClient {
+	return &JWKSClient{
+		url:        url,
+		ttl:        ttl,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		keys:       make(map[string]jwksKey),
+	}
+}
+
+// SigningKey always fails; public keys only verify tokens
+func (c *JWKSClient) SigningKey() (*SigningKey, error) {
+	return nil, errors.New("tokens can only be signed by the auth service")
+}
+
+// VerificationKey returns the cached public key of kid, fetching the key set when it is stale or lacks kid
+// A stale set is refreshed in the background; only a token signed by an unknown key waits for the fetch
+func (c *JWKSClient) VerificationKey(ctx context.Context, kid, alg string) (interface{}, error) {
+	c.mu.Lock()
+	key, known := c.keys[kid]
+	stale := time.Since(c.fetchedAt) > c.ttl
+	c.mu.Unlock()
+
+	switch {
+	case !known:
+		c.fetches.Do("jwks", func() (interface{}, error) {
+			c.refresh(context.WithoutCancel(ctx))
+			return nil, nil
+		})
+		c.mu.Lock()
+		key, known = c.keys[kid]
+		c.mu.Unlock()
+	case stale:
+		go c.fetches.Do("jwks", func() (interface{}, error) {
+			c.refresh(context.Background())
+			return nil, nil
+		})
+	}
+
+	if !known {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if key.algorithm != alg {
+		return nil, errors.New("unexpected signing method")
+	}
+	return key.public, nil
+}
+
+// refresh fetches the published key set, at most once per jwksRefetchInterval, and swaps it in
+func (c *JWKSClient) refresh(ctx context.Context) {
+	c.mu.Lock()
+	if time.Since(c.attemptedAt) <= jwksRefetchInterval {
+		c.mu.Unlock()
+		return
+	}
+	c.attemptedAt = time.Now()
+	c.mu.Unlock()
+
+	keys, err := c.fetch(ctx)
+	if err != nil {
+		// Cached keys stay usable while the auth service is unreachable
+		logger.WithError(err).Warn("Failed to fetch JWKS, using cached keys")
+		return
+	}
+
+	c.mu.Lock()
+	c.keys, c.fetchedAt = keys, time.Now()
+	c.mu.Unlock()
+}
+
+// fetch downloads and decodes the published key set
+func (c *JWKSClient) fetch(ctx context.Context) (map[string]jwksKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", c.url, resp.Status)
+	}
+
+	var set JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("invalid key set from %s: %w", c.url, err)
+	}
+
+	keys := make(map[string]jwksKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		public, err := jwk.publicKey()
+		if err != nil {
+			logger.WithError(err).WithField("kid", jwk.KeyID).Warn("Skipping invalid JWKS key")
+			continue
+		}
+		keys[jwk.KeyID] = jwksKey{algorithm: jwk.Algorithm, public: public}
+	}
+	return keys, nil
+}
+
+// base64URL encodes key material as in a JWK
+func base64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// bigEndian encodes an RSA exponent without leading zeros
+func bigEndian(n int) []byte {
+	return big.NewInt(int64(n)).Bytes()
+}
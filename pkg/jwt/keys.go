@@ -0,0 +1,219 @@
+package jwt
+
+import (
+	"bufio"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Keys supplies the keys tokens are signed with and verified against
+type Keys interface {
+	// SigningKey returns the key new tokens are signed with
+	SigningKey() (*SigningKey, error)
+	// VerificationKey returns the key verifying a token signed by kid with alg
+	VerificationKey(ctx context.Context, kid, alg string) (interface{}, error)
+}
+
+// SigningKey is a key new tokens are signed with
+type SigningKey struct {
+	ID     string            // kid header of the tokens, empty for the shared secret
+	Method jwt.SigningMethod // HS256, RS256 or EdDSA
+	Key    interface{}       // []byte, *rsa.PrivateKey or ed25519.PrivateKey
+}
+
+// secretKeys signs and verifies tokens with a shared HS256 secret
+type secretKeys []byte
+
+// SecretKeys returns keys signing with a shared HS256 secret; every service validating tokens needs the secret
+func SecretKeys(secret string) Keys {
+	return secretKeys(secret)
+}
+
+func (s secretKeys) SigningKey() (*SigningKey, error) {
+	return &SigningKey{Method: jwt.SigningMethodHS256, Key: []byte(s)}, nil
+}
+
+func (s secretKeys) VerificationKey(_ context.Context, _, alg string) (interface{}, error) {
+	if _, ok := jwt.GetSigningMethod(alg).(*jwt.SigningMethodHMAC); !ok {
+		return nil, errors.New("unexpected signing method")
+	}
+	return []byte(s), nil
+}
+
+// ringKey is a private key of a key ring and when it signs tokens
+type ringKey struct {
+	id         string
+	method     jwt.SigningMethod
+	private    crypto.Signer
+	activeFrom time.Time // Signs new tokens from then until the next key becomes active
+}
+
+// KeyRing signs tokens with RS256 or EdDSA keys that take turns on a schedule
+// A key is published and verifies tokens as soon as it is on the ring, signs from its activation time until
+// the next key activates, and keeps verifying for the overlap after that so its tokens stay valid until they expire
+type KeyRing struct {
+	keys    []*ringKey // Ordered by activation time
+	overlap time.Duration
+}
+
+// LoadKeyRing loads the keys of a ring from a file
+// File format: kid|algorithm|private-key-file|active-from (one per line)
+// algorithm is RS256 or EdDSA, the key file is PEM and relative to the ring file, active-from is RFC 3339 or - for always
+// Lines starting with # are comments
+func LoadKeyRing(filename string, overlap time.Duration) (*KeyRing, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	ring := &KeyRing{overlap: overlap}
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.Split(line, "|")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("%s:%d: expected kid|algorithm|private-key-file|active-from", filename, lineNumber)
+		}
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+
+		kid, algorithm, keyFile, activeFrom := parts[0], parts[1], parts[2], parts[3]
+		if kid == "" || seen[kid] {
+			return nil, fmt.Errorf("%s:%d: kid %q is empty or used twice", filename, lineNumber, kid)
+		}
+		seen[kid] = true
+
+		if !filepath.IsAbs(keyFile) {
+			keyFile = filepath.Join(filepath.Dir(filename), keyFile)
+		}
+		key, err := loadPrivateKey(algorithm, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", filename, lineNumber, err)
+		}
+		key.id = kid
+
+		if activeFrom != "-" {
+			if key.activeFrom, err = time.Parse(time.RFC3339, activeFrom); err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid active-from %q, expected RFC 3339 or -", filename, lineNumber, activeFrom)
+			}
+		}
+
+		ring.keys = append(ring.keys, key)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(ring.keys) == 0 {
+		return nil, fmt.Errorf("%s: no keys", filename)
+	}
+
+	sort.SliceStable(ring.keys, func(i, j int) bool {
+		return ring.keys[i].activeFrom.Before(ring.keys[j].activeFrom)
+	})
+	return ring, nil
+}
+
+// loadPrivateKey reads a PEM private key of the given algorithm
+func loadPrivateKey(algorithm, filename string) (*ringKey, error) {
+	pem, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	switch algorithm {
+	case jwt.SigningMethodRS256.Alg():
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+		return &ringKey{method: jwt.SigningMethodRS256, private: key}, nil
+	case jwt.SigningMethodEdDSA.Alg():
+		key, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+		return &ringKey{method: jwt.SigningMethodEdDSA, private: key.(ed25519.PrivateKey)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q, expected RS256 or EdDSA", algorithm)
+	}
+}
+
+// SigningKey returns the most recently activated key
+func (r *KeyRing) SigningKey() (*SigningKey, error) {
+	now := time.Now()
+	for i := len(r.keys) - 1; i >= 0; i-- {
+		if key := r.keys[i]; !key.activeFrom.After(now) {
+			return &SigningKey{ID: key.id, Method: key.method, Key: key.private}, nil
+		}
+	}
+	return nil, errors.New("no signing key is active yet")
+}
+
+// VerificationKey returns the public key of a published key
+func (r *KeyRing) VerificationKey(_ context.Context, kid, alg string) (interface{}, error) {
+	for _, key := range r.published(time.Now()) {
+		if key.id == kid {
+			if key.method.Alg() != alg {
+				return nil, errors.New("unexpected signing method")
+			}
+			return key.private.Public(), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// published returns the keys that verify tokens: upcoming keys, the active key
+// and earlier keys within the overlap after they were superseded
+func (r *KeyRing) published(now time.Time) []*ringKey {
+	keys := make([]*ringKey, 0, len(r.keys))
+	for i, key := range r.keys {
+		if i+1 < len(r.keys) {
+			if supersededAt := r.keys[i+1].activeFrom; !supersededAt.After(now) && now.Sub(supersededAt) > r.overlap {
+				continue
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// JWKS returns the public keys of the ring as a JSON Web Key Set
+func (r *KeyRing) JWKS() *JWKS {
+	set := &JWKS{Keys: []JWK{}}
+	for _, key := range r.published(time.Now()) {
+		jwk := JWK{KeyID: key.id, Use: "sig", Algorithm: key.method.Alg()}
+		switch public := key.private.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64URL(public.N.Bytes())
+			jwk.E = base64URL(bigEndian(public.E))
+		case ed25519.PublicKey:
+			jwk.KeyType, jwk.Curve = "OKP", "Ed25519"
+			jwk.X = base64URL(public)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
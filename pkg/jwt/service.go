@@ -9,13 +9,17 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	goredis "github.com/redis/go-redis/v9"
+	pkgcontext "github.com/saurabh/entgo-microservices/pkg/context"
 	"github.com/saurabh/entgo-microservices/pkg/logger"
 	"github.com/saurabh/entgo-microservices/pkg/redis"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// RefreshExpiryDays is the lifetime of refresh tokens; keys that signed them must stay verifiable as long
+const RefreshExpiryDays = 30
+
 type Service struct {
-	secretKey         string
+	keys              Keys
 	expiryHours       int
 	refreshExpiryDays int
 	tokenService      *redis.TokenService
@@ -25,15 +29,23 @@ type Claims struct {
 	UserID    int    `json:"user_id"`
 	Username  string `json:"username"`
 	Email     string `json:"email"`
-	TokenType string `json:"token_type"` // "access" or "refresh"
+	TokenType string `json:"token_type"`       // "access" or "refresh"
+	Family    string `json:"family,omitempty"` // Refresh token family the token was issued in
 	jwt.RegisteredClaims
 }
 
+// NewService creates a service signing and verifying tokens with a shared HS256 secret
 func NewService(secretKey string, expiryHours int, redisClient *goredis.Client, serviceName string) *Service {
+	return NewServiceWithKeys(SecretKeys(secretKey), expiryHours, redisClient, serviceName)
+}
+
+// NewServiceWithKeys creates a service using the given keys, e.g. a KeyRing in the auth service
+// or a JWKSClient in services that only validate tokens
+func NewServiceWithKeys(keys Keys, expiryHours int, redisClient *goredis.Client, serviceName string) *Service {
 	return &Service{
-		secretKey:         secretKey,
+		keys:              keys,
 		expiryHours:       expiryHours,
-		refreshExpiryDays: 30, // Refresh tokens expire in 30 days
+		refreshExpiryDays: RefreshExpiryDays,
 		tokenService:      redis.NewTokenService(redisClient, serviceName),
 	}
 }
@@ -47,27 +59,67 @@ func (j *Service) generateTokenID() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
-// GenerateTokenPair creates both access and refresh tokens with Redis tracking
+// GenerateTokenPair creates both access and refresh tokens with Redis tracking, starting a new refresh token family
 func (j *Service) GenerateTokenPair(ctx context.Context, userID int, username, email string) (accessToken, refreshToken string, err error) {
+	family, err := j.generateTokenID()
+	if err != nil {
+		return "", "", err
+	}
+	if accessToken, refreshToken, err = j.generateTokenPair(ctx, userID, username, email, family); err != nil {
+		return "", "", err
+	}
+	return accessToken, refreshToken, j.startSession(ctx, userID, family)
+}
+
+// startSession indexes a new refresh token family as a session of the user on the requesting client
+func (j *Service) startSession(ctx context.Context, userID int, family string) error {
+	now := time.Now()
+	session := &redis.Session{ID: family, UserID: userID, IssuedAt: now, LastSeen: now}
+	if client, ok := pkgcontext.GetClient(ctx); ok {
+		session.UserAgent, session.IP = client.UserAgent, client.IP
+	}
+
+	if err := j.tokenService.SaveSession(ctx, session, j.refreshExpiry()); err != nil {
+		logger.WithError(err).WithField("user_id", userID).Error("Failed to save session")
+		return err
+	}
+	return nil
+}
+
+// refreshExpiry is the lifetime of refresh tokens, and of the families and sessions they extend
+func (j *Service) refreshExpiry() time.Duration {
+	return time.Duration(j.refreshExpiryDays) * 24 * time.Hour
+}
+
+// generateTokenPair creates access and refresh tokens in a refresh token family
+func (j *Service) generateTokenPair(ctx context.Context, userID int, username, email, family string) (accessToken, refreshToken string, err error) {
+	refreshExpiry := j.refreshExpiry()
+
 	// Generate access token
-	accessToken, accessTokenID, err := j.generateToken(userID, username, email, "access", time.Duration(j.expiryHours)*time.Hour)
+	accessToken, accessTokenID, err := j.generateToken(userID, username, email, "access", family, time.Duration(j.expiryHours)*time.Hour)
 	if err != nil {
 		return "", "", err
 	}
 
 	// Generate refresh token
-	refreshToken, refreshTokenID, err := j.generateToken(userID, username, email, "refresh", time.Duration(j.refreshExpiryDays)*24*time.Hour)
+	refreshToken, refreshTokenID, err := j.generateToken(userID, username, email, "refresh", family, refreshExpiry)
 	if err != nil {
 		return "", "", err
 	}
 
+	// Track the family so reuse of a rotated refresh token can revoke all of it
+	if err := j.tokenService.AddToFamily(ctx, family, refreshExpiry, accessTokenID, refreshTokenID); err != nil {
+		logger.WithError(err).Error("Failed to track refresh token family")
+		return "", "", err
+	}
+
 	// Add both tokens to whitelist
 	if err := j.tokenService.AddToWhitelist(ctx, accessTokenID, time.Duration(j.expiryHours)*time.Hour); err != nil {
 		logger.WithError(err).Error("Failed to whitelist access token")
 		return "", "", err
 	}
 
-	if err := j.tokenService.AddToWhitelist(ctx, refreshTokenID, time.Duration(j.refreshExpiryDays)*24*time.Hour); err != nil {
+	if err := j.tokenService.AddToWhitelist(ctx, refreshTokenID, refreshExpiry); err != nil {
 		logger.WithError(err).Error("Failed to whitelist refresh token")
 		return "", "", err
 	}
@@ -76,7 +128,7 @@ func (j *Service) GenerateTokenPair(ctx context.Context, userID int, username, e
 }
 
 // generateToken creates a new JWT token with unique ID
-func (j *Service) generateToken(userID int, username, email, tokenType string, expiry time.Duration) (string, string, error) {
+func (j *Service) generateToken(userID int, username, email, tokenType, family string, expiry time.Duration) (string, string, error) {
 	tokenID, err := j.generateTokenID()
 	if err != nil {
 		return "", "", err
@@ -87,6 +139,7 @@ func (j *Service) generateToken(userID int, username, email, tokenType string, e
 		Username:  username,
 		Email:     email,
 		TokenType: tokenType,
+		Family:    family,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
@@ -96,29 +149,34 @@ func (j *Service) generateToken(userID int, username, email, tokenType string, e
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(j.secretKey))
+	key, err := j.keys.SigningKey()
+	if err != nil {
+		return "", "", err
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	tokenString, err := token.SignedString(key.Key)
 	return tokenString, tokenID, err
 }
 
+// keyFunc resolves the key verifying a token from its kid and alg headers
+func (j *Service) keyFunc(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return j.keys.VerificationKey(ctx, kid, token.Method.Alg())
+	}
+}
+
 // ValidateToken validates and parses a JWT token with Redis checks
 func (j *Service) ValidateToken(ctx context.Context, tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(j.secretKey), nil
-	})
-
+	claims, err := j.parseToken(ctx, tokenString)
 	if err != nil {
 		return nil, err
 	}
 
-	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid {
-		return nil, errors.New("invalid token")
-	}
-
 	// Check token validity in Redis
 	isValid, err := j.tokenService.IsTokenValid(ctx, claims.ID)
 	if err != nil {
@@ -133,19 +191,123 @@ func (j *Service) ValidateToken(ctx context.Context, tokenString string) (*Claim
 	return claims, nil
 }
 
-// RefreshAccessToken generates a new access token using a refresh token
-func (j *Service) RefreshAccessToken(ctx context.Context, refreshToken string) (string, string, error) {
-	claims, err := j.ValidateToken(ctx, refreshToken)
+// parseToken checks the signature and expiry of a token without Redis checks
+func (j *Service) parseToken(ctx context.Context, tokenString string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, j.keyFunc(ctx))
 	if err != nil {
-		return "", "", err
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+// RefreshAccessToken exchanges a refresh token for a new token pair in the same family and revokes it
+// Presenting a refresh token that was already exchanged means it was copied, so its whole family is revoked
+// Returns the claims of the presented refresh token with the new pair
+func (j *Service) RefreshAccessToken(ctx context.Context, refreshToken string) (string, string, *Claims, error) {
+	claims, err := j.parseToken(ctx, refreshToken)
+	if err != nil {
+		return "", "", nil, err
 	}
 
 	if claims.TokenType != "refresh" {
-		return "", "", errors.New("invalid token type")
+		return "", "", nil, errors.New("invalid token type")
+	}
+
+	isValid, err := j.tokenService.IsTokenValid(ctx, claims.ID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to check token validity in Redis")
+		return "", "", nil, errors.New("token validation failed")
+	}
+	if !isValid {
+		if family, err := j.tokenService.RotatedFamily(ctx, claims.ID); err == nil && family != "" {
+			j.revokeFamily(ctx, claims, family)
+			return "", "", nil, errRefreshTokenReused
+		}
+		return "", "", nil, errors.New("token has been revoked or is not valid")
+	}
+
+	// Tokens issued before refresh token families start one
+	family, newFamily := claims.Family, claims.Family == ""
+	if newFamily {
+		if family, err = j.generateTokenID(); err != nil {
+			return "", "", nil, err
+		}
+	}
+
+	// Only the first exchange of a token wins, a concurrent one counts as reuse
+	ttl := max(time.Until(claims.ExpiresAt.Time), time.Second)
+	first, err := j.tokenService.MarkRotated(ctx, claims.ID, family, ttl)
+	if err != nil {
+		logger.WithError(err).Error("Failed to mark refresh token as rotated")
+		return "", "", nil, errors.New("token refresh failed")
+	}
+	if !first {
+		j.revokeFamily(ctx, claims, family)
+		return "", "", nil, errRefreshTokenReused
+	}
+
+	if err := j.tokenService.RevokeToken(ctx, claims.ID, ttl); err != nil {
+		logger.WithError(err).Error("Failed to revoke rotated refresh token")
+		return "", "", nil, err
 	}
 
 	// Generate new token pair
-	return j.GenerateTokenPair(ctx, claims.UserID, claims.Username, claims.Email)
+	accessToken, newRefreshToken, err := j.generateTokenPair(ctx, claims.UserID, claims.Username, claims.Email, family)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	if newFamily {
+		err = j.startSession(ctx, claims.UserID, family)
+	} else {
+		j.touchSession(ctx, claims.UserID, family)
+	}
+	return accessToken, newRefreshToken, claims, err
+}
+
+// touchSession records the requesting client's activity in a session, starting it for families issued
+// before sessions were tracked; sessions are informational, so failing to update one does not fail the request
+func (j *Service) touchSession(ctx context.Context, userID int, sessionID string) {
+	var ip string
+	if client, ok := pkgcontext.GetClient(ctx); ok {
+		ip = client.IP
+	}
+
+	touched, err := j.tokenService.TouchSession(ctx, sessionID, ip, j.refreshExpiry())
+	if err == nil && !touched {
+		err = j.startSession(ctx, userID, sessionID)
+	}
+	if err != nil {
+		logger.WithError(err).WithField("session_id", sessionID).Warn("Failed to update session")
+	}
+}
+
+// errRefreshTokenReused is returned for a refresh token that was already exchanged
+var errRefreshTokenReused = errors.New("refresh token has already been used")
+
+// revokeFamily revokes every token of a family after reuse of one of its refresh tokens and logs the security event
+func (j *Service) revokeFamily(ctx context.Context, claims *Claims, family string) {
+	revoked, err := j.tokenService.RevokeFamily(ctx, family)
+	entry := logger.WithFields(map[string]interface{}{
+		"event":    "refresh_token_reuse",
+		"user_id":  claims.UserID,
+		"family":   family,
+		"token_id": claims.ID,
+		"revoked":  revoked,
+	})
+	if err == nil {
+		err = j.tokenService.DeleteSession(ctx, claims.UserID, family)
+	}
+	if err != nil {
+		entry.WithError(err).Error("Refresh token reuse detected, failed to revoke token family")
+		return
+	}
+	entry.Warn("Refresh token reuse detected, token family revoked")
 }
 
 // RevokeToken adds a token to the blacklist
@@ -153,9 +315,7 @@ func (j *Service) RevokeToken(ctx context.Context, tokenString string) error {
 	claims, err := j.ValidateToken(ctx, tokenString)
 	if err != nil {
 		// Even if validation fails, try to extract the token ID for blacklisting
-		token, parseErr := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(j.secretKey), nil
-		})
+		token, parseErr := jwt.ParseWithClaims(tokenString, &Claims{}, j.keyFunc(ctx))
 
 		if parseErr != nil {
 			return err // Return original validation error
@@ -180,12 +340,60 @@ func (j *Service) RevokeToken(ctx context.Context, tokenString string) error {
 	return j.tokenService.RevokeToken(ctx, claims.ID, ttl)
 }
 
+// ErrSessionNotFound is returned for a session that expired, was revoked or belongs to another user
+var ErrSessionNotFound = errors.New("session not found")
+
+// Sessions returns the active sessions of a user, most recently used first
+func (j *Service) Sessions(ctx context.Context, userID int) ([]*redis.Session, error) {
+	return j.tokenService.UserSessions(ctx, userID)
+}
+
+// RevokeSession signs a user out of one session by revoking every token issued in it
+func (j *Service) RevokeSession(ctx context.Context, userID int, sessionID string) error {
+	session, err := j.tokenService.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	if _, err := j.tokenService.RevokeFamily(ctx, sessionID); err != nil {
+		return err
+	}
+	return j.tokenService.DeleteSession(ctx, userID, sessionID)
+}
+
 // RevokeAllUserTokens revokes all tokens for a specific user (useful for logout from all devices)
 func (j *Service) RevokeAllUserTokens(ctx context.Context, userID int) error {
-	logger.WithField("user_id", userID).Info("Revoking all user tokens - implement pattern matching if needed")
-	// This is a placeholder - you can implement pattern matching for user tokens
-	// or maintain a separate Redis set for each user's active tokens
-	return nil
+	_, err := j.RevokeUserSessions(ctx, userID, "")
+	return err
+}
+
+// RevokeUserSessions revokes every session of a user except the one with ID except, if any,
+// and returns how many were revoked
+func (j *Service) RevokeUserSessions(ctx context.Context, userID int, except string) (int, error) {
+	sessions, err := j.tokenService.UserSessions(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, session := range sessions {
+		if session.ID == except {
+			continue
+		}
+		if err := j.RevokeSession(ctx, userID, session.ID); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+
+	logger.WithFields(map[string]interface{}{
+		"user_id":  userID,
+		"sessions": revoked,
+	}).Info("Revoked user sessions")
+	return revoked, nil
 }
 
 // HashPassword hashes a plain text password
@@ -0,0 +1,152 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/saurabh/entgo-microservices/pkg/logger"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMain(m *testing.M) {
+	logger.Logger = logrus.New()
+	logger.Logger.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
+
+// newTestService creates a token service backed by an in-memory Redis
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewService("test-secret", 1, client, "auth")
+}
+
+func TestRefreshRotatesTokens(t *testing.T) {
+	ctx := context.Background()
+	j := newTestService(t)
+
+	_, refresh, err := j.GenerateTokenPair(ctx, 7, "alice", "alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	access, rotated, claims, err := j.RefreshAccessToken(ctx, refresh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserID != 7 {
+		t.Fatalf("expected the claims of user 7, got %d", claims.UserID)
+	}
+
+	newClaims, err := j.ValidateToken(ctx, access)
+	if err != nil {
+		t.Fatalf("the new access token is not valid: %v", err)
+	}
+	if newClaims.Family != claims.Family {
+		t.Fatal("expected the new tokens in the family of the exchanged one")
+	}
+	if _, err := j.ValidateToken(ctx, refresh); err == nil {
+		t.Fatal("expected the exchanged refresh token to be revoked")
+	}
+	if _, _, _, err := j.RefreshAccessToken(ctx, rotated); err != nil {
+		t.Fatalf("the new refresh token cannot be exchanged: %v", err)
+	}
+
+	sessions, err := j.Sessions(ctx, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != claims.Family {
+		t.Fatalf("expected the family as the only session, got %+v", sessions)
+	}
+}
+
+func TestRefreshReuseRevokesFamily(t *testing.T) {
+	ctx := context.Background()
+	j := newTestService(t)
+
+	_, stolen, err := j.GenerateTokenPair(ctx, 7, "alice", "alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	access, refresh, _, err := j.RefreshAccessToken(ctx, stolen)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A second exchange of the same token reveals that it was copied
+	if _, _, _, err := j.RefreshAccessToken(ctx, stolen); !errors.Is(err, errRefreshTokenReused) {
+		t.Fatalf("expected reuse to be detected, got %v", err)
+	}
+
+	if _, err := j.ValidateToken(ctx, access); err == nil {
+		t.Fatal("expected the access token of the family to be revoked")
+	}
+	if _, _, _, err := j.RefreshAccessToken(ctx, refresh); err == nil {
+		t.Fatal("expected the latest refresh token of the family to be revoked")
+	}
+	sessions, err := j.Sessions(ctx, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("expected the session to be deleted, got %d", len(sessions))
+	}
+
+	// Other families of the user are untouched
+	other, _, err := j.GenerateTokenPair(ctx, 7, "alice", "alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := j.ValidateToken(ctx, other); err != nil {
+		t.Fatalf("a new login was affected by the revoked family: %v", err)
+	}
+}
+
+func TestConcurrentRefreshFirstWins(t *testing.T) {
+	ctx := context.Background()
+	j := newTestService(t)
+
+	_, refresh, err := j.GenerateTokenPair(ctx, 7, "alice", "alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const attempts = 8
+	errs := make([]error, attempts)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, _, errs[i] = j.RefreshAccessToken(ctx, refresh)
+		}(i)
+	}
+	wg.Wait()
+
+	won := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			won++
+		case errors.Is(err, errRefreshTokenReused):
+		default:
+			// A late attempt may find the token already revoked by the winner
+			if err.Error() != "token has been revoked or is not valid" {
+				t.Fatalf("unexpected error %v", err)
+			}
+		}
+	}
+	if won != 1 {
+		t.Fatalf("expected exactly one exchange to win, got %d", won)
+	}
+}
@@ -0,0 +1,67 @@
+package mail
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/saurabh/entgo-microservices/pkg/logger"
+)
+
+// FileMailer writes each email to a .eml file instead of sending it, for development
+type FileMailer struct {
+	from string
+	dir  string
+}
+
+// NewFileMailer creates a mailer writing emails to dir, creating it if needed
+func NewFileMailer(from, dir string) (*FileMailer, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("file mailer requires a directory")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileMailer{from: from, dir: dir}, nil
+}
+
+// Send writes a message to {dir}/{time}-{random}.eml
+func (m *FileMailer) Send(_ context.Context, msg *Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	now := time.Now()
+	filename := filepath.Join(m.dir, fmt.Sprintf("%s-%s.eml", now.Format("20060102-150405"), hex.EncodeToString(suffix)))
+
+	// Messages carry single-use tokens, so only the owner can read them
+	if err := os.WriteFile(filename, format(m.from, msg, now), 0o600); err != nil {
+		return err
+	}
+
+	logger.WithFields(map[string]interface{}{"to": msg.To, "subject": msg.Subject, "file": filename}).Info("Email written to file")
+	return nil
+}
+
+// LogMailer logs emails instead of sending them, for development
+type LogMailer struct{}
+
+// NewLogMailer creates a mailer logging every email, body included
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+// Send logs a message
+func (m *LogMailer) Send(_ context.Context, msg *Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+	logger.WithFields(map[string]interface{}{"to": msg.To, "subject": msg.Subject, "body": msg.Body}).Info("Email")
+	return nil
+}
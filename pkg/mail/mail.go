@@ -0,0 +1,75 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Config selects and configures a mailer
+type Config struct {
+	Driver   string // smtp, file, log or memory
+	From     string // Sender address
+	Host     string // SMTP server
+	Port     int    // SMTP port, usually 587 with STARTTLS
+	Username string // SMTP username, empty to send without authentication
+	Password string // SMTP password
+	Dir      string // Directory the file driver writes .eml files to
+}
+
+// New creates the mailer of the configured driver
+func New(cfg Config) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		if cfg.Host == "" {
+			return nil, fmt.Errorf("smtp mailer requires a host")
+		}
+		return NewSMTPMailer(cfg), nil
+	case "file":
+		return NewFileMailer(cfg.From, cfg.Dir)
+	case "log":
+		return NewLogMailer(), nil
+	case "memory":
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q, expected smtp, file, log or memory", cfg.Driver)
+	}
+}
+
+// format renders a message as RFC 5322 text
+func format(from string, msg *Message, date time.Time) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// validate rejects messages whose headers could inject other headers
+func validate(msg *Message) error {
+	if msg.To == "" {
+		return fmt.Errorf("message has no recipient")
+	}
+	if strings.ContainsAny(msg.To+msg.Subject, "\r\n") {
+		return fmt.Errorf("message headers cannot contain line breaks")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package mail
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPMailer sends emails through an SMTP server, upgrading to TLS when it offers STARTTLS
+type SMTPMailer struct {
+	from string
+	addr string
+	auth smtp.Auth // nil without a username
+}
+
+// NewSMTPMailer creates a mailer sending through the configured SMTP server
+func NewSMTPMailer(cfg Config) *SMTPMailer {
+	m := &SMTPMailer{
+		from: cfg.From,
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+	}
+	if cfg.Username != "" {
+		m.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return m
+}
+
+// Send delivers a message; the context only bounds waiting for the server to respond
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, format(m.from, msg, time.Now()))
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
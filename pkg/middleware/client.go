@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	pkgcontext "github.com/saurabh/entgo-microservices/pkg/context"
+)
+
+// Client middleware records the requesting device in the request context, e.g. for the sessions it logs into
+// The IP follows X-Forwarded-For only from proxies trusted with gin's SetTrustedProxies, such as the gateway
+func Client() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := pkgcontext.SetClient(c.Request.Context(), &pkgcontext.Client{
+			UserAgent: c.Request.UserAgent(),
+			IP:        c.ClientIP(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
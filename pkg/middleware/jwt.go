@@ -7,17 +7,20 @@ import (
 	"net/http"
 	"strings"
 
+	gojwt "github.com/golang-jwt/jwt/v5"
 	"github.com/redis/go-redis/v9"
 	pkgcontext "github.com/saurabh/entgo-microservices/pkg/context"
+	"github.com/saurabh/entgo-microservices/pkg/identity"
 	"github.com/saurabh/entgo-microservices/pkg/jwt"
 	"github.com/saurabh/entgo-microservices/pkg/logger"
 )
 
 // JWTAuthMiddleware validates JWT tokens and adds user to context from Redis cache
 type JWTAuthMiddleware struct {
-	jwtService  *jwt.Service
-	redisClient *redis.Client
-	serviceName string
+	jwtService     *jwt.Service
+	redisClient    *redis.Client
+	serviceName    string
+	identitySecret []byte // Shared with the gateway; nil ignores gateway identities
 }
 
 // NewJWTAuthMiddleware creates a new JWT authentication middleware
@@ -29,6 +32,14 @@ func NewJWTAuthMiddleware(jwtService *jwt.Service, redisClient *redis.Client, se
 	}
 }
 
+// TrustGateway accepts identities signed by the gateway with the shared secret in place of re-validating the token
+func (m *JWTAuthMiddleware) TrustGateway(secret string) *JWTAuthMiddleware {
+	if secret != "" {
+		m.identitySecret = []byte(secret)
+	}
+	return m
+}
+
 // buildUserKey creates Redis key for user data
 func (m *JWTAuthMiddleware) buildUserKey(userID int) string {
 	return fmt.Sprintf("%s:user:%d", m.serviceName, userID)
@@ -61,14 +72,21 @@ func (m *JWTAuthMiddleware) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Extract token from Authorization header
 		authHeader := r.Header.Get("Authorization")
+
+		// Remove "Bearer " prefix if present
+		token := strings.TrimPrefix(authHeader, "Bearer ")
+
+		// Requests from the gateway carry an identity it already validated and checked for revocation
+		if claims := m.gatewayClaims(r); claims != nil {
+			m.serveUser(w, r, next, claims, token)
+			return
+		}
+
 		if authHeader == "" {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		// Remove "Bearer " prefix if present
-		token := strings.TrimPrefix(authHeader, "Bearer ")
-
 		// Validate token
 		claims, err := m.jwtService.ValidateToken(r.Context(), token)
 		if err != nil {
@@ -84,43 +102,68 @@ func (m *JWTAuthMiddleware) Middleware(next http.Handler) http.Handler {
 			return
 		}
 
-		// Get user from Redis cache
-		cachedData, err := m.GetUserFromCache(r.Context(), claims.UserID)
-		if err != nil {
-			logger.WithError(err).WithField("user_id", claims.UserID).Debug("User not found in cache")
-			next.ServeHTTP(w, r)
-			return
-		}
+		m.serveUser(w, r, next, claims, token)
+	})
+}
 
-		if cachedData == nil || cachedData.User == nil {
-			logger.WithField("user_id", claims.UserID).Debug("User data is nil in cache")
-			next.ServeHTTP(w, r)
-			return
-		}
+// gatewayClaims returns the claims of a valid gateway identity header, or nil
+func (m *JWTAuthMiddleware) gatewayClaims(r *http.Request) *jwt.Claims {
+	value := r.Header.Get(identity.Header)
+	if value == "" || m.identitySecret == nil {
+		return nil
+	}
 
-		user := cachedData.User
+	id, err := identity.Verify(value, m.identitySecret)
+	if err != nil {
+		logger.WithError(err).Debug("Gateway identity rejected, validating token")
+		return nil
+	}
 
-		// Check if user is active
-		if !user.IsActive {
-			logger.WithField("user_id", user.ID).Debug("User account is deactivated")
-			next.ServeHTTP(w, r)
-			return
-		}
+	return &jwt.Claims{
+		UserID:           id.UserID,
+		TokenType:        "access",
+		RegisteredClaims: gojwt.RegisteredClaims{ID: id.TokenID},
+	}
+}
 
-		logger.WithFields(map[string]interface{}{
-			"user_id": user.ID,
-			"email":   user.Email,
-		}).Debug("User authenticated from cache")
+// serveUser loads the authenticated user from Redis cache and adds it to the request context
+func (m *JWTAuthMiddleware) serveUser(w http.ResponseWriter, r *http.Request, next http.Handler, claims *jwt.Claims, token string) {
+	// Get user from Redis cache
+	cachedData, err := m.GetUserFromCache(r.Context(), claims.UserID)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", claims.UserID).Debug("User not found in cache")
+		next.ServeHTTP(w, r)
+		return
+	}
 
-		// Add user to context
-		ctx := pkgcontext.SetUser(r.Context(), user)
-		ctx = pkgcontext.SetClaims(ctx, claims)
-		ctx = pkgcontext.SetToken(ctx, token)
+	if cachedData == nil || cachedData.User == nil {
+		logger.WithField("user_id", claims.UserID).Debug("User data is nil in cache")
+		next.ServeHTTP(w, r)
+		return
+	}
 
-		// Store cached data in context for authorization checks
-		ctx = pkgcontext.SetCachedUserData(ctx, cachedData)
+	user := cachedData.User
 
-		r = r.WithContext(ctx)
+	// Check if user is active
+	if !user.IsActive {
+		logger.WithField("user_id", user.ID).Debug("User account is deactivated")
 		next.ServeHTTP(w, r)
-	})
+		return
+	}
+
+	logger.WithFields(map[string]interface{}{
+		"user_id": user.ID,
+		"email":   user.Email,
+	}).Debug("User authenticated from cache")
+
+	// Add user to context
+	ctx := pkgcontext.SetUser(r.Context(), user)
+	ctx = pkgcontext.SetClaims(ctx, claims)
+	ctx = pkgcontext.SetToken(ctx, token)
+
+	// Store cached data in context for authorization checks
+	ctx = pkgcontext.SetCachedUserData(ctx, cachedData)
+
+	r = r.WithContext(ctx)
+	next.ServeHTTP(w, r)
 }
@@ -0,0 +1,65 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SaveOneTimeToken stores a single-use token for a purpose, such as a password reset, with the value it redeems for
+// Only a hash of the token is stored, so reading Redis does not reveal usable tokens
+func (r *TokenService) SaveOneTimeToken(ctx context.Context, purpose, token, value string, expiry time.Duration) error {
+	return r.client.Set(ctx, r.oneTimeKey(purpose, token), value, expiry).Err()
+}
+
+// ConsumeOneTimeToken redeems a single-use token and deletes it, returning "" when it expired, was used or never existed
+func (r *TokenService) ConsumeOneTimeToken(ctx context.Context, purpose, token string) (string, error) {
+	value, err := r.client.GetDel(ctx, r.oneTimeKey(purpose, token)).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return value, err
+}
+
+// oneTimeKey builds the key of a single-use token from its hash
+func (r *TokenService) oneTimeKey(purpose, token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return r.buildKey(purpose, hex.EncodeToString(hash[:]))
+}
+
+// PeekOneTimeToken returns the value of a single-use token without redeeming it, or "" when it does not exist
+func (r *TokenService) PeekOneTimeToken(ctx context.Context, purpose, token string) (string, error) {
+	value, err := r.client.Get(ctx, r.oneTimeKey(purpose, token)).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return value, err
+}
+
+// FailOneTimeToken counts a failed attempt to use a single-use token and deletes the token once maxAttempts
+// have failed, returning true when it did
+func (r *TokenService) FailOneTimeToken(ctx context.Context, purpose, token string, maxAttempts int, expiry time.Duration) (bool, error) {
+	key := r.oneTimeKey(purpose, token)
+	attemptsKey := key + ":attempts"
+
+	pipe := r.client.TxPipeline()
+	attempts := pipe.Incr(ctx, attemptsKey)
+	pipe.Expire(ctx, attemptsKey, expiry)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+
+	if attempts.Val() < int64(maxAttempts) {
+		return false, nil
+	}
+	return true, r.client.Del(ctx, key, attemptsKey).Err()
+}
+
+// MarkUsed records that a value was used for a purpose, such as a one-time password, and returns false
+// when it already was within expiry
+func (r *TokenService) MarkUsed(ctx context.Context, purpose, value string, expiry time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, r.buildKey(purpose, value), "used", expiry).Result()
+}
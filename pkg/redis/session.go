@@ -0,0 +1,133 @@
+package redis
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Session is a login of a user on a device, identified by its refresh token family
+type Session struct {
+	ID        string    `json:"id"`
+	UserID    int       `json:"user_id"`
+	UserAgent string    `json:"user_agent"`
+	IP        string    `json:"ip"`
+	IssuedAt  time.Time `json:"issued_at"`
+	LastSeen  time.Time `json:"last_seen"`
+	TokenIDs  []string  `json:"token_ids"` // Tokens issued in the session's family
+}
+
+// SaveSession stores a session and adds it to its user's session index, both kept for expiry
+func (r *TokenService) SaveSession(ctx context.Context, session *Session, expiry time.Duration) error {
+	key := r.buildKey("session", session.ID)
+	userKey := r.buildKey("user_sessions", strconv.Itoa(session.UserID))
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key,
+		"user_id", session.UserID,
+		"user_agent", session.UserAgent,
+		"ip", session.IP,
+		"issued_at", session.IssuedAt.Unix(),
+		"last_seen", session.LastSeen.Unix(),
+	)
+	pipe.Expire(ctx, key, expiry)
+	pipe.SAdd(ctx, userKey, session.ID)
+	// The index lives as long as the newest session, expired members are dropped when it is read
+	pipe.Expire(ctx, userKey, expiry)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// TouchSession records activity in a session from ip and extends it for expiry
+// It returns false when the session no longer exists
+func (r *TokenService) TouchSession(ctx context.Context, sessionID, ip string, expiry time.Duration) (bool, error) {
+	key := r.buildKey("session", sessionID)
+
+	userID, err := r.client.HGet(ctx, key, "user_id").Result()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	userKey := r.buildKey("user_sessions", userID)
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, "last_seen", time.Now().Unix())
+	if ip != "" {
+		pipe.HSet(ctx, key, "ip", ip)
+	}
+	pipe.Expire(ctx, key, expiry)
+	// The index must outlive every session in it, or revoking all of a user's sessions would miss this one
+	pipe.SAdd(ctx, userKey, sessionID)
+	pipe.Expire(ctx, userKey, expiry)
+	_, err = pipe.Exec(ctx)
+	return err == nil, err
+}
+
+// GetSession returns a session with the tokens of its family, or nil when it does not exist
+func (r *TokenService) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	fields, err := r.client.HGetAll(ctx, r.buildKey("session", sessionID)).Result()
+	if err != nil || len(fields) == 0 {
+		return nil, err
+	}
+
+	userID, _ := strconv.Atoi(fields["user_id"])
+	issuedAt, _ := strconv.ParseInt(fields["issued_at"], 10, 64)
+	lastSeen, _ := strconv.ParseInt(fields["last_seen"], 10, 64)
+
+	tokenIDs, err := r.client.SMembers(ctx, r.buildKey("family", sessionID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		ID:        sessionID,
+		UserID:    userID,
+		UserAgent: fields["user_agent"],
+		IP:        fields["ip"],
+		IssuedAt:  time.Unix(issuedAt, 0),
+		LastSeen:  time.Unix(lastSeen, 0),
+		TokenIDs:  tokenIDs,
+	}, nil
+}
+
+// UserSessions returns the sessions of a user, most recently used first, dropping expired ones from the index
+func (r *TokenService) UserSessions(ctx context.Context, userID int) ([]*Session, error) {
+	userKey := r.buildKey("user_sessions", strconv.Itoa(userID))
+	sessionIDs, err := r.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*Session, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		session, err := r.GetSession(ctx, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if session == nil {
+			r.client.SRem(ctx, userKey, sessionID)
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	// Most recently used first
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeen.After(sessions[j].LastSeen)
+	})
+	return sessions, nil
+}
+
+// DeleteSession removes a session and its entry in the user's session index
+func (r *TokenService) DeleteSession(ctx context.Context, userID int, sessionID string) error {
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, r.buildKey("session", sessionID))
+	pipe.SRem(ctx, r.buildKey("user_sessions", strconv.Itoa(userID)), sessionID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
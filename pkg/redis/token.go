@@ -100,6 +100,62 @@ func (r *TokenService) IsTokenValid(ctx context.Context, tokenID string) (bool,
 	return whitelisted, nil
 }
 
+// AddToFamily records tokens issued in a refresh token family and keeps the family for expiry
+func (r *TokenService) AddToFamily(ctx context.Context, familyID string, expiry time.Duration, tokenIDs ...string) error {
+	key := r.buildKey("family", familyID)
+	members := make([]interface{}, len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		members[i] = tokenID
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.SAdd(ctx, key, members...)
+	pipe.Expire(ctx, key, expiry)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// MarkRotated records that a refresh token was exchanged, returning false when it already was
+func (r *TokenService) MarkRotated(ctx context.Context, tokenID, familyID string, expiry time.Duration) (bool, error) {
+	key := r.buildKey("rotated", tokenID)
+	return r.client.SetNX(ctx, key, familyID, expiry).Result()
+}
+
+// RotatedFamily returns the family of a refresh token that was already exchanged, or "" when it was not
+func (r *TokenService) RotatedFamily(ctx context.Context, tokenID string) (string, error) {
+	key := r.buildKey("rotated", tokenID)
+	familyID, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return familyID, err
+}
+
+// RevokeFamily revokes every token issued in a refresh token family and returns how many there were
+func (r *TokenService) RevokeFamily(ctx context.Context, familyID string) (int, error) {
+	key := r.buildKey("family", familyID)
+
+	// The family outlives each of its tokens, so its TTL covers their remaining lifetime
+	expiry, err := r.client.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	tokenIDs, err := r.client.SMembers(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if expiry <= 0 || len(tokenIDs) == 0 {
+		return 0, nil
+	}
+
+	for _, tokenID := range tokenIDs {
+		if err := r.RevokeToken(ctx, tokenID, expiry); err != nil {
+			return 0, err
+		}
+	}
+	return len(tokenIDs), r.client.Del(ctx, key).Err()
+}
+
 // CleanupExpiredTokens removes expired tokens from both lists (optional cleanup)
 func (r *TokenService) CleanupExpiredTokens(ctx context.Context) error {
 	// Redis automatically handles TTL expiration, so this is mainly for monitoring
@@ -0,0 +1,83 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 key of the RFC 6238 test vectors, "12345678901234567890", base32 encoded
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// rfcVectors are the SHA1 test vectors of RFC 6238 appendix B, truncated to the last 6 of their 8 digits
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestCode(t *testing.T) {
+	for _, v := range rfcVectors {
+		code, err := Code(rfcSecret, time.Unix(v.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != v.code {
+			t.Errorf("Code at %d = %s, want %s", v.unix, code, v.code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, v := range rfcVectors {
+		at := time.Unix(v.unix, 0)
+		step := v.unix / int64(Period.Seconds())
+
+		tests := []struct {
+			name     string
+			secret   string
+			passcode string
+			t        time.Time
+			valid    bool
+		}{
+			{"current step", rfcSecret, v.code, at, true},
+			{"one step later", rfcSecret, v.code, at.Add(Period), true},
+			{"one step earlier", rfcSecret, v.code, at.Add(-Period), true},
+			{"two steps later", rfcSecret, v.code, at.Add(2 * Period), false},
+			{"two steps earlier", rfcSecret, v.code, at.Add(-2 * Period), false},
+			{"surrounding spaces", rfcSecret, " " + v.code + " ", at, true},
+			{"secret as apps show it", "gezd gnbv gy3t qojq gezd gnbv gy3t qojq", v.code, at, true},
+			{"truncated code", rfcSecret, v.code[1:], at, false},
+			{"invalid secret", "not base32!", v.code, at, false},
+		}
+		for _, tt := range tests {
+			// Steps before the epoch do not exist
+			if tt.t.Unix() < 0 {
+				continue
+			}
+			matched, ok := Validate(tt.secret, tt.passcode, tt.t)
+			if ok != tt.valid {
+				t.Errorf("%d, %s: Validate = %v, want %v", v.unix, tt.name, ok, tt.valid)
+				continue
+			}
+			// The matched step is the one the code belongs to, not the step of the validation time
+			if ok && matched != step {
+				t.Errorf("%d, %s: matched step %d, want %d", v.unix, tt.name, matched, step)
+			}
+		}
+	}
+}
+
+func TestValidateRejectsOtherCodes(t *testing.T) {
+	at := time.Unix(1234567890, 0)
+	for _, code := range []string{"005925", "000000", "00592", "0059245", "abcdef"} {
+		if _, ok := Validate(rfcSecret, code, at); ok {
+			t.Errorf("Validate accepted %q", code)
+		}
+	}
+}